@@ -29,6 +29,13 @@ func approveTokens(ctx *cli.Context) error {
 
 	addrKeyStorePath := ctx.String(config.FlagKeyStorePath)
 	addrPassword := ctx.String(config.FlagPassword)
+	signerAddress := ctx.String(config.FlagSignerAddress)
+	if addrKeyStorePath == "" && signerAddress == "" {
+		return fmt.Errorf("either the %s or the %s flag must be provided", config.FlagKeyStorePath, config.FlagSignerAddress)
+	}
+	if signerAddress != "" && !common.IsHexAddress(signerAddress) {
+		return fmt.Errorf("invalid signer address: %s", signerAddress)
+	}
 
 	c, err := config.Load(ctx, true)
 	if err != nil {
@@ -57,14 +64,25 @@ func approveTokens(ctx *cli.Context) error {
 		return err
 	}
 
-	// load auth from keystore file
-	auth, err := etherman.LoadAuthFromKeyStore(addrKeyStorePath, addrPassword)
-	if err != nil {
-		log.Fatal(err)
-		return err
+	var from common.Address
+	if signerAddress != "" {
+		// XLayer load the configured signer of the address
+		from, err = loadApproveSignerXLayer(*c, etherman, common.HexToAddress(signerAddress))
+		if err != nil {
+			log.Fatal(err)
+			return err
+		}
+	} else {
+		// load auth from keystore file
+		auth, err := etherman.LoadAuthFromKeyStore(addrKeyStorePath, addrPassword)
+		if err != nil {
+			log.Fatal(err)
+			return err
+		}
+		from = auth.From
 	}
 
-	tx, err := etherman.ApprovePol(ctx.Context, auth.From, amount, c.NetworkConfig.L1Config.ZkEVMAddr)
+	tx, err := etherman.ApprovePol(ctx.Context, from, amount, c.NetworkConfig.L1Config.ZkEVMAddr)
 	if err != nil {
 		return err
 	}
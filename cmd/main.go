@@ -72,7 +72,7 @@ var (
 	httpAPIFlag = cli.StringSliceFlag{
 		Name:     config.FlagHTTPAPI,
 		Aliases:  []string{"ha"},
-		Usage:    fmt.Sprintf("List of JSON RPC apis to be exposed by the server: --http.api=%v,%v,%v,%v,%v,%v", jsonrpc.APIEth, jsonrpc.APINet, jsonrpc.APIDebug, jsonrpc.APIZKEVM, jsonrpc.APITxPool, jsonrpc.APIWeb3),
+		Usage:    fmt.Sprintf("List of JSON RPC apis to be exposed by the server: --http.api=%v,%v,%v,%v,%v,%v,%v,%v", jsonrpc.APIEth, jsonrpc.APINet, jsonrpc.APIDebug, jsonrpc.APIZKEVM, jsonrpc.APITxPool, jsonrpc.APIWeb3, jsonrpc.APITrace, jsonrpc.APIOts),
 		Required: false,
 		Value:    cli.NewStringSlice(jsonrpc.APIEth, jsonrpc.APINet, jsonrpc.APIZKEVM, jsonrpc.APITxPool, jsonrpc.APIWeb3),
 	}
@@ -128,13 +128,19 @@ func main() {
 					Name:     config.FlagKeyStorePath,
 					Aliases:  []string{""},
 					Usage:    "the path of the key store file containing the private key of the account going to sign and approve the tokens",
-					Required: true,
+					Required: false,
 				},
 				&cli.StringFlag{
 					Name:     config.FlagPassword,
 					Aliases:  []string{"pw"},
 					Usage:    "the password do decrypt the key store file",
-					Required: true,
+					Required: false,
+				},
+				&cli.StringFlag{
+					Name:     config.FlagSignerAddress,
+					Aliases:  []string{"sa"},
+					Usage:    "the address of the EthTxManager signer going to sign and approve the tokens, instead of the key store file",
+					Required: false,
 				},
 				&cli.StringFlag{
 					Name:     config.FlagAmount,
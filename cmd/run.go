@@ -386,11 +386,19 @@ func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64
 		log.Debug("SequencerNodeURI ", c.RPC.SequencerNodeURI)
 	}
 
+	// XLayer the ots endpoints load the internal txs through the eth endpoints
+	var ethEndpoints *jsonrpc.EthEndpoints
+	_, ethEnabled := apis[jsonrpc.APIEth]
+	_, otsEnabled := apis[jsonrpc.APIOts]
+	if ethEnabled || otsEnabled {
+		ethEndpoints = jsonrpc.NewEthEndpoints(c.RPC, chainID, pool, st, etherman, storage)
+	}
+
 	services := []jsonrpc.Service{}
-	if _, ok := apis[jsonrpc.APIEth]; ok {
+	if ethEnabled {
 		services = append(services, jsonrpc.Service{
 			Name:    jsonrpc.APIEth,
-			Service: jsonrpc.NewEthEndpoints(c.RPC, chainID, pool, st, etherman, storage),
+			Service: ethEndpoints,
 		})
 	}
 
@@ -411,7 +419,14 @@ func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64
 	if _, ok := apis[jsonrpc.APITxPool]; ok {
 		services = append(services, jsonrpc.Service{
 			Name:    jsonrpc.APITxPool,
-			Service: &jsonrpc.TxPoolEndpoints{},
+			Service: jsonrpc.NewTxPoolEndpoints(c.RPC, pool),
+		})
+	}
+
+	if _, ok := apis[jsonrpc.APIAdmin]; ok {
+		services = append(services, jsonrpc.Service{
+			Name:    jsonrpc.APIAdmin,
+			Service: jsonrpc.NewAdminEndpoints(c.RPC, pool),
 		})
 	}
 
@@ -422,6 +437,20 @@ func runJSONRPCServer(c config.Config, etherman *etherman.Client, chainID uint64
 		})
 	}
 
+	if _, ok := apis[jsonrpc.APITrace]; ok {
+		services = append(services, jsonrpc.Service{
+			Name:    jsonrpc.APITrace,
+			Service: jsonrpc.NewTraceEndpoints(c.RPC, st, etherman),
+		})
+	}
+
+	if otsEnabled {
+		services = append(services, jsonrpc.Service{
+			Name:    jsonrpc.APIOts,
+			Service: jsonrpc.NewOtsEndpoints(c.RPC, st, etherman, ethEndpoints),
+		})
+	}
+
 	if _, ok := apis[jsonrpc.APIWeb3]; ok {
 		services = append(services, jsonrpc.Service{
 			Name:    jsonrpc.APIWeb3,
@@ -591,6 +620,8 @@ func createEthTxManager(cfg config.Config, etmStorage *ethtxmanager.PostgresStor
 			log.Fatal(err)
 		}
 	}
+	// XLayer signers of the L1 txs
+	loadSignersXLayer(cfg, etherman)
 	etm := ethtxmanager.New(cfg.EthTxManager, etherman, etmStorage, st)
 	return etm
 }
@@ -8,8 +8,10 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/config"
 	"github.com/0xPolygonHermez/zkevm-node/config/apollo"
 	"github.com/0xPolygonHermez/zkevm-node/dataavailability"
+	"github.com/0xPolygonHermez/zkevm-node/dataavailability/blob"
 	"github.com/0xPolygonHermez/zkevm-node/dataavailability/datacommittee"
 	"github.com/0xPolygonHermez/zkevm-node/etherman"
+	"github.com/0xPolygonHermez/zkevm-node/etherman/signer"
 	"github.com/0xPolygonHermez/zkevm-node/ethtxmanager"
 	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc"
@@ -42,6 +44,8 @@ func createSequenceSenderXLayer(cfg config.Config, pool *pool.Pool, etmStorage *
 	}
 	log.Infof("from pk %s, from sender %s", crypto.PubkeyToAddress(privKey.PublicKey), cfg.SequenceSender.SenderAddress.String()) //nolint:staticcheck
 
+	loadSignersXLayer(cfg, etherman)
+
 	cfg.SequenceSender.ForkUpgradeBatchNumber = cfg.ForkUpgradeBatchNumber
 
 	ethTxManager := ethtxmanager.New(cfg.EthTxManager, etherman, etmStorage, st)
@@ -54,6 +58,34 @@ func createSequenceSenderXLayer(cfg config.Config, pool *pool.Pool, etmStorage *
 	return seqSender
 }
 
+// loadSignersXLayer loads the signers of the L1 txs in the etherman, replacing the key store
+// files of the same address
+func loadSignersXLayer(cfg config.Config, etherman *etherman.Client) {
+	for _, signerCfg := range cfg.EthTxManager.Signers {
+		if _, err := etherman.LoadSigner(signerCfg); err != nil {
+			log.Fatalf("failed to load %s signer: %v", signerCfg.Type, err)
+		}
+	}
+}
+
+// loadApproveSignerXLayer loads the configured signer of the address used to approve the tokens
+func loadApproveSignerXLayer(cfg config.Config, etherman *etherman.Client, address common.Address) (common.Address, error) {
+	for _, signerCfg := range cfg.EthTxManager.Signers {
+		if signerCfg.Address != (common.Address{}) && signerCfg.Address != address {
+			continue
+		}
+		s, err := signer.New(signerCfg, cfg.NetworkConfig.L1Config.L1ChainID)
+		if err != nil {
+			return common.Address{}, err
+		}
+		if s.Address() == address {
+			etherman.AddSigner(s)
+			return address, nil
+		}
+	}
+	return common.Address{}, fmt.Errorf("signer not configured for address %s", address)
+}
+
 func initRunForXLayer(c *config.Config, components []string) {
 	// Read configure from apollo
 	apolloClient := apollo.NewClient(c)
@@ -96,6 +128,11 @@ func newDataAvailability(c config.Config, st *state.State, etherman *etherman.Cl
 	if err != nil {
 		return nil, fmt.Errorf("error getting data availability protocol name: %v", err)
 	}
+	// The configured backend must be the protocol of the DA contract, the sequences are read and
+	// verified against it
+	if c.DataAvailability.Backend != "" && string(c.DataAvailability.Backend) != daProtocolName {
+		return nil, fmt.Errorf("the configured data availability backend %s doesn't match the protocol %s of the DA contract", c.DataAvailability.Backend, daProtocolName)
+	}
 	var daBackend dataavailability.DABackender
 	switch daProtocolName {
 	case string(dataavailability.DataAvailabilityCommittee):
@@ -123,6 +160,11 @@ func newDataAvailability(c config.Config, st *state.State, etherman *etherman.Cl
 		if err != nil {
 			return nil, err
 		}
+	case string(dataavailability.EIP4844Blob):
+		daBackend, err = blob.New(c.DataAvailability.Blob)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, fmt.Errorf("unexpected / unsupported DA protocol: %s", daProtocolName)
 	}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/0xPolygonHermez/zkevm-node/aggregator"
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/dataavailability"
 	"github.com/0xPolygonHermez/zkevm-node/db"
 	"github.com/0xPolygonHermez/zkevm-node/etherman"
 	"github.com/0xPolygonHermez/zkevm-node/ethtxmanager"
@@ -56,6 +57,8 @@ const (
 	FlagMaxAmount = "max-amount"
 	// FlagDocumentationFileType is the flag for the choose which file generate json-schema
 	FlagDocumentationFileType = "config-file"
+	// FlagSignerAddress is the address of the configured L1 signer going to sign and approve the tokens
+	FlagSignerAddress = "signer-address"
 )
 
 /*
@@ -122,6 +125,8 @@ type Config struct {
 	Apollo types.ApolloConfig
 	// ForceBatchAddress Address of the L1 ForceBatch contract
 	Fork9UpgradeBatch uint64 `mapstructure:"Fork9UpgradeBatch"`
+	// Configuration of the data availability backend
+	DataAvailability dataavailability.Config
 }
 
 // Default parses the default configuration values.
@@ -181,6 +186,10 @@ func Load(ctx *cli.Context, loadNetworkConfig bool) (*Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	// XLayer
+	if err = cfg.validateXLayer(); err != nil {
+		return nil, err
+	}
 
 	if loadNetworkConfig {
 		// Load genesis parameters
@@ -185,6 +185,38 @@ func Test_Defaults(t *testing.T) {
 			path:          "Sequencer.StreamServer.Enabled",
 			expectedValue: false,
 		},
+		{
+			path:          "Sequencer.WorkerSnapshot.Enabled",
+			expectedValue: false,
+		},
+		{
+			path:          "Sequencer.WorkerSnapshot.Filename",
+			expectedValue: "/datastreamer/worker_snapshot.json",
+		},
+		{
+			path:          "Sequencer.WorkerSnapshot.Interval",
+			expectedValue: types.NewDuration(10 * time.Second),
+		},
+		{
+			path:          "Sequencer.OrderingPolicy.Type",
+			expectedValue: "gasprice",
+		},
+		{
+			path:          "Sequencer.OrderingPolicy.MaxTxsPerSender",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "Sequencer.OrderingPolicy.ReservedResourcesPct",
+			expectedValue: uint32(0),
+		},
+		{
+			path:          "Sequencer.Preconfirmation.Host",
+			expectedValue: "127.0.0.1",
+		},
+		{
+			path:          "Sequencer.Preconfirmation.AuthKeys",
+			expectedValue: []string{},
+		},
 		{
 			path:          "SequenceSender.WaitPeriodSendSequence",
 			expectedValue: types.NewDuration(5 * time.Second),
@@ -398,6 +430,18 @@ func Test_Defaults(t *testing.T) {
 			path:          "Pool.DB.MaxConns",
 			expectedValue: 200,
 		},
+		{
+			path:          "Pool.Quota.MaxPendingTxsPerSender",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "Pool.Quota.MaxPendingTxsPerIP",
+			expectedValue: uint64(0),
+		},
+		{
+			path:          "Pool.Quota.GlobalSlots",
+			expectedValue: uint64(0),
+		},
 		{
 			path:          "RPC.Host",
 			expectedValue: "0.0.0.0",
@@ -578,6 +622,10 @@ func Test_Defaults(t *testing.T) {
 			path:          "State.Batch.Constraints.MaxBinaries",
 			expectedValue: uint32(473170),
 		},
+		{
+			path:          "DataAvailability.Blob.ArchivePath",
+			expectedValue: "/datastreamer/blobs",
+		},
 	}
 	file, err := os.CreateTemp("", "genesisConfig")
 	require.NoError(t, err)
@@ -2,10 +2,12 @@ package config
 
 import (
 	"crypto/ecdsa"
+	"fmt"
 	"os"
 	"path/filepath"
 
 	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/etherman/signer"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
 )
 
@@ -24,3 +26,13 @@ func NewKeyFromKeystore(cfg types.KeystoreFileConfig) (*ecdsa.PrivateKey, error)
 	}
 	return key.PrivateKey, nil
 }
+
+// validateXLayer checks the X Layer config that can't be checked when decoding it
+func (cfg *Config) validateXLayer() error {
+	for _, signerCfg := range cfg.EthTxManager.Signers {
+		if err := signerCfg.Validate(); err != nil {
+			return fmt.Errorf("invalid EthTxManager.Signers: %w", err)
+		}
+	}
+	return nil
+}
@@ -69,6 +69,11 @@ TxFeeCap = 1.0
 	Port = "5432"
 	EnableLog = false
 	MaxConns = 200
+	[Pool.Quota]
+	MaxPendingTxsPerSender = 0
+	MaxPendingTxsPerIP = 0
+	GlobalSlots = 0
+	LocalAddresses = []
 
 [Etherman]
 URL = "http://localhost:8545"
@@ -76,6 +81,13 @@ ForkIDChunkSize = 20000
 MultiGasProvider = false
 	[Etherman.Etherscan]
 		ApiKey = ""
+	[Etherman.L1Client]
+		Providers = []
+		RequestTimeout = "30s"
+		SlowRequestThreshold = "5s"
+		FailureThreshold = 3
+		CircuitOpenPeriod = "30s"
+		Quorum = 0
 
 [EthTxManager]
 FrequencyToMonitorTxs = "1s"
@@ -99,6 +111,23 @@ MaxGasPriceLimit = 0
 		OperateAmount = 0
 		RequestSignURI = "/priapi/v1/assetonchain/ecology/ecologyOperate"
 		QuerySignURI = "/priapi/v1/assetonchain/ecology/querySignDataByOrderNo"
+	[EthTxManager.DynamicFee]
+		Enable = false
+		FeeHistoryBlocks = 10
+		PriorityFeeStrategy = "percentile"
+		RewardPercentile = 50
+		FixedPriorityFee = 1000000000
+		MinPriorityFee = 100000000
+		MaxPriorityFee = 0
+		MaxFeeStrategy = "baseFeeMultiplier"
+		BaseFeeMultiplier = 2
+		MaxFeePerGasLimit = 0
+		BlobFeeMultiplier = 2
+		MaxBlobFeePerGasLimit = 0
+	[EthTxManager.NonceManager]
+		Enable = false
+		StuckTxTimeout = "10m"
+		MaxResends = 3
 
 [RPC]
 Host = "0.0.0.0"
@@ -116,6 +145,9 @@ MaxNativeBlockHashBlockRange = 60000
 EnableHttpLog = true
 GasLimitFactor = 1
 DisableAPIs = []
+AdminApiKeys = []
+PreconfirmationURI = ""
+PreconfirmationAuthKey = ""
 	[RPC.RateLimit]
 		Enabled = false
 		RateLimitApis = []
@@ -139,6 +171,8 @@ DisableAPIs = []
 	[RPC.ApiAuthentication]
 		Enabled = false
 		ApiKeys = []
+		DefaultMethodWeight = 1
+		MethodWeights = []
 	[RPC.ApiRelay]
 		Enabled = false
 		DestURI = "" 
@@ -213,6 +247,20 @@ StateConsistencyCheckInterval = "5s"
 		InactivityTimeout = "120s"
 		InactivityCheckInterval = "5s"
 		Enabled = false
+	[Sequencer.WorkerSnapshot]
+		Enabled = false
+		Filename = "/datastreamer/worker_snapshot.json"
+		Interval = "10s"
+	[Sequencer.OrderingPolicy]
+		Type = "gasprice"
+		MaxTxsPerSender = 0
+		ReservedResourcesPct = 0
+		WhitelistedContracts = []
+	[Sequencer.Preconfirmation]
+		Enabled = false
+		Host = "127.0.0.1"
+		Port = 6910
+		AuthKeys = []
 
 [SequenceSender]
 WaitPeriodSendSequence = "5s"
@@ -276,4 +324,12 @@ Host = "xlayer-state-db"
 Port = "5432"
 EnableLog = false
 MaxConns = 200
+
+[DataAvailability]
+Backend = ""
+	[DataAvailability.Blob]
+		ArchivePath = "/datastreamer/blobs"
+		BeaconURL = ""
+		BeaconTimeout = "10s"
+		MaxBlobsPerTx = 6
 `
@@ -0,0 +1,64 @@
+package blob
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+// archive is a local store of blobs indexed by versioned hash
+type archive struct {
+	path string
+}
+
+// init creates the directory of the archive
+func (a *archive) init() error {
+	return os.MkdirAll(a.path, 0750) //nolint:gomnd
+}
+
+func (a *archive) blobPath(versionedHash common.Hash) string {
+	return filepath.Join(a.path, versionedHash.Hex()+".blob")
+}
+
+// put stores a blob in the archive. The blob is written to a temporary file that is renamed once
+// complete, so a crash or a concurrent put never leaves a truncated blob in the archive
+func (a *archive) put(versionedHash common.Hash, blob *kzg4844.Blob) error {
+	tmpFile, err := os.CreateTemp(a.path, versionedHash.Hex()+".*.tmp")
+	if err != nil {
+		return err
+	}
+	tmpPath := tmpFile.Name()
+	_, err = tmpFile.Write(blob[:])
+	if closeErr := tmpFile.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmpPath, a.blobPath(versionedHash))
+	}
+	if err != nil {
+		_ = os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// get loads a blob from the archive
+func (a *archive) get(versionedHash common.Hash) (*kzg4844.Blob, error) {
+	data, err := os.ReadFile(a.blobPath(versionedHash))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	if len(data) != len(kzg4844.Blob{}) {
+		return nil, fmt.Errorf("unexpected size of archived blob %s: %d", versionedHash, len(data))
+	}
+
+	var blob kzg4844.Blob
+	copy(blob[:], data)
+	return &blob, nil
+}
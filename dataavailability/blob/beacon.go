@@ -0,0 +1,64 @@
+package blob
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+// beaconClient retrieves blobs by versioned hash from a beacon API stand-in, that serves
+// GET {url}/blobs/{versionedHash} with a JSON object containing the hex encoded blob
+type beaconClient struct {
+	url    string
+	client *http.Client
+}
+
+// blobResponse is the response of the beacon API stand-in
+type blobResponse struct {
+	Blob hexutil.Bytes `json:"blob"`
+}
+
+func newBeaconClient(url string, timeout time.Duration) *beaconClient {
+	return &beaconClient{
+		url:    strings.TrimSuffix(url, "/"),
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+// getBlob requests a blob to the beacon API stand-in
+func (c *beaconClient) getBlob(ctx context.Context, versionedHash common.Hash) (*kzg4844.Blob, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/blobs/%s", c.url, versionedHash.Hex()), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrBlobNotFound
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code getting blob %s from the beacon API: %d", versionedHash, resp.StatusCode)
+	}
+
+	var response blobResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, err
+	}
+	if len(response.Blob) != len(kzg4844.Blob{}) {
+		return nil, fmt.Errorf("unexpected size of blob %s from the beacon API: %d", versionedHash, len(response.Blob))
+	}
+
+	var blob kzg4844.Blob
+	copy(blob[:], response.Blob)
+	return &blob, nil
+}
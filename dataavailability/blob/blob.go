@@ -0,0 +1,204 @@
+package blob
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// maxBlobsPerBlock is the maximum number of blobs that can be included in a L1 block
+const maxBlobsPerBlock = params.MaxBlobGasPerBlock / params.BlobTxBlobGasPerBlob
+
+var (
+	// ErrTooManyBlobs is returned when the sequence data doesn't fit in MaxBlobsPerTx blobs
+	ErrTooManyBlobs = errors.New("sequence data doesn't fit in the max blobs per tx")
+	// ErrBlobNotFound is returned when a blob is not found in the archive nor in the beacon API
+	ErrBlobNotFound = errors.New("blob not found")
+	// ErrBlobHashMismatch is returned when the commitment of a blob doesn't match its versioned hash
+	ErrBlobHashMismatch = errors.New("blob doesn't match its versioned hash")
+)
+
+// Backend implements the EIP-4844 blob data availability. The data of the sequence is packed
+// into blobs carried by the sequence tx, and the data availability message is the list of
+// versioned hashes of the blobs
+type Backend struct {
+	cfg     Config
+	archive *archive
+	beacon  *beaconClient
+}
+
+// New creates an instance of Backend
+func New(cfg Config) (*Backend, error) {
+	if cfg.MaxBlobsPerTx == 0 || cfg.MaxBlobsPerTx > maxBlobsPerBlock {
+		return nil, fmt.Errorf("invalid MaxBlobsPerTx %d, it must be between 1 and %d", cfg.MaxBlobsPerTx, maxBlobsPerBlock)
+	}
+	if cfg.ArchivePath == "" && cfg.BeaconURL == "" {
+		return nil, errors.New("either the blob archive or the beacon API must be configured to retrieve the blobs")
+	}
+
+	b := &Backend{cfg: cfg}
+	if cfg.ArchivePath != "" {
+		b.archive = &archive{path: cfg.ArchivePath}
+	}
+	if cfg.BeaconURL != "" {
+		b.beacon = newBeaconClient(cfg.BeaconURL, cfg.BeaconTimeout.Duration)
+	}
+	return b, nil
+}
+
+// Init creates the blob archive
+func (b *Backend) Init() error {
+	if b.archive != nil {
+		return b.archive.init()
+	}
+	return nil
+}
+
+// SequenceFits checks if the data of the batches fits in the blobs of a single sequence tx
+func (b *Backend) SequenceFits(batchesData [][]byte) bool {
+	return uint64(blobsNeeded(batchesData)) <= b.cfg.MaxBlobsPerTx
+}
+
+// PostSequence packs the sequence data into blobs and returns the dataAvailabilityMessage. The
+// blobs are only archived, PostSequenceBlobs must be used to get the sidecar of the sequence tx
+func (b *Backend) PostSequence(ctx context.Context, batchesData [][]byte) ([]byte, error) {
+	message, _, err := b.PostSequenceBlobs(ctx, batchesData)
+	return message, err
+}
+
+// PostSequenceBlobs packs the sequence data into blobs with their KZG commitments and proofs,
+// archives them and returns the dataAvailabilityMessage and the sidecar of the sequence tx
+func (b *Backend) PostSequenceBlobs(ctx context.Context, batchesData [][]byte) ([]byte, *types.BlobTxSidecar, error) {
+	if !b.SequenceFits(batchesData) {
+		return nil, nil, fmt.Errorf("%w: %d blobs needed, max %d", ErrTooManyBlobs, blobsNeeded(batchesData), b.cfg.MaxBlobsPerTx)
+	}
+
+	blobs, err := EncodeBlobs(batchesData)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sidecar := &types.BlobTxSidecar{Blobs: blobs}
+	for i := range blobs {
+		commitment, err := kzg4844.BlobToCommitment(blobs[i])
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to compute the commitment of blob %d: %w", i, err)
+		}
+		proof, err := kzg4844.ComputeBlobProof(blobs[i], commitment)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to compute the proof of blob %d: %w", i, err)
+		}
+		sidecar.Commitments = append(sidecar.Commitments, commitment)
+		sidecar.Proofs = append(sidecar.Proofs, proof)
+	}
+
+	versionedHashes := sidecar.BlobHashes()
+	if b.archive != nil {
+		for i, versionedHash := range versionedHashes {
+			if err := b.archive.put(versionedHash, &blobs[i]); err != nil {
+				return nil, nil, fmt.Errorf("failed to archive blob %s: %w", versionedHash, err)
+			}
+		}
+	}
+	log.Infof("sequence of %d batches packed into %d blobs: %v", len(batchesData), len(blobs), versionedHashes)
+
+	return EncodeMessage(versionedHashes), sidecar, nil
+}
+
+// GetSequence reads back the blobs of the dataAvailabilityMessage and returns the data of the batches
+// matching the provided hashes
+func (b *Backend) GetSequence(ctx context.Context, batchHashes []common.Hash, dataAvailabilityMessage []byte) ([][]byte, error) {
+	versionedHashes, err := DecodeMessage(dataAvailabilityMessage)
+	if err != nil {
+		return nil, err
+	}
+
+	blobs := make([]kzg4844.Blob, 0, len(versionedHashes))
+	for _, versionedHash := range versionedHashes {
+		blob, err := b.getBlob(ctx, versionedHash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blob %s: %w", versionedHash, err)
+		}
+		blobs = append(blobs, *blob)
+	}
+
+	batchesData, err := DecodeBlobs(blobs)
+	if err != nil {
+		return nil, err
+	}
+	dataByHash := make(map[common.Hash][]byte, len(batchesData))
+	for _, data := range batchesData {
+		dataByHash[crypto.Keccak256Hash(data)] = data
+	}
+
+	result := make([][]byte, 0, len(batchHashes))
+	for _, batchHash := range batchHashes {
+		data, found := dataByHash[batchHash]
+		if !found {
+			return nil, fmt.Errorf("data of batch with hash %s not found in blobs %v", batchHash, versionedHashes)
+		}
+		result = append(result, data)
+	}
+	return result, nil
+}
+
+// getBlob loads a blob from the archive, or from the beacon API if it's not archived or the archived
+// blob doesn't match its versioned hash. The blobs retrieved from the beacon API are archived
+func (b *Backend) getBlob(ctx context.Context, versionedHash common.Hash) (*kzg4844.Blob, error) {
+	var archiveErr error
+	if b.archive != nil {
+		blob, err := b.archive.get(versionedHash)
+		if err == nil {
+			err = verifyBlob(versionedHash, blob)
+			if err == nil {
+				return blob, nil
+			}
+			log.Warnf("archived blob %s doesn't match its versioned hash: %v", versionedHash, err)
+			archiveErr = err
+		} else if !errors.Is(err, ErrBlobNotFound) {
+			log.Warnf("failed to get blob %s from the archive: %v", versionedHash, err)
+			archiveErr = err
+		}
+	}
+
+	if b.beacon == nil {
+		if archiveErr != nil {
+			return nil, archiveErr
+		}
+		return nil, ErrBlobNotFound
+	}
+	blob, err := b.beacon.getBlob(ctx, versionedHash)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyBlob(versionedHash, blob); err != nil {
+		return nil, err
+	}
+
+	if b.archive != nil {
+		if err := b.archive.put(versionedHash, blob); err != nil {
+			log.Warnf("failed to archive blob %s: %v", versionedHash, err)
+		}
+	}
+	return blob, nil
+}
+
+// verifyBlob checks that the KZG commitment of the blob matches the versioned hash
+func verifyBlob(versionedHash common.Hash, blob *kzg4844.Blob) error {
+	commitment, err := kzg4844.BlobToCommitment(*blob)
+	if err != nil {
+		return err
+	}
+	if common.Hash(kzg4844.CalcBlobHashV1(sha256.New(), &commitment)) != versionedHash {
+		return fmt.Errorf("%w: %s", ErrBlobHashMismatch, versionedHash)
+	}
+	return nil
+}
@@ -0,0 +1,128 @@
+package blob
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeDecodeBlobs(t *testing.T) {
+	batchesData := [][]byte{
+		[]byte("batch 1"),
+		{},
+		make([]byte, usableBytesPerBlob), // spans over two blobs
+	}
+	batchesData[2][0], batchesData[2][usableBytesPerBlob-1] = 0xff, 0xff
+
+	blobs, err := EncodeBlobs(batchesData)
+	require.NoError(t, err)
+	require.Len(t, blobs, 2)
+
+	decoded, err := DecodeBlobs(blobs)
+	require.NoError(t, err)
+	assert.Equal(t, batchesData, decoded)
+
+	blobs[1][0] = 1
+	_, err = DecodeBlobs(blobs)
+	assert.ErrorIs(t, err, ErrInvalidBlobEncoding)
+
+	// a forged number of batches is rejected without allocating for it
+	var forged kzg4844.Blob
+	copy(forged[1:], []byte{0xff, 0xff, 0xff, 0xff})
+	_, err = DecodeBlobs([]kzg4844.Blob{forged})
+	assert.ErrorIs(t, err, ErrInvalidBlobEncoding)
+
+	_, err = DecodeMessage([]byte{1, 2, 3})
+	assert.ErrorIs(t, err, ErrInvalidMessage)
+	_, err = DecodeMessage(common.Hash{}.Bytes())
+	assert.ErrorIs(t, err, ErrInvalidMessage)
+}
+
+func TestPostAndGetSequence(t *testing.T) {
+	ctx := context.Background()
+	batchesData := [][]byte{[]byte("batch 1"), []byte("batch 2")}
+	hashes := []common.Hash{crypto.Keccak256Hash(batchesData[0]), crypto.Keccak256Hash(batchesData[1])}
+
+	archivePath := t.TempDir()
+	sender, err := New(Config{ArchivePath: archivePath, MaxBlobsPerTx: 1})
+	require.NoError(t, err)
+	require.NoError(t, sender.Init())
+
+	assert.False(t, sender.SequenceFits([][]byte{make([]byte, usableBytesPerBlob)}))
+	_, _, err = sender.PostSequenceBlobs(ctx, [][]byte{make([]byte, usableBytesPerBlob)})
+	assert.ErrorIs(t, err, ErrTooManyBlobs)
+
+	message, sidecar, err := sender.PostSequenceBlobs(ctx, batchesData)
+	require.NoError(t, err)
+	require.Len(t, sidecar.Blobs, 1)
+	require.NoError(t, kzg4844.VerifyBlobProof(sidecar.Blobs[0], sidecar.Commitments[0], sidecar.Proofs[0]))
+	versionedHashes, err := DecodeMessage(message)
+	require.NoError(t, err)
+	assert.Equal(t, sidecar.BlobHashes(), versionedHashes)
+
+	// The sequence is read back from the archive
+	data, err := sender.GetSequence(ctx, []common.Hash{hashes[1], hashes[0]}, message)
+	require.NoError(t, err)
+	assert.Equal(t, [][]byte{batchesData[1], batchesData[0]}, data)
+
+	// A node without the blobs in its archive gets them from the beacon API
+	blob := sidecar.Blobs[0]
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/blobs/"+versionedHashes[0].Hex() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(blobResponse{Blob: hexutil.Bytes(blob[:])}))
+	}))
+	defer server.Close()
+
+	retriever, err := New(Config{ArchivePath: t.TempDir(), BeaconURL: server.URL, BeaconTimeout: types.NewDuration(time.Second), MaxBlobsPerTx: 1})
+	require.NoError(t, err)
+	require.NoError(t, retriever.Init())
+	data, err = retriever.GetSequence(ctx, hashes, message)
+	require.NoError(t, err)
+	assert.Equal(t, batchesData, data)
+	archived, err := retriever.archive.get(versionedHashes[0])
+	require.NoError(t, err)
+	assert.Equal(t, blob, *archived)
+
+	_, err = retriever.GetSequence(ctx, []common.Hash{crypto.Keccak256Hash([]byte("batch 3"))}, message)
+	assert.True(t, err != nil && strings.Contains(err.Error(), "not found in blobs"))
+
+	// A corrupted archived blob is retrieved again from the beacon API and rewritten
+	require.NoError(t, os.WriteFile(sender.archive.blobPath(versionedHashes[0]), make([]byte, len(blob)), 0600))
+	_, err = sender.GetSequence(ctx, hashes, message)
+	assert.ErrorIs(t, err, ErrBlobHashMismatch)
+	retriever, err = New(Config{ArchivePath: archivePath, BeaconURL: server.URL, BeaconTimeout: types.NewDuration(time.Second), MaxBlobsPerTx: 1})
+	require.NoError(t, err)
+	data, err = retriever.GetSequence(ctx, hashes, message)
+	require.NoError(t, err)
+	assert.Equal(t, batchesData, data)
+	archived, err = retriever.archive.get(versionedHashes[0])
+	require.NoError(t, err)
+	assert.Equal(t, blob, *archived)
+	tmpFiles, err := filepath.Glob(filepath.Join(archivePath, "*.tmp"))
+	require.NoError(t, err)
+	assert.Empty(t, tmpFiles)
+
+	// A blob that doesn't match its versioned hash is rejected
+	blob[1] = 0xff
+	retriever, err = New(Config{BeaconURL: server.URL, MaxBlobsPerTx: 1})
+	require.NoError(t, err)
+	_, err = retriever.GetSequence(ctx, hashes, message)
+	assert.ErrorIs(t, err, ErrBlobHashMismatch)
+}
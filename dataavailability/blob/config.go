@@ -0,0 +1,20 @@
+package blob
+
+import "github.com/0xPolygonHermez/zkevm-node/config/types"
+
+// Config is the configuration of the EIP-4844 blob data availability backend
+type Config struct {
+	// ArchivePath is the directory of the local store where the posted and retrieved blobs are archived,
+	// so the data can be read back after the L1 nodes prune the blobs. Empty to disable the archive
+	ArchivePath string `mapstructure:"ArchivePath"`
+
+	// BeaconURL is the URL of the beacon API stand-in used to retrieve the blobs missing from the archive.
+	// Empty to disable it
+	BeaconURL string `mapstructure:"BeaconURL"`
+
+	// BeaconTimeout is the timeout of the requests to the beacon API stand-in
+	BeaconTimeout types.Duration `mapstructure:"BeaconTimeout"`
+
+	// MaxBlobsPerTx is the maximum number of blobs carried by a sequence tx
+	MaxBlobsPerTx uint64 `mapstructure:"MaxBlobsPerTx"`
+}
@@ -0,0 +1,129 @@
+package blob
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// usableBytesPerFieldElement is the number of data bytes stored in each field element of a blob, the
+	// first byte is always zero to keep the field element lower than the BLS modulus
+	usableBytesPerFieldElement = params.BlobTxBytesPerFieldElement - 1
+	// usableBytesPerBlob is the number of data bytes stored in a blob
+	usableBytesPerBlob = params.BlobTxFieldElementsPerBlob * usableBytesPerFieldElement
+	// lengthPrefixSize is the size of the number of batches and of the length of each batch in the payload
+	lengthPrefixSize = 4
+)
+
+var (
+	// ErrInvalidBlobEncoding is returned when the blobs don't contain a valid payload
+	ErrInvalidBlobEncoding = errors.New("invalid blob encoding")
+	// ErrInvalidMessage is returned when the data availability message is not a list of blob versioned hashes
+	ErrInvalidMessage = errors.New("invalid blob data availability message")
+)
+
+// payloadSize returns the size of the payload that packs the data of the batches
+func payloadSize(batchesData [][]byte) int {
+	size := lengthPrefixSize
+	for _, data := range batchesData {
+		size += lengthPrefixSize + len(data)
+	}
+	return size
+}
+
+// blobsNeeded returns the number of blobs needed to carry the data of the batches
+func blobsNeeded(batchesData [][]byte) int {
+	return (payloadSize(batchesData) + usableBytesPerBlob - 1) / usableBytesPerBlob
+}
+
+// EncodeBlobs packs the data of the batches into blobs. The payload is the number of batches followed by
+// the length and the data of each batch, spread over the field elements of the blobs in order
+func EncodeBlobs(batchesData [][]byte) ([]kzg4844.Blob, error) {
+	payload := make([]byte, 0, payloadSize(batchesData))
+	payload = binary.BigEndian.AppendUint32(payload, uint32(len(batchesData)))
+	for i, data := range batchesData {
+		if uint64(len(data)) > math.MaxUint32 {
+			return nil, fmt.Errorf("data of batch %d is too big to be encoded: %d bytes", i, len(data))
+		}
+		payload = binary.BigEndian.AppendUint32(payload, uint32(len(data)))
+		payload = append(payload, data...)
+	}
+
+	blobs := make([]kzg4844.Blob, blobsNeeded(batchesData))
+	for i := 0; i*usableBytesPerFieldElement < len(payload); i++ {
+		blob := &blobs[i/params.BlobTxFieldElementsPerBlob]
+		offset := (i % params.BlobTxFieldElementsPerBlob) * params.BlobTxBytesPerFieldElement
+		copy(blob[offset+1:offset+params.BlobTxBytesPerFieldElement], payload[i*usableBytesPerFieldElement:])
+	}
+	return blobs, nil
+}
+
+// DecodeBlobs unpacks the data of the batches from the blobs encoded by EncodeBlobs
+func DecodeBlobs(blobs []kzg4844.Blob) ([][]byte, error) {
+	payload := make([]byte, 0, len(blobs)*usableBytesPerBlob)
+	for i := range blobs {
+		for offset := 0; offset < len(blobs[i]); offset += params.BlobTxBytesPerFieldElement {
+			if blobs[i][offset] != 0 {
+				return nil, fmt.Errorf("%w: field element %d of blob %d is out of range", ErrInvalidBlobEncoding, offset/params.BlobTxBytesPerFieldElement, i)
+			}
+			payload = append(payload, blobs[i][offset+1:offset+params.BlobTxBytesPerFieldElement]...)
+		}
+	}
+
+	if len(payload) < lengthPrefixSize {
+		return nil, fmt.Errorf("%w: missing number of batches", ErrInvalidBlobEncoding)
+	}
+	count := binary.BigEndian.Uint32(payload)
+	payload = payload[lengthPrefixSize:]
+
+	// every batch takes at least its length prefix, the count is not trusted to size the slice
+	capacity := count
+	if maxCount := uint32(len(payload) / lengthPrefixSize); capacity > maxCount {
+		capacity = maxCount
+	}
+	batchesData := make([][]byte, 0, capacity)
+	for i := uint32(0); i < count; i++ {
+		if len(payload) < lengthPrefixSize {
+			return nil, fmt.Errorf("%w: missing length of batch %d", ErrInvalidBlobEncoding, i)
+		}
+		length := binary.BigEndian.Uint32(payload)
+		payload = payload[lengthPrefixSize:]
+		if uint64(len(payload)) < uint64(length) {
+			return nil, fmt.Errorf("%w: data of batch %d is truncated", ErrInvalidBlobEncoding, i)
+		}
+		batchesData = append(batchesData, common.CopyBytes(payload[:length]))
+		payload = payload[length:]
+	}
+	return batchesData, nil
+}
+
+// EncodeMessage returns the data availability message of the sequence, the versioned hashes of its blobs
+func EncodeMessage(versionedHashes []common.Hash) []byte {
+	message := make([]byte, 0, len(versionedHashes)*common.HashLength)
+	for _, versionedHash := range versionedHashes {
+		message = append(message, versionedHash.Bytes()...)
+	}
+	return message
+}
+
+// DecodeMessage returns the versioned hashes of the blobs in the data availability message
+func DecodeMessage(message []byte) ([]common.Hash, error) {
+	if len(message) == 0 || len(message)%common.HashLength != 0 {
+		return nil, fmt.Errorf("%w: unexpected length %d", ErrInvalidMessage, len(message))
+	}
+	versionedHashes := make([]common.Hash, 0, len(message)/common.HashLength)
+	for i := 0; i < len(message); i += common.HashLength {
+		versionedHash := message[i : i+common.HashLength]
+		if !kzg4844.IsValidVersionedHash(versionedHash) {
+			return nil, fmt.Errorf("%w: invalid versioned hash %s", ErrInvalidMessage, common.Bytes2Hex(versionedHash))
+		}
+		versionedHashes = append(versionedHashes, common.BytesToHash(versionedHash))
+	}
+	return versionedHashes, nil
+}
@@ -1,9 +1,23 @@
 package dataavailability
 
+import "github.com/0xPolygonHermez/zkevm-node/dataavailability/blob"
+
 // DABackendType is the data availability protocol for the CDK
 type DABackendType string
 
 const (
 	// DataAvailabilityCommittee is the DAC protocol backend
 	DataAvailabilityCommittee DABackendType = "DataAvailabilityCommittee"
+	// EIP4844Blob is the EIP-4844 blob backend
+	EIP4844Blob DABackendType = "EIP4844Blob"
 )
+
+// Config is the configuration of the data availability backend
+type Config struct {
+	// Backend is the expected protocol of the data availability contract, the node doesn't start if they differ,
+	// empty to use the one of the contract
+	Backend DABackendType `mapstructure:"Backend"`
+
+	// Blob is the configuration of the EIP-4844 blob backend
+	Blob blob.Config `mapstructure:"Blob"`
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/etherman/types"
 	"github.com/0xPolygonHermez/zkevm-node/log"
 	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -45,6 +46,32 @@ func New(
 // PostSequence sends the sequence data to the data availability backend, and returns the dataAvailabilityMessage
 // as expected by the contract
 func (d *DataAvailability) PostSequence(ctx context.Context, sequences []types.Sequence) ([]byte, error) {
+	return d.backend.PostSequence(ctx, sequencesData(sequences))
+}
+
+// PostSequenceWithBlobs sends the sequence data to the data availability backend like PostSequence. When the backend
+// posts the data in blobs, the sidecar to be carried by the sequence tx is also returned
+func (d *DataAvailability) PostSequenceWithBlobs(ctx context.Context, sequences []types.Sequence) ([]byte, *ethTypes.BlobTxSidecar, error) {
+	blobBackend, ok := d.backend.(BlobSequenceSender)
+	if !ok {
+		dataAvailabilityMessage, err := d.PostSequence(ctx, sequences)
+		return dataAvailabilityMessage, nil, err
+	}
+	return blobBackend.PostSequenceBlobs(ctx, sequencesData(sequences))
+}
+
+// SequenceFits checks if the sequence data fits in a single sequence tx, it's always true
+// unless the backend posts the data in blobs
+func (d *DataAvailability) SequenceFits(sequences []types.Sequence) bool {
+	blobBackend, ok := d.backend.(BlobSequenceSender)
+	if !ok {
+		return true
+	}
+	return blobBackend.SequenceFits(sequencesData(sequences))
+}
+
+// sequencesData returns the data of the batches to be sent to the DA backend
+func sequencesData(sequences []types.Sequence) [][]byte {
 	batchesData := [][]byte{}
 	for _, batch := range sequences {
 		// Do not send to the DA backend data that will be stored to L1
@@ -52,7 +79,7 @@ func (d *DataAvailability) PostSequence(ctx context.Context, sequences []types.S
 			batchesData = append(batchesData, batch.BatchL2Data)
 		}
 	}
-	return d.backend.PostSequence(ctx, batchesData)
+	return batchesData
 }
 
 // GetBatchL2Data tries to return the data from a batch, in the following priorities
@@ -7,6 +7,7 @@ import (
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
 	"github.com/jackc/pgx/v4"
 )
 
@@ -25,6 +26,15 @@ type SequenceSender interface {
 	PostSequence(ctx context.Context, batchesData [][]byte) ([]byte, error)
 }
 
+// BlobSequenceSender is implemented by the backends that post the sequence data in blobs carried by the sequence tx
+type BlobSequenceSender interface {
+	// PostSequenceBlobs packs the sequence data into blobs, and returns the dataAvailabilityMessage as expected
+	// by the contract and the sidecar of the sequence tx
+	PostSequenceBlobs(ctx context.Context, batchesData [][]byte) ([]byte, *ethTypes.BlobTxSidecar, error)
+	// SequenceFits checks if the sequence data fits in the blobs of a single sequence tx
+	SequenceFits(batchesData [][]byte) bool
+}
+
 // SequenceRetriever is used to retrieve batch data
 type SequenceRetriever interface {
 	// GetSequence retrieves the sequence data from the data availability backend
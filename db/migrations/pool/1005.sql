@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE pool.transaction ADD COLUMN IF NOT EXISTS is_private BOOLEAN DEFAULT FALSE;
+
+-- +migrate Down
+ALTER TABLE pool.transaction DROP COLUMN IF EXISTS is_private;
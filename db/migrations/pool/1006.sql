@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE pool.transaction ADD COLUMN IF NOT EXISTS conditional JSONB;
+
+-- +migrate Down
+ALTER TABLE pool.transaction DROP COLUMN IF EXISTS conditional;
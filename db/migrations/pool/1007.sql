@@ -0,0 +1,5 @@
+-- +migrate Up
+CREATE INDEX IF NOT EXISTS idx_transaction_ip_status ON pool.transaction (ip, status);
+
+-- +migrate Down
+DROP INDEX IF EXISTS pool.idx_transaction_ip_status;
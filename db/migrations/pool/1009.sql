@@ -0,0 +1,5 @@
+-- +migrate Up
+ALTER TABLE pool.transaction ADD COLUMN IF NOT EXISTS selected_at TIMESTAMP WITH TIME ZONE;
+
+-- +migrate Down
+ALTER TABLE pool.transaction DROP COLUMN IF EXISTS selected_at;
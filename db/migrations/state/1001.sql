@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS state.address_transaction
+(
+    address      VARCHAR NOT NULL,
+    tx_hash      VARCHAR NOT NULL REFERENCES state.transaction (hash) ON DELETE CASCADE,
+    l2_block_num BIGINT  NOT NULL,
+    tx_index     INTEGER NOT NULL,
+    PRIMARY KEY (address, tx_hash)
+);
+
+CREATE INDEX IF NOT EXISTS idx_address_transaction_address_block ON state.address_transaction (address, l2_block_num, tx_index);
+CREATE INDEX IF NOT EXISTS idx_address_transaction_tx_hash ON state.address_transaction (tx_hash);
+CREATE INDEX IF NOT EXISTS idx_receipt_contract_address ON state.receipt (contract_address);
+
+-- +migrate Down
+DROP INDEX IF EXISTS state.idx_receipt_contract_address;
+DROP TABLE IF EXISTS state.address_transaction;
@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE state.monitored_txs
+    ADD COLUMN gas_fee_cap  DECIMAL(78, 0),
+    ADD COLUMN gas_tip_cap  DECIMAL(78, 0),
+    ADD COLUMN history_fees JSONB;
+
+-- +migrate Down
+ALTER TABLE state.monitored_txs
+    DROP COLUMN gas_fee_cap,
+    DROP COLUMN gas_tip_cap,
+    DROP COLUMN history_fees;
@@ -0,0 +1,9 @@
+-- +migrate Up
+ALTER TABLE state.monitored_txs
+    ADD COLUMN blob_gas_fee_cap DECIMAL(78, 0),
+    ADD COLUMN blob_sidecar     BYTEA;
+
+-- +migrate Down
+ALTER TABLE state.monitored_txs
+    DROP COLUMN blob_gas_fee_cap,
+    DROP COLUMN blob_sidecar;
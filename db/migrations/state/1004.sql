@@ -0,0 +1,7 @@
+-- +migrate Up
+ALTER TABLE state.monitored_txs
+    ADD COLUMN nonce_recoveries JSONB;
+
+-- +migrate Down
+ALTER TABLE state.monitored_txs
+    DROP COLUMN nonce_recoveries;
@@ -0,0 +1,15 @@
+-- +migrate Up
+-- The txs stored before state.address_transaction was created are not indexed, since their senders
+-- can't be recovered in SQL. The first l2 block of the index is recorded to reject the searches below it
+CREATE TABLE IF NOT EXISTS state.address_transaction_index
+(
+    first_l2_block_num BIGINT NOT NULL
+);
+
+INSERT INTO state.address_transaction_index (first_l2_block_num)
+SELECT COALESCE(MAX(t.l2_block_num) + 1, 0)
+  FROM state.transaction t
+ WHERE NOT EXISTS (SELECT 1 FROM state.address_transaction a WHERE a.tx_hash = t.hash);
+
+-- +migrate Down
+DROP TABLE IF EXISTS state.address_transaction_index;
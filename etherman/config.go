@@ -1,6 +1,9 @@
 package etherman
 
-import "github.com/0xPolygonHermez/zkevm-node/etherman/etherscan"
+import (
+	"github.com/0xPolygonHermez/zkevm-node/etherman/etherscan"
+	"github.com/0xPolygonHermez/zkevm-node/etherman/l1client"
+)
 
 // Config represents the configuration of the etherman
 type Config struct {
@@ -14,4 +17,8 @@ type Config struct {
 	MultiGasProvider bool `mapstructure:"MultiGasProvider"`
 	// Configuration for use Etherscan as used as gas provider, basically it needs the API-KEY
 	Etherscan etherscan.Config
+
+	// L1Client is the configuration of the multi-provider L1 client, used instead of the URL
+	// when providers are configured
+	L1Client l1client.Config `mapstructure:"L1Client"`
 }
@@ -39,7 +39,6 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
 	"golang.org/x/crypto/sha3"
 )
@@ -165,6 +164,9 @@ type ethereumClient interface {
 	ethereum.TransactionSender
 
 	bind.DeployBackend
+
+	// XLayer pending nonce used by the nonce manager of the ethtxmanager
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
 }
 
 // L1Config represents the configuration of the network used in L1
@@ -215,7 +217,8 @@ type Client struct {
 // NewClient creates a new etherman.
 func NewClient(cfg Config, l1Config L1Config) (*Client, error) {
 	// Connect to ethereum node
-	ethClient, err := ethclient.Dial(cfg.URL)
+	// XLayer multi-provider L1 client
+	ethClient, err := newEthereumClientXLayer(cfg)
 	if err != nil {
 		log.Errorf("error connecting to %s: %+v", cfg.URL, err)
 		return nil, err
@@ -504,7 +507,8 @@ type Order struct {
 
 func (etherMan *Client) readEvents(ctx context.Context, query ethereum.FilterQuery) ([]Block, map[common.Hash][]Order, error) {
 	start := time.Now()
-	logs, err := etherMan.EthClient.FilterLogs(ctx, query)
+	// XLayer the rollup events may require the agreement of the L1 providers quorum
+	logs, err := etherMan.quorumFilterLogsXLayer(ctx, query)
 	metrics.GetEventsTime(time.Since(start))
 	if err != nil {
 		return nil, nil, err
@@ -1231,7 +1235,8 @@ func (etherMan *Client) sequencedBatchesEvent(ctx context.Context, vLog types.Lo
 	if tx.Hash() != vLog.TxHash {
 		return fmt.Errorf("error: tx hash mismatch. want: %s have: %s", vLog.TxHash, tx.Hash().String())
 	}
-	msg, err := core.TransactionToMessage(tx, types.NewLondonSigner(tx.ChainId()), big.NewInt(0))
+	// XLayer the latest signer also recovers the sender of the blob txs carrying the sequence data
+	msg, err := core.TransactionToMessage(tx, types.LatestSignerForChainID(tx.ChainId()), big.NewInt(0))
 	if err != nil {
 		return err
 	}
@@ -1764,6 +1769,10 @@ func (etherMan *Client) GetSafeBlockNumber(ctx context.Context) (uint64, error)
 
 // GetFinalizedBlockNumber gets the Finalized block number from the ethereum
 func (etherMan *Client) GetFinalizedBlockNumber(ctx context.Context) (uint64, error) {
+	// XLayer the finalized block number may require the agreement of the L1 providers quorum
+	if quorumReader, ok := etherMan.EthClient.(l1QuorumReader); ok {
+		return quorumReader.QuorumBlockNumber(ctx, big.NewInt(int64(rpc.FinalizedBlockNumber)))
+	}
 	return etherMan.getBlockNumber(ctx, rpc.FinalizedBlockNumber)
 }
 
@@ -1889,6 +1898,11 @@ func (etherMan *Client) CurrentNonce(ctx context.Context, account common.Address
 	return etherMan.EthClient.NonceAt(ctx, account, nil)
 }
 
+// PendingNonce returns the next nonce for the provided account including the txs in the L1 txpool
+func (etherMan *Client) PendingNonce(ctx context.Context, account common.Address) (uint64, error) {
+	return etherMan.EthClient.PendingNonceAt(ctx, account)
+}
+
 // SuggestedGasPrice returns the suggest nonce for the network at the moment
 func (etherMan *Client) SuggestedGasPrice(ctx context.Context) (*big.Int, error) {
 	suggestedGasPrice := etherMan.GetL1GasPrice(ctx)
@@ -1898,6 +1912,15 @@ func (etherMan *Client) SuggestedGasPrice(ctx context.Context) (*big.Int, error)
 	return suggestedGasPrice, nil
 }
 
+// FeeHistory returns the base fees and the priority fees percentiles of the last blockCount L1 blocks
+func (etherMan *Client) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	feeHistoryReader, ok := etherMan.EthClient.(ethereum.FeeHistoryReader)
+	if !ok {
+		return nil, errors.New("the L1 client doesn't support eth_feeHistory")
+	}
+	return feeHistoryReader.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+}
+
 // EstimateGas returns the estimated gas for the tx
 func (etherMan *Client) EstimateGas(ctx context.Context, from common.Address, to *common.Address, value *big.Int, data []byte) (uint64, error) {
 	return etherMan.EthClient.EstimateGas(ctx, ethereum.CallMsg{
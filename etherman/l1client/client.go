@@ -0,0 +1,488 @@
+package l1client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/etherman/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// healthScoreAlpha is the weight of the last request in the health score moving average
+	healthScoreAlpha = 0.2
+	// slowRequestScore is the health score sample of a request slower than the threshold
+	slowRequestScore = 0.5
+	// minHealthScore keeps a share of the requests for the unhealthy providers so they can recover
+	minHealthScore = 0.05
+
+	// JSON-RPC error codes returned by the providers when they can't serve the request
+	rpcInternalErrorCode = -32603
+	rpcLimitExceededCode = -32005
+)
+
+var (
+	// ErrNoProviders is returned when the client is created without providers
+	ErrNoProviders = errors.New("no L1 providers configured")
+	// ErrQuorumNotReached is returned when not enough providers agree on a critical read
+	ErrQuorumNotReached = errors.New("L1 providers quorum not reached")
+)
+
+// EthereumClient is the client of a L1 provider
+type EthereumClient interface {
+	ethereum.ChainReader
+	ethereum.ChainStateReader
+	ethereum.ContractCaller
+	ethereum.GasEstimator
+	ethereum.GasPricer
+	ethereum.LogFilterer
+	ethereum.TransactionReader
+	ethereum.TransactionSender
+	ethereum.FeeHistoryReader
+
+	PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error)
+	PendingNonceAt(ctx context.Context, account common.Address) (uint64, error)
+	SuggestGasTipCap(ctx context.Context) (*big.Int, error)
+	Close()
+}
+
+// provider is a L1 provider and its health
+type provider struct {
+	name   string
+	weight float64
+	client EthereumClient
+
+	// score is the health score, the moving average of the request results from 0 to 1
+	score float64
+	// currentWeight is the state of the smooth weighted round robin
+	currentWeight       float64
+	consecutiveFailures uint64
+	openUntil           time.Time
+}
+
+// circuitOpen checks if the provider doesn't receive requests at the time
+func (p *provider) circuitOpen(now time.Time) bool {
+	return now.Before(p.openUntil)
+}
+
+// Client is a L1 client that balances the requests between several providers with a weighted round
+// robin, failing over to the next provider on errors or timeouts. The providers failing repeatedly
+// are taken out of the rotation for a while, and the critical reads may require the agreement of a
+// quorum of providers
+type Client struct {
+	cfg       Config
+	providers []*provider
+	mutex     sync.Mutex
+}
+
+// New dials the configured providers and creates the client
+func New(cfg Config) (*Client, error) {
+	clients := make([]EthereumClient, 0, len(cfg.Providers))
+	for _, p := range cfg.Providers {
+		ethClient, err := ethclient.Dial(p.URL)
+		if err != nil {
+			for _, c := range clients {
+				c.Close()
+			}
+			return nil, fmt.Errorf("error connecting to L1 provider %s: %w", p.Name, err)
+		}
+		clients = append(clients, ethClient)
+	}
+	return newClient(cfg, clients)
+}
+
+func newClient(cfg Config, clients []EthereumClient) (*Client, error) {
+	if len(clients) == 0 {
+		return nil, ErrNoProviders
+	}
+	if cfg.Quorum > uint64(len(clients)) {
+		return nil, fmt.Errorf("quorum %d greater than the %d L1 providers", cfg.Quorum, len(clients))
+	}
+	c := &Client{cfg: cfg}
+	for i, ethClient := range clients {
+		name := cfg.Providers[i].Name
+		if name == "" {
+			name = fmt.Sprintf("provider%d", i)
+		}
+		weight := cfg.Providers[i].Weight
+		if weight == 0 {
+			weight = 1
+		}
+		c.providers = append(c.providers, &provider{name: name, weight: float64(weight), client: ethClient, score: 1})
+		metrics.L1ProviderHealth(name, 1, false)
+	}
+	return c, nil
+}
+
+// Close closes the clients of all the providers
+func (c *Client) Close() {
+	for _, p := range c.providers {
+		p.client.Close()
+	}
+}
+
+// order returns the providers in the order they are tried for a request: the provider selected by the
+// smooth weighted round robin, the rest of the available providers by health score and at last the
+// providers with the circuit open, as a last resort
+func (c *Client) order() []*provider {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	var available, open []*provider
+	for _, p := range c.providers {
+		if p.circuitOpen(now) {
+			open = append(open, p)
+		} else {
+			available = append(available, p)
+		}
+	}
+
+	if len(available) > 0 {
+		var (
+			selected    *provider
+			totalWeight float64
+		)
+		for _, p := range available {
+			effectiveWeight := p.weight * maxFloat(p.score, minHealthScore)
+			p.currentWeight += effectiveWeight
+			totalWeight += effectiveWeight
+			if selected == nil || p.currentWeight > selected.currentWeight {
+				selected = p
+			}
+		}
+		selected.currentWeight -= totalWeight
+
+		rest := make([]*provider, 0, len(available)-1)
+		for _, p := range available {
+			if p != selected {
+				rest = append(rest, p)
+			}
+		}
+		sort.SliceStable(rest, func(i, j int) bool { return rest[i].score > rest[j].score })
+		available = append([]*provider{selected}, rest...)
+	}
+
+	sort.SliceStable(open, func(i, j int) bool { return open[i].openUntil.Before(open[j].openUntil) })
+	return append(available, open...)
+}
+
+// available returns the providers with the circuit closed, or all of them if there are less than the quorum
+func (c *Client) available() []*provider {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := time.Now()
+	var available []*provider
+	for _, p := range c.providers {
+		if !p.circuitOpen(now) {
+			available = append(available, p)
+		}
+	}
+	if uint64(len(available)) < c.cfg.Quorum {
+		return c.providers
+	}
+	return available
+}
+
+// record updates the health of the provider with the result of a request
+func (c *Client) record(p *provider, latency time.Duration, failed bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	sample := float64(1)
+	if failed {
+		sample = 0
+	} else if c.cfg.SlowRequestThreshold.Duration > 0 && latency > c.cfg.SlowRequestThreshold.Duration {
+		sample = slowRequestScore
+	}
+	p.score = p.score*(1-healthScoreAlpha) + sample*healthScoreAlpha
+
+	now := time.Now()
+	if failed {
+		p.consecutiveFailures++
+		if c.cfg.FailureThreshold > 0 && p.consecutiveFailures >= c.cfg.FailureThreshold {
+			p.openUntil = now.Add(c.cfg.CircuitOpenPeriod.Duration)
+			log.Warnf("circuit of L1 provider %s opened until %v after %d consecutive failures", p.name, p.openUntil, p.consecutiveFailures)
+		}
+	} else {
+		if p.consecutiveFailures >= c.cfg.FailureThreshold && c.cfg.FailureThreshold > 0 {
+			log.Infof("circuit of L1 provider %s closed", p.name)
+		}
+		p.consecutiveFailures = 0
+		p.openUntil = time.Time{}
+	}
+
+	metrics.L1ProviderRequest(p.name, latency, failed)
+	metrics.L1ProviderHealth(p.name, p.score, p.circuitOpen(now))
+}
+
+// isProviderError checks if the error of a request is caused by the provider, so the request can be
+// sent to another provider. The errors of the request itself, like not found or reverted, are returned
+// by any provider
+func isProviderError(ctx context.Context, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	if errors.Is(err, ethereum.NotFound) || errors.Is(err, rpc.ErrNotificationsUnsupported) {
+		return false
+	}
+	var rpcErr rpc.Error
+	if errors.As(err, &rpcErr) {
+		return rpcErr.ErrorCode() == rpcInternalErrorCode || rpcErr.ErrorCode() == rpcLimitExceededCode
+	}
+	return true
+}
+
+// request sends a request to the provider under the request timeout and records the result
+func request[T any](ctx context.Context, c *Client, p *provider, f func(context.Context, EthereumClient) (T, error)) (T, error) {
+	reqCtx := ctx
+	if c.cfg.RequestTimeout.Duration > 0 {
+		var cancel context.CancelFunc
+		reqCtx, cancel = context.WithTimeout(ctx, c.cfg.RequestTimeout.Duration)
+		defer cancel()
+	}
+	start := time.Now()
+	result, err := f(reqCtx, p.client)
+	failed := isProviderError(ctx, err)
+	c.record(p, time.Since(start), failed)
+	return result, err
+}
+
+// do sends the request to the providers until one of them serves it
+func do[T any](ctx context.Context, c *Client, method string, f func(context.Context, EthereumClient) (T, error)) (T, error) {
+	var (
+		result T
+		err    error
+	)
+	for _, p := range c.order() {
+		result, err = request(ctx, c, p, f)
+		if !isProviderError(ctx, err) {
+			return result, err
+		}
+		log.Warnf("L1 provider %s failed to serve %s, failing over: %v", p.name, method, err)
+	}
+	return result, err
+}
+
+// quorumResult is the result of a request to a provider of the quorum
+type quorumResult[T any] struct {
+	provider string
+	result   T
+	err      error
+}
+
+// askQuorum sends the request to the available providers concurrently, returning the successful results
+func askQuorum[T any](ctx context.Context, c *Client, f func(context.Context, EthereumClient) (T, error)) []quorumResult[T] {
+	results := askProviders(ctx, c, c.available(), f)
+	succeeded := make([]quorumResult[T], 0, len(results))
+	for _, r := range results {
+		if r.err != nil {
+			log.Warnf("L1 provider %s failed to serve a quorum read: %v", r.provider, r.err)
+			continue
+		}
+		succeeded = append(succeeded, r)
+	}
+	return succeeded
+}
+
+// askProviders sends the request to the providers concurrently, returning the result of each one
+func askProviders[T any](ctx context.Context, c *Client, providers []*provider, f func(context.Context, EthereumClient) (T, error)) []quorumResult[T] {
+	results := make([]quorumResult[T], len(providers))
+	var wg sync.WaitGroup
+	for i, p := range providers {
+		wg.Add(1)
+		go func(i int, p *provider) {
+			defer wg.Done()
+			result, err := request(ctx, c, p, f)
+			results[i] = quorumResult[T]{provider: p.name, result: result, err: err}
+		}(i, p)
+	}
+	wg.Wait()
+	return results
+}
+
+func maxFloat(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// BlockByHash returns the block with the hash
+func (c *Client) BlockByHash(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	return do(ctx, c, "BlockByHash", func(ctx context.Context, client EthereumClient) (*types.Block, error) {
+		return client.BlockByHash(ctx, hash)
+	})
+}
+
+// BlockByNumber returns the block with the number, the latest one if nil
+func (c *Client) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	return do(ctx, c, "BlockByNumber", func(ctx context.Context, client EthereumClient) (*types.Block, error) {
+		return client.BlockByNumber(ctx, number)
+	})
+}
+
+// HeaderByHash returns the block header with the hash
+func (c *Client) HeaderByHash(ctx context.Context, hash common.Hash) (*types.Header, error) {
+	return do(ctx, c, "HeaderByHash", func(ctx context.Context, client EthereumClient) (*types.Header, error) {
+		return client.HeaderByHash(ctx, hash)
+	})
+}
+
+// HeaderByNumber returns the block header with the number, the latest one if nil
+func (c *Client) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return do(ctx, c, "HeaderByNumber", func(ctx context.Context, client EthereumClient) (*types.Header, error) {
+		return client.HeaderByNumber(ctx, number)
+	})
+}
+
+// TransactionCount returns the number of txs of the block
+func (c *Client) TransactionCount(ctx context.Context, blockHash common.Hash) (uint, error) {
+	return do(ctx, c, "TransactionCount", func(ctx context.Context, client EthereumClient) (uint, error) {
+		return client.TransactionCount(ctx, blockHash)
+	})
+}
+
+// TransactionInBlock returns the tx at the index of the block
+func (c *Client) TransactionInBlock(ctx context.Context, blockHash common.Hash, index uint) (*types.Transaction, error) {
+	return do(ctx, c, "TransactionInBlock", func(ctx context.Context, client EthereumClient) (*types.Transaction, error) {
+		return client.TransactionInBlock(ctx, blockHash, index)
+	})
+}
+
+// SubscribeNewHead subscribes to the new block headers
+func (c *Client) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return do(ctx, c, "SubscribeNewHead", func(ctx context.Context, client EthereumClient) (ethereum.Subscription, error) {
+		return client.SubscribeNewHead(ctx, ch)
+	})
+}
+
+// BalanceAt returns the balance of the account at the block number
+func (c *Client) BalanceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	return do(ctx, c, "BalanceAt", func(ctx context.Context, client EthereumClient) (*big.Int, error) {
+		return client.BalanceAt(ctx, account, blockNumber)
+	})
+}
+
+// StorageAt returns the value of the storage key of the account at the block number
+func (c *Client) StorageAt(ctx context.Context, account common.Address, key common.Hash, blockNumber *big.Int) ([]byte, error) {
+	return do(ctx, c, "StorageAt", func(ctx context.Context, client EthereumClient) ([]byte, error) {
+		return client.StorageAt(ctx, account, key, blockNumber)
+	})
+}
+
+// CodeAt returns the code of the account at the block number
+func (c *Client) CodeAt(ctx context.Context, account common.Address, blockNumber *big.Int) ([]byte, error) {
+	return do(ctx, c, "CodeAt", func(ctx context.Context, client EthereumClient) ([]byte, error) {
+		return client.CodeAt(ctx, account, blockNumber)
+	})
+}
+
+// NonceAt returns the nonce of the account at the block number
+func (c *Client) NonceAt(ctx context.Context, account common.Address, blockNumber *big.Int) (uint64, error) {
+	return do(ctx, c, "NonceAt", func(ctx context.Context, client EthereumClient) (uint64, error) {
+		return client.NonceAt(ctx, account, blockNumber)
+	})
+}
+
+// PendingCodeAt returns the code of the account in the pending state
+func (c *Client) PendingCodeAt(ctx context.Context, account common.Address) ([]byte, error) {
+	return do(ctx, c, "PendingCodeAt", func(ctx context.Context, client EthereumClient) ([]byte, error) {
+		return client.PendingCodeAt(ctx, account)
+	})
+}
+
+// PendingNonceAt returns the nonce of the account in the pending state
+func (c *Client) PendingNonceAt(ctx context.Context, account common.Address) (uint64, error) {
+	return do(ctx, c, "PendingNonceAt", func(ctx context.Context, client EthereumClient) (uint64, error) {
+		return client.PendingNonceAt(ctx, account)
+	})
+}
+
+// CallContract executes the call at the block number
+func (c *Client) CallContract(ctx context.Context, call ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return do(ctx, c, "CallContract", func(ctx context.Context, client EthereumClient) ([]byte, error) {
+		return client.CallContract(ctx, call, blockNumber)
+	})
+}
+
+// EstimateGas estimates the gas of the call
+func (c *Client) EstimateGas(ctx context.Context, call ethereum.CallMsg) (uint64, error) {
+	return do(ctx, c, "EstimateGas", func(ctx context.Context, client EthereumClient) (uint64, error) {
+		return client.EstimateGas(ctx, call)
+	})
+}
+
+// SuggestGasPrice returns the suggested gas price
+func (c *Client) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return do(ctx, c, "SuggestGasPrice", func(ctx context.Context, client EthereumClient) (*big.Int, error) {
+		return client.SuggestGasPrice(ctx)
+	})
+}
+
+// SuggestGasTipCap returns the suggested max priority fee
+func (c *Client) SuggestGasTipCap(ctx context.Context) (*big.Int, error) {
+	return do(ctx, c, "SuggestGasTipCap", func(ctx context.Context, client EthereumClient) (*big.Int, error) {
+		return client.SuggestGasTipCap(ctx)
+	})
+}
+
+// FeeHistory returns the fee history of the blocks
+func (c *Client) FeeHistory(ctx context.Context, blockCount uint64, lastBlock *big.Int, rewardPercentiles []float64) (*ethereum.FeeHistory, error) {
+	return do(ctx, c, "FeeHistory", func(ctx context.Context, client EthereumClient) (*ethereum.FeeHistory, error) {
+		return client.FeeHistory(ctx, blockCount, lastBlock, rewardPercentiles)
+	})
+}
+
+// FilterLogs returns the logs of the query
+func (c *Client) FilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	return do(ctx, c, "FilterLogs", func(ctx context.Context, client EthereumClient) ([]types.Log, error) {
+		return client.FilterLogs(ctx, q)
+	})
+}
+
+// SubscribeFilterLogs subscribes to the logs of the query
+func (c *Client) SubscribeFilterLogs(ctx context.Context, q ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	return do(ctx, c, "SubscribeFilterLogs", func(ctx context.Context, client EthereumClient) (ethereum.Subscription, error) {
+		return client.SubscribeFilterLogs(ctx, q, ch)
+	})
+}
+
+// TransactionByHash returns the tx with the hash
+func (c *Client) TransactionByHash(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+	type txResult struct {
+		tx        *types.Transaction
+		isPending bool
+	}
+	r, err := do(ctx, c, "TransactionByHash", func(ctx context.Context, client EthereumClient) (txResult, error) {
+		tx, isPending, err := client.TransactionByHash(ctx, txHash)
+		return txResult{tx: tx, isPending: isPending}, err
+	})
+	return r.tx, r.isPending, err
+}
+
+// TransactionReceipt returns the receipt of the tx
+func (c *Client) TransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
+	return do(ctx, c, "TransactionReceipt", func(ctx context.Context, client EthereumClient) (*types.Receipt, error) {
+		return client.TransactionReceipt(ctx, txHash)
+	})
+}
+
+// SendTransaction sends the signed tx, the same tx is sent to the next provider if one fails
+func (c *Client) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	_, err := do(ctx, c, "SendTransaction", func(ctx context.Context, client EthereumClient) (struct{}, error) {
+		return struct{}{}, client.SendTransaction(ctx, tx)
+	})
+	return err
+}
@@ -0,0 +1,216 @@
+package l1client
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClient is a L1 provider serving the block headers, logs and txs, the other methods are not implemented
+type fakeClient struct {
+	EthereumClient
+	err    error
+	number uint64
+	logs   []ethTypes.Log
+	tx     *ethTypes.Transaction
+	calls  int
+}
+
+func (f *fakeClient) HeaderByNumber(_ context.Context, _ *big.Int) (*ethTypes.Header, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &ethTypes.Header{Number: new(big.Int).SetUint64(f.number)}, nil
+}
+
+func (f *fakeClient) FilterLogs(_ context.Context, _ ethereum.FilterQuery) ([]ethTypes.Log, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.logs, nil
+}
+
+func (f *fakeClient) TransactionByHash(_ context.Context, _ common.Hash) (*ethTypes.Transaction, bool, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, false, f.err
+	}
+	if f.tx == nil {
+		return nil, false, ethereum.NotFound
+	}
+	return f.tx, false, nil
+}
+
+func (f *fakeClient) Close() {}
+
+type rpcError struct {
+	code int
+}
+
+func (e rpcError) Error() string  { return "rpc error" }
+func (e rpcError) ErrorCode() int { return e.code }
+
+func newTestClient(t *testing.T, cfg Config, clients ...*fakeClient) *Client {
+	ethClients := make([]EthereumClient, 0, len(clients))
+	for range clients {
+		cfg.Providers = append(cfg.Providers, ProviderConfig{})
+	}
+	for i, c := range clients {
+		if cfg.Providers[i].Weight == 0 {
+			cfg.Providers[i].Weight = 1
+		}
+		ethClients = append(ethClients, c)
+	}
+	client, err := newClient(cfg, ethClients)
+	require.NoError(t, err)
+	return client
+}
+
+func TestWeightedRoundRobin(t *testing.T) {
+	ctx := context.Background()
+	p0, p1 := &fakeClient{number: 1}, &fakeClient{number: 1}
+	cfg := Config{Providers: []ProviderConfig{{Weight: 3}, {Weight: 1}}}
+	client, err := newClient(cfg, []EthereumClient{p0, p1})
+	require.NoError(t, err)
+
+	for i := 0; i < 8; i++ {
+		_, err := client.HeaderByNumber(ctx, nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 6, p0.calls)
+	assert.Equal(t, 2, p1.calls)
+
+	_, err = newClient(Config{Quorum: 3, Providers: cfg.Providers}, []EthereumClient{p0, p1})
+	assert.Error(t, err)
+	_, err = newClient(Config{}, nil)
+	assert.ErrorIs(t, err, ErrNoProviders)
+}
+
+func TestFailoverAndCircuitBreaking(t *testing.T) {
+	ctx := context.Background()
+	p0, p1 := &fakeClient{err: errors.New("connection refused")}, &fakeClient{number: 2}
+	client := newTestClient(t, Config{FailureThreshold: 1, CircuitOpenPeriod: types.NewDuration(time.Hour)}, p0, p1)
+
+	// the request fails over to the second provider and the circuit of the first one is opened
+	header, err := client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), header.Number.Uint64())
+	assert.True(t, client.providers[0].circuitOpen(time.Now()))
+	assert.Less(t, client.providers[0].score, client.providers[1].score)
+
+	for i := 0; i < 5; i++ {
+		_, err := client.HeaderByNumber(ctx, nil)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, 1, p0.calls)
+	assert.Equal(t, 6, p1.calls)
+
+	// the errors of the request itself don't fail over
+	p1.err = ethereum.NotFound
+	_, err = client.HeaderByNumber(ctx, nil)
+	assert.ErrorIs(t, err, ethereum.NotFound)
+	assert.Equal(t, 1, p0.calls)
+	assert.False(t, client.providers[1].circuitOpen(time.Now()))
+
+	// the providers with the circuit open are tried as a last resort
+	p1.err = errors.New("timeout")
+	_, err = client.HeaderByNumber(ctx, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 2, p0.calls)
+
+	// the circuit is closed after a successful request
+	p0.err = nil
+	_, err = client.HeaderByNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.False(t, client.providers[0].circuitOpen(time.Now()))
+}
+
+func TestIsProviderError(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, isProviderError(ctx, nil))
+	assert.False(t, isProviderError(ctx, ethereum.NotFound))
+	assert.False(t, isProviderError(ctx, rpcError{code: 3}))
+	assert.True(t, isProviderError(ctx, rpcError{code: rpcLimitExceededCode}))
+	assert.True(t, isProviderError(ctx, context.DeadlineExceeded))
+
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.False(t, isProviderError(canceledCtx, context.Canceled))
+}
+
+func TestQuorumFilterLogs(t *testing.T) {
+	ctx := context.Background()
+	logs := []ethTypes.Log{{Address: common.HexToAddress("0x1"), BlockNumber: 1}}
+	otherLogs := []ethTypes.Log{{Address: common.HexToAddress("0x2"), BlockNumber: 1}}
+	p0, p1, p2 := &fakeClient{logs: otherLogs}, &fakeClient{logs: logs}, &fakeClient{logs: logs}
+	client := newTestClient(t, Config{Quorum: 2}, p0, p1, p2)
+
+	result, err := client.QuorumFilterLogs(ctx, ethereum.FilterQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, logs, result)
+
+	p2.err = errors.New("connection refused")
+	_, err = client.QuorumFilterLogs(ctx, ethereum.FilterQuery{})
+	assert.ErrorIs(t, err, ErrQuorumNotReached)
+
+	// a single provider is asked without quorum
+	client.cfg.Quorum = 0
+	p0.calls, p1.calls, p2.calls = 0, 0, 0
+	_, err = client.QuorumFilterLogs(ctx, ethereum.FilterQuery{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, p0.calls+p1.calls+p2.calls)
+}
+
+func TestQuorumBlockNumber(t *testing.T) {
+	ctx := context.Background()
+	p0, p1, p2 := &fakeClient{number: 100}, &fakeClient{number: 98}, &fakeClient{number: 99}
+	client := newTestClient(t, Config{Quorum: 2}, p0, p1, p2)
+
+	number, err := client.QuorumBlockNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(99), number)
+
+	client.cfg.Quorum = 3
+	number, err = client.QuorumBlockNumber(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(98), number)
+
+	p1.err = errors.New("connection refused")
+	_, err = client.QuorumBlockNumber(ctx, nil)
+	assert.ErrorIs(t, err, ErrQuorumNotReached)
+}
+
+func TestTransactionByHashFromAll(t *testing.T) {
+	ctx := context.Background()
+	tx := ethTypes.NewTx(&ethTypes.LegacyTx{Nonce: 1})
+	p0, p1, p2 := &fakeClient{}, &fakeClient{}, &fakeClient{tx: tx}
+	client := newTestClient(t, Config{}, p0, p1, p2)
+
+	// the tx is found even if only the last provider has seen it
+	result, _, err := client.TransactionByHashFromAll(ctx, tx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, tx.Hash(), result.Hash())
+	assert.Equal(t, 1, p0.calls)
+	assert.Equal(t, 1, p1.calls)
+
+	p2.tx = nil
+	_, _, err = client.TransactionByHashFromAll(ctx, tx.Hash())
+	assert.ErrorIs(t, err, ethereum.NotFound)
+
+	// the tx is not reported as not found when a provider fails to answer
+	p2.err = errors.New("connection refused")
+	_, _, err = client.TransactionByHashFromAll(ctx, tx.Hash())
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, ethereum.NotFound)
+}
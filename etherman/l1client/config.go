@@ -0,0 +1,41 @@
+package l1client
+
+import "github.com/0xPolygonHermez/zkevm-node/config/types"
+
+// Config is the configuration of the multi-provider L1 client
+type Config struct {
+	// Providers are the L1 providers, the Etherman URL is used as the only provider when empty
+	Providers []ProviderConfig `mapstructure:"Providers"`
+
+	// RequestTimeout is the timeout of each request to a provider, the request fails over
+	// to the next provider when it's reached
+	RequestTimeout types.Duration `mapstructure:"RequestTimeout"`
+
+	// SlowRequestThreshold is the latency over which a successful request lowers the health
+	// score of the provider, so it receives less requests
+	SlowRequestThreshold types.Duration `mapstructure:"SlowRequestThreshold"`
+
+	// FailureThreshold is the number of consecutive failed requests that open the circuit of
+	// a provider, 0 to never open it
+	FailureThreshold uint64 `mapstructure:"FailureThreshold"`
+
+	// CircuitOpenPeriod is the time the circuit of a provider stays open before it receives
+	// a request again
+	CircuitOpenPeriod types.Duration `mapstructure:"CircuitOpenPeriod"`
+
+	// Quorum is the number of providers that must agree on the critical reads, the rollup
+	// events and the finalized block number. 0 or 1 to read them from a single provider
+	Quorum uint64 `mapstructure:"Quorum"`
+}
+
+// ProviderConfig is the configuration of a L1 provider
+type ProviderConfig struct {
+	// Name identifies the provider in the logs and metrics, so the URL and its credentials are not exposed
+	Name string `mapstructure:"Name"`
+
+	// URL is the URL of the provider
+	URL string `mapstructure:"URL"`
+
+	// Weight is the share of the requests sent to the provider while it's healthy, 0 is taken as 1
+	Weight uint64 `mapstructure:"Weight"`
+}
@@ -0,0 +1,108 @@
+package l1client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/0xPolygonHermez/zkevm-node/etherman/metrics"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// QuorumFilterLogs returns the logs of the query agreed by the quorum of providers. The logs are read
+// from a single provider when the quorum is not configured
+func (c *Client) QuorumFilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error) {
+	if c.cfg.Quorum <= 1 {
+		return c.FilterLogs(ctx, q)
+	}
+
+	results := askQuorum(ctx, c, func(ctx context.Context, client EthereumClient) ([]types.Log, error) {
+		return client.FilterLogs(ctx, q)
+	})
+	votes := map[common.Hash]uint64{}
+	for _, r := range results {
+		digest, err := logsDigest(r.result)
+		if err != nil {
+			return nil, err
+		}
+		votes[digest]++
+		if votes[digest] >= c.cfg.Quorum {
+			return r.result, nil
+		}
+	}
+
+	metrics.L1QuorumFailure()
+	return nil, fmt.Errorf("%w: %d of %d providers answered, %d different logs", ErrQuorumNotReached, len(results), len(c.providers), len(votes))
+}
+
+// QuorumBlockNumber returns the highest number of the block tag, like finalized or safe, reached by the
+// quorum of providers. The block number is read from a single provider when the quorum is not configured
+func (c *Client) QuorumBlockNumber(ctx context.Context, number *big.Int) (uint64, error) {
+	if c.cfg.Quorum <= 1 {
+		header, err := c.HeaderByNumber(ctx, number)
+		if err != nil || header == nil {
+			return 0, err
+		}
+		return header.Number.Uint64(), nil
+	}
+
+	results := askQuorum(ctx, c, func(ctx context.Context, client EthereumClient) (*types.Header, error) {
+		return client.HeaderByNumber(ctx, number)
+	})
+	numbers := make([]uint64, 0, len(results))
+	for _, r := range results {
+		if r.result != nil {
+			numbers = append(numbers, r.result.Number.Uint64())
+		}
+	}
+	if uint64(len(numbers)) < c.cfg.Quorum {
+		metrics.L1QuorumFailure()
+		return 0, fmt.Errorf("%w: %d of %d providers answered", ErrQuorumNotReached, len(numbers), len(c.providers))
+	}
+
+	// the providers move forward at different paces, the quorum has reached the lowest of the highest numbers
+	sort.Slice(numbers, func(i, j int) bool { return numbers[i] > numbers[j] })
+	return numbers[c.cfg.Quorum-1], nil
+}
+
+// TransactionByHashFromAll returns the tx with the hash from any of the providers. A tx sent through a
+// provider may not have reached the others yet, so all of them are asked before reporting the tx as not
+// found. The tx is not reported as not found if any provider failed to answer
+func (c *Client) TransactionByHashFromAll(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+	type txResult struct {
+		tx        *types.Transaction
+		isPending bool
+	}
+	results := askProviders(ctx, c, c.providers, func(ctx context.Context, client EthereumClient) (txResult, error) {
+		tx, isPending, err := client.TransactionByHash(ctx, txHash)
+		return txResult{tx: tx, isPending: isPending}, err
+	})
+	err := ethereum.NotFound
+	for _, r := range results {
+		if r.err == nil {
+			return r.result.tx, r.result.isPending, nil
+		}
+		if !errors.Is(r.err, ethereum.NotFound) {
+			err = fmt.Errorf("L1 provider %s failed to get tx %s: %w", r.provider, txHash, r.err)
+		}
+	}
+	return nil, false, err
+}
+
+// logsDigest returns a hash identifying the logs
+func logsDigest(logs []types.Log) (common.Hash, error) {
+	if len(logs) == 0 {
+		return common.Hash{}, nil
+	}
+	b, err := json.Marshal(logs)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return crypto.Keccak256Hash(b), nil
+}
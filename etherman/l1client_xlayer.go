@@ -0,0 +1,53 @@
+package etherman
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/etherman/l1client"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// ethereumClientXLayer is the L1 client used by the etherman and the smart contract bindings
+type ethereumClientXLayer interface {
+	ethereumClient
+	bind.ContractBackend
+}
+
+// l1QuorumReader reads the critical L1 data with the agreement of the quorum of providers, or from all of them
+type l1QuorumReader interface {
+	QuorumFilterLogs(ctx context.Context, q ethereum.FilterQuery) ([]types.Log, error)
+	QuorumBlockNumber(ctx context.Context, number *big.Int) (uint64, error)
+	TransactionByHashFromAll(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error)
+}
+
+// newEthereumClientXLayer connects to the configured L1 providers, or to the L1 URL when there are none
+func newEthereumClientXLayer(cfg Config) (ethereumClientXLayer, error) {
+	if len(cfg.L1Client.Providers) == 0 {
+		return ethclient.Dial(cfg.URL)
+	}
+	log.Infof("connecting to %d L1 providers, quorum %d", len(cfg.L1Client.Providers), cfg.L1Client.Quorum)
+	return l1client.New(cfg.L1Client)
+}
+
+// quorumFilterLogsXLayer reads the logs with the agreement of the L1 providers quorum, when configured
+func (etherMan *Client) quorumFilterLogsXLayer(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	if quorumReader, ok := etherMan.EthClient.(l1QuorumReader); ok {
+		return quorumReader.QuorumFilterLogs(ctx, query)
+	}
+	return etherMan.EthClient.FilterLogs(ctx, query)
+}
+
+// GetTxFromAllProviders gets the tx from any of the L1 providers, all of them are asked before reporting
+// it as not found, when several providers are configured
+func (etherMan *Client) GetTxFromAllProviders(ctx context.Context, txHash common.Hash) (*types.Transaction, bool, error) {
+	if quorumReader, ok := etherMan.EthClient.(l1QuorumReader); ok {
+		return quorumReader.TransactionByHashFromAll(ctx, txHash)
+	}
+	return etherMan.EthClient.TransactionByHash(ctx, txHash)
+}
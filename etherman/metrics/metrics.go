@@ -76,6 +76,12 @@ func Register() {
 
 	metrics.RegisterCounters(counters...)
 	metrics.RegisterHistograms(histograms...)
+
+	// XLayer handler
+	metrics.RegisterCounters(countersXLayer...)
+	metrics.RegisterCounterVecs(counterVecsXLayer...)
+	metrics.RegisterHistogramVecs(histogramVecsXLayer...)
+	metrics.RegisterGaugeVecs(gaugeVecsXLayer...)
 }
 
 // ReadAndProcessAllEventsTime observes the time read and process all event on the histogram.
@@ -0,0 +1,109 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// L1ProviderLabelName is the name of the label for the L1 provider.
+	L1ProviderLabelName = "provider"
+
+	// L1ProviderRequestsName is the name of the metric that counts the requests sent to each L1 provider.
+	L1ProviderRequestsName = Prefix + "l1_provider_requests"
+
+	// L1ProviderErrorsName is the name of the metric that counts the failed requests of each L1 provider.
+	L1ProviderErrorsName = Prefix + "l1_provider_errors"
+
+	// L1ProviderLatencyName is the name of the metric that shows the latency of the requests of each L1 provider.
+	L1ProviderLatencyName = Prefix + "l1_provider_latency"
+
+	// L1ProviderHealthScoreName is the name of the metric that shows the health score of each L1 provider.
+	L1ProviderHealthScoreName = Prefix + "l1_provider_health_score"
+
+	// L1ProviderCircuitOpenName is the name of the metric that shows if the circuit of each L1 provider is open.
+	L1ProviderCircuitOpenName = Prefix + "l1_provider_circuit_open"
+
+	// L1QuorumFailuresName is the name of the metric that counts the reads without agreement of the L1 providers quorum.
+	L1QuorumFailuresName = Prefix + "l1_quorum_failures"
+)
+
+var (
+	countersXLayer = []prometheus.CounterOpts{
+		{
+			Name: L1QuorumFailuresName,
+			Help: "[ETHERMAN] count reads without agreement of the L1 providers quorum",
+		},
+	}
+
+	counterVecsXLayer = []metrics.CounterVecOpts{
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: L1ProviderRequestsName,
+				Help: "[ETHERMAN] count requests sent to the L1 provider",
+			},
+			Labels: []string{L1ProviderLabelName},
+		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: L1ProviderErrorsName,
+				Help: "[ETHERMAN] count failed requests of the L1 provider",
+			},
+			Labels: []string{L1ProviderLabelName},
+		},
+	}
+
+	histogramVecsXLayer = []metrics.HistogramVecOpts{
+		{
+			HistogramOpts: prometheus.HistogramOpts{
+				Name: L1ProviderLatencyName,
+				Help: "[ETHERMAN] latency of the requests of the L1 provider",
+			},
+			Labels: []string{L1ProviderLabelName},
+		},
+	}
+
+	gaugeVecsXLayer = []metrics.GaugeVecOpts{
+		{
+			GaugeOpts: prometheus.GaugeOpts{
+				Name: L1ProviderHealthScoreName,
+				Help: "[ETHERMAN] health score of the L1 provider, from 0 to 1",
+			},
+			Labels: []string{L1ProviderLabelName},
+		},
+		{
+			GaugeOpts: prometheus.GaugeOpts{
+				Name: L1ProviderCircuitOpenName,
+				Help: "[ETHERMAN] 1 if the circuit of the L1 provider is open, 0 otherwise",
+			},
+			Labels: []string{L1ProviderLabelName},
+		},
+	}
+)
+
+// L1ProviderRequest increases the requests counter and observes the latency of the L1 provider,
+// increasing the errors counter if the request failed
+func L1ProviderRequest(provider string, latency time.Duration, failed bool) {
+	metrics.CounterVecInc(L1ProviderRequestsName, provider)
+	metrics.HistogramVecObserve(L1ProviderLatencyName, provider, float64(latency)/float64(time.Second))
+	if failed {
+		metrics.CounterVecInc(L1ProviderErrorsName, provider)
+	}
+}
+
+// L1ProviderHealth sets the health score and the circuit state of the L1 provider
+func L1ProviderHealth(provider string, score float64, circuitOpen bool) {
+	metrics.GaugeVecSet(L1ProviderHealthScoreName, provider, score)
+	open := float64(0)
+	if circuitOpen {
+		open = 1
+	}
+	metrics.GaugeVecSet(L1ProviderCircuitOpenName, provider, open)
+}
+
+// L1QuorumFailure increases the counter of the reads without agreement of the L1 providers quorum
+func L1QuorumFailure() {
+	metrics.CounterInc(L1QuorumFailuresName)
+}
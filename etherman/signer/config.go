@@ -0,0 +1,86 @@
+package signer
+
+import (
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// TypeLocal signs with a private key loaded from a key store file
+	TypeLocal = "local"
+	// TypeRemote signs with a remote signer exposing a JSON-RPC sign transaction method, like web3signer or Clef
+	TypeRemote = "remote"
+	// TypeHSM signs with a private key stored in a hardware security module
+	TypeHSM = "hsm"
+)
+
+// Config is the configuration of a L1 signer
+type Config struct {
+	// Type is the backend of the signer: "local", "remote" or "hsm"
+	Type string `mapstructure:"Type"`
+
+	// Address is the address of the signing key, required by the remote signer. It's checked
+	// against the key of the local and hsm signers when set
+	Address common.Address `mapstructure:"Address"`
+
+	// Keystore is the key store file of the local signer
+	Keystore types.KeystoreFileConfig `mapstructure:"Keystore"`
+
+	// Remote is the configuration of the remote signer
+	Remote RemoteConfig `mapstructure:"Remote"`
+
+	// HSM is the configuration of the hsm signer
+	HSM HSMConfig `mapstructure:"HSM"`
+
+	// Policy restricts the calls signed with the key. Each rule is a contract address and a method
+	// separated by a colon, the method being a 4 bytes selector, a signature or * for any method,
+	// e.g. "0x519E42c24163192Dca44CD3fBDCEBF6be9130987:approve(address,uint256)".
+	// Empty to allow any call. The zero value self-transfers used to recover the nonce are always allowed
+	Policy []string `mapstructure:"Policy"`
+}
+
+// Validate checks the type of the signer, and that the driver of a hsm signer is registered
+func (c Config) Validate() error {
+	switch c.Type {
+	case TypeLocal, TypeRemote:
+		return nil
+	case TypeHSM:
+		if _, err := getHSMDriver(c.HSM.Driver); err != nil {
+			return fmt.Errorf("hsm signer %s not supported by this binary: %w", c.Address, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("%w: %s", ErrUnknownSignerType, c.Type)
+	}
+}
+
+// RemoteConfig is the configuration of a remote signer
+type RemoteConfig struct {
+	// URL is the JSON-RPC endpoint of the remote signer
+	URL string `mapstructure:"URL"`
+
+	// Method is the JSON-RPC method used to sign the txs, eth_signTransaction for web3signer
+	// and account_signTransaction for Clef
+	Method string `mapstructure:"Method"`
+
+	// Timeout is the timeout of the requests to the remote signer
+	Timeout types.Duration `mapstructure:"Timeout"`
+}
+
+// HSMConfig is the configuration of a hsm signer
+type HSMConfig struct {
+	// Driver is the name of the PKCS#11 driver of the hsm. No driver is built in, it must be registered
+	// with RegisterHSMDriver by the binary before the config is loaded
+	Driver string `mapstructure:"Driver"`
+
+	// Slot is the slot of the token storing the key
+	Slot uint64 `mapstructure:"Slot"`
+
+	// PIN is the user PIN to log in the token
+	PIN string `mapstructure:"PIN"`
+
+	// KeyLabel is the label of the private key object
+	KeyLabel string `mapstructure:"KeyLabel"`
+}
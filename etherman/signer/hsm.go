@@ -0,0 +1,155 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// hsmSignatureLength is the length of a CKM_ECDSA signature, r and s of 32 bytes each
+const hsmSignatureLength = 64
+
+var (
+	// ErrUnknownHSMDriver is returned when the configured hsm driver is not registered
+	ErrUnknownHSMDriver = errors.New("unknown hsm driver")
+
+	hsmDriversMutex sync.RWMutex
+	hsmDrivers      = map[string]HSMDriver{}
+
+	secp256k1HalfN = new(big.Int).Rsh(crypto.S256().Params().N, 1)
+)
+
+// HSMDriver opens sessions with the tokens of a PKCS#11 module
+type HSMDriver interface {
+	// OpenSession opens a session with the token of the slot and logs in with the user PIN
+	OpenSession(slot uint64, pin string) (HSMSession, error)
+}
+
+// HSMSession is a logged in session with a PKCS#11 token
+type HSMSession interface {
+	// FindKey finds the secp256k1 private key object with the label
+	FindKey(label string) (HSMKey, error)
+	// Close logs out and closes the session
+	Close() error
+}
+
+// HSMKey is a secp256k1 private key object stored in a PKCS#11 token
+type HSMKey interface {
+	// PublicKey returns the public key of the key pair
+	PublicKey() (*ecdsa.PublicKey, error)
+	// Sign signs the digest with the CKM_ECDSA mechanism, returning r and s of 32 bytes each
+	Sign(digest []byte) ([]byte, error)
+}
+
+// RegisterHSMDriver registers a PKCS#11 driver to be used by the hsm signers configured with its name
+func RegisterHSMDriver(name string, driver HSMDriver) {
+	hsmDriversMutex.Lock()
+	defer hsmDriversMutex.Unlock()
+	hsmDrivers[name] = driver
+}
+
+func getHSMDriver(name string) (HSMDriver, error) {
+	hsmDriversMutex.RLock()
+	defer hsmDriversMutex.RUnlock()
+	driver, found := hsmDrivers[name]
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownHSMDriver, name)
+	}
+	return driver, nil
+}
+
+// hsmSigner signs with a private key stored in a hardware security module
+type hsmSigner struct {
+	session HSMSession
+	key     HSMKey
+	address common.Address
+	signer  types.Signer
+	mutex   sync.Mutex
+}
+
+func newHSM(cfg Config, chainID uint64) (Signer, error) {
+	driver, err := getHSMDriver(cfg.HSM.Driver)
+	if err != nil {
+		return nil, err
+	}
+	return NewHSM(driver, cfg.HSM.Slot, cfg.HSM.PIN, cfg.HSM.KeyLabel, cfg.Address, chainID)
+}
+
+// NewHSM creates a signer for the key with the label stored in the token of the slot. The address of
+// the key is checked against the address when it's not zero
+func NewHSM(driver HSMDriver, slot uint64, pin, label string, address common.Address, chainID uint64) (Signer, error) {
+	session, err := driver.OpenSession(slot, pin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open hsm session: %w", err)
+	}
+	key, err := session.FindKey(label)
+	if err != nil {
+		_ = session.Close()
+		return nil, fmt.Errorf("failed to find hsm key %s: %w", label, err)
+	}
+	publicKey, err := key.PublicKey()
+	if err != nil {
+		_ = session.Close()
+		return nil, fmt.Errorf("failed to get hsm public key: %w", err)
+	}
+	keyAddress := crypto.PubkeyToAddress(*publicKey)
+	if err := checkAddress(address, keyAddress); err != nil {
+		_ = session.Close()
+		return nil, err
+	}
+	return &hsmSigner{
+		session: session,
+		key:     key,
+		address: keyAddress,
+		signer:  types.LatestSignerForChainID(new(big.Int).SetUint64(chainID)),
+	}, nil
+}
+
+// Address returns the address of the signing key
+func (s *hsmSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx signs the tx with the hsm key
+func (s *hsmSigner) SignTx(_ context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	hash := s.signer.Hash(tx)
+
+	// PKCS#11 sessions are not safe for concurrent use
+	s.mutex.Lock()
+	rs, err := s.key.Sign(hash.Bytes())
+	s.mutex.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign tx with the hsm: %w", err)
+	}
+	if len(rs) != hsmSignatureLength {
+		return nil, fmt.Errorf("%w: hsm signature length %d", ErrInvalidSignedTx, len(rs))
+	}
+
+	// the hsm doesn't enforce the low s values required by the L1 (EIP-2)
+	r := new(big.Int).SetBytes(rs[:32])
+	sValue := new(big.Int).SetBytes(rs[32:])
+	if sValue.Cmp(secp256k1HalfN) > 0 {
+		sValue.Sub(crypto.S256().Params().N, sValue)
+	}
+
+	// the hsm doesn't return the recovery id, it's found by recovering the public key
+	sig := make([]byte, crypto.SignatureLength)
+	r.FillBytes(sig[:32])
+	sValue.FillBytes(sig[32:64])
+	for recoveryID := byte(0); recoveryID <= 1; recoveryID++ {
+		sig[64] = recoveryID
+		publicKey, err := crypto.SigToPub(hash.Bytes(), sig)
+		if err != nil || crypto.PubkeyToAddress(*publicKey) != s.address {
+			continue
+		}
+		return tx.WithSignature(s.signer, sig)
+	}
+	return nil, fmt.Errorf("%w: hsm signature doesn't recover the key address %s", ErrInvalidSignedTx, s.address)
+}
@@ -0,0 +1,125 @@
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	policyAnyMethod = "*"
+	selectorLength  = 4
+)
+
+var (
+	// ErrPolicyViolation is returned when the tx to be signed is not allowed by the signing policy
+	ErrPolicyViolation = errors.New("signing policy violation")
+	// ErrInvalidPolicy is returned when a rule of the signing policy can't be parsed
+	ErrInvalidPolicy = errors.New("invalid signing policy")
+)
+
+// policy is the set of contracts and methods a key is allowed to call, a nil set of
+// methods allows any method of the contract
+type policy map[common.Address]map[[selectorLength]byte]bool
+
+// newPolicy parses the policy rules, "<contract address>:<selector|signature|*>"
+func newPolicy(rules []string) (policy, error) {
+	p := policy{}
+	for _, rule := range rules {
+		contract, method, found := strings.Cut(strings.TrimSpace(rule), ":")
+		if !found || !common.IsHexAddress(contract) || method == "" {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPolicy, rule)
+		}
+		address := common.HexToAddress(contract)
+		if method == policyAnyMethod {
+			p[address] = nil
+			continue
+		}
+		methods, exists := p[address]
+		if exists && methods == nil {
+			// any method is already allowed
+			continue
+		}
+		selector, err := parseSelector(method)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s: %v", ErrInvalidPolicy, rule, err)
+		}
+		if methods == nil {
+			methods = map[[selectorLength]byte]bool{}
+			p[address] = methods
+		}
+		methods[selector] = true
+	}
+	return p, nil
+}
+
+// parseSelector parses a 4 bytes hex selector or computes the selector of a method signature
+func parseSelector(method string) ([selectorLength]byte, error) {
+	var selector [selectorLength]byte
+	if strings.Contains(method, "(") {
+		copy(selector[:], crypto.Keccak256([]byte(strings.ReplaceAll(method, " ", "")))[:selectorLength])
+		return selector, nil
+	}
+	b, err := hexutil.Decode(method)
+	if err != nil {
+		return selector, err
+	}
+	if len(b) != selectorLength {
+		return selector, fmt.Errorf("selector must be %d bytes", selectorLength)
+	}
+	copy(selector[:], b)
+	return selector, nil
+}
+
+// check checks if the tx sent from the address calls a contract and method allowed by the policy. The zero
+// value self-transfers without data are always allowed, they are sent to recover the nonce of the address
+func (p policy) check(from common.Address, tx *types.Transaction) error {
+	if isSelfTransfer(from, tx) {
+		return nil
+	}
+	if tx.To() == nil {
+		return fmt.Errorf("%w: contract creation not allowed", ErrPolicyViolation)
+	}
+	methods, found := p[*tx.To()]
+	if !found {
+		return fmt.Errorf("%w: calls to %s not allowed", ErrPolicyViolation, tx.To())
+	}
+	if methods == nil {
+		return nil
+	}
+	data := tx.Data()
+	if len(data) < selectorLength {
+		return fmt.Errorf("%w: calls to %s without method not allowed", ErrPolicyViolation, tx.To())
+	}
+	var selector [selectorLength]byte
+	copy(selector[:], data[:selectorLength])
+	if !methods[selector] {
+		return fmt.Errorf("%w: method %s of %s not allowed", ErrPolicyViolation, hexutil.Encode(selector[:]), tx.To())
+	}
+	return nil
+}
+
+// isSelfTransfer checks if the tx is a zero value transfer without data from the address to itself
+func isSelfTransfer(from common.Address, tx *types.Transaction) bool {
+	return tx.To() != nil && *tx.To() == from && tx.Value().Sign() == 0 && len(tx.Data()) == 0
+}
+
+// policySigner checks the signing policy before signing with the wrapped signer
+type policySigner struct {
+	Signer
+	policy policy
+}
+
+// SignTx signs the tx if it's allowed by the signing policy
+func (s *policySigner) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	if err := s.policy.check(s.Address(), tx); err != nil {
+		return nil, err
+	}
+	return s.Signer.SignTx(ctx, tx)
+}
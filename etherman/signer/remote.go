@@ -0,0 +1,160 @@
+package signer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// MethodEthSignTransaction is the sign transaction method of web3signer
+	MethodEthSignTransaction = "eth_signTransaction"
+	// MethodAccountSignTransaction is the sign transaction method of Clef
+	MethodAccountSignTransaction = "account_signTransaction"
+
+	defaultRemoteTimeout = 30 * time.Second
+)
+
+// remoteSigner signs with a remote signer exposing a JSON-RPC sign transaction method
+type remoteSigner struct {
+	address common.Address
+	client  *rpc.Client
+	method  string
+	timeout time.Duration
+	signer  types.Signer
+	chainID *big.Int
+}
+
+// remoteSignTxArgs are the tx arguments sent to the remote signer
+type remoteSignTxArgs struct {
+	From                 common.Address  `json:"from"`
+	To                   *common.Address `json:"to,omitempty"`
+	Gas                  hexutil.Uint64  `json:"gas"`
+	GasPrice             *hexutil.Big    `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big    `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big    `json:"maxPriorityFeePerGas,omitempty"`
+	MaxFeePerBlobGas     *hexutil.Big    `json:"maxFeePerBlobGas,omitempty"`
+	BlobVersionedHashes  []common.Hash   `json:"blobVersionedHashes,omitempty"`
+	Value                hexutil.Big     `json:"value"`
+	Nonce                hexutil.Uint64  `json:"nonce"`
+	Data                 hexutil.Bytes   `json:"data"`
+	Input                hexutil.Bytes   `json:"input"`
+	ChainID              *hexutil.Big    `json:"chainId,omitempty"`
+}
+
+// clefSignTxResult is the result of the Clef sign transaction method
+type clefSignTxResult struct {
+	Raw hexutil.Bytes `json:"raw"`
+}
+
+func newRemote(cfg Config, chainID uint64) (Signer, error) {
+	if cfg.Remote.URL == "" {
+		return nil, errors.New("remote signer URL not configured")
+	}
+	if cfg.Address == (common.Address{}) {
+		return nil, errors.New("remote signer address not configured")
+	}
+	method := cfg.Remote.Method
+	if method == "" {
+		method = MethodEthSignTransaction
+	}
+	timeout := cfg.Remote.Timeout.Duration
+	if timeout <= 0 {
+		timeout = defaultRemoteTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	client, err := rpc.DialContext(ctx, cfg.Remote.URL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to the remote signer: %w", err)
+	}
+	bChainID := new(big.Int).SetUint64(chainID)
+	return &remoteSigner{
+		address: cfg.Address,
+		client:  client,
+		method:  method,
+		timeout: timeout,
+		signer:  types.LatestSignerForChainID(bChainID),
+		chainID: bChainID,
+	}, nil
+}
+
+// Address returns the address of the signing key
+func (s *remoteSigner) Address() common.Address {
+	return s.address
+}
+
+// SignTx signs the tx with the remote signer
+func (s *remoteSigner) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	var result json.RawMessage
+	if err := s.client.CallContext(ctx, &result, s.method, s.toArgs(tx)); err != nil {
+		return nil, fmt.Errorf("failed to sign tx with the remote signer: %w", err)
+	}
+
+	raw, err := decodeRemoteSignTxResult(result)
+	if err != nil {
+		return nil, err
+	}
+	signedTx := new(types.Transaction)
+	if err := signedTx.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignedTx, err)
+	}
+
+	return withSignatureValues(s.signer, tx, signedTx, s.address)
+}
+
+// toArgs converts the tx to the arguments of the sign transaction method
+func (s *remoteSigner) toArgs(tx *types.Transaction) remoteSignTxArgs {
+	args := remoteSignTxArgs{
+		From:    s.address,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		Input:   tx.Data(),
+		ChainID: (*hexutil.Big)(s.chainID),
+	}
+	if tx.Value() != nil {
+		args.Value = hexutil.Big(*tx.Value())
+	}
+	switch tx.Type() {
+	case types.LegacyTxType, types.AccessListTxType:
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	default:
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+	}
+	if tx.Type() == types.BlobTxType {
+		args.MaxFeePerBlobGas = (*hexutil.Big)(tx.BlobGasFeeCap())
+		args.BlobVersionedHashes = tx.BlobHashes()
+	}
+	return args
+}
+
+// decodeRemoteSignTxResult returns the raw signed tx of the result, either a hex string
+// as returned by web3signer or an object with the raw field as returned by Clef
+func decodeRemoteSignTxResult(result json.RawMessage) ([]byte, error) {
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err == nil {
+		return raw, nil
+	}
+	var clefResult clefSignTxResult
+	if err := json.Unmarshal(result, &clefResult); err != nil {
+		return nil, fmt.Errorf("%w: unexpected remote signer result %s", ErrInvalidSignedTx, string(result))
+	}
+	if len(clefResult.Raw) == 0 {
+		return nil, fmt.Errorf("%w: empty remote signer result", ErrInvalidSignedTx)
+	}
+	return clefResult.Raw, nil
+}
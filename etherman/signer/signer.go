@@ -0,0 +1,151 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+var (
+	// ErrUnknownSignerType is returned when the type of the signer is not supported
+	ErrUnknownSignerType = errors.New("unknown signer type")
+	// ErrAddressMismatch is returned when the key of the signer doesn't match the configured address
+	ErrAddressMismatch = errors.New("signer address mismatch")
+	// ErrInvalidSignedTx is returned when the tx signed by a remote signer or a hsm doesn't match the tx to be signed
+	ErrInvalidSignedTx = errors.New("invalid signed tx")
+)
+
+// Signer signs the L1 txs sent from the address of its key
+type Signer interface {
+	// Address returns the address of the signing key
+	Address() common.Address
+	// SignTx signs the tx for the L1 chain
+	SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error)
+}
+
+// New creates the signer of the configured type, the signing policy is enforced when it's not empty
+func New(cfg Config, chainID uint64) (Signer, error) {
+	var (
+		s   Signer
+		err error
+	)
+	switch cfg.Type {
+	case TypeLocal:
+		s, err = newLocalFromKeystore(cfg, chainID)
+	case TypeRemote:
+		s, err = newRemote(cfg, chainID)
+	case TypeHSM:
+		s, err = newHSM(cfg, chainID)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownSignerType, cfg.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(cfg.Policy) == 0 {
+		return s, nil
+	}
+	p, err := newPolicy(cfg.Policy)
+	if err != nil {
+		return nil, err
+	}
+	return &policySigner{Signer: s, policy: p}, nil
+}
+
+// localSigner signs with a private key held in memory
+type localSigner struct {
+	key    *ecdsa.PrivateKey
+	signer types.Signer
+}
+
+// NewLocal creates a signer for the private key
+func NewLocal(key *ecdsa.PrivateKey, chainID uint64) Signer {
+	return &localSigner{key: key, signer: types.LatestSignerForChainID(new(big.Int).SetUint64(chainID))}
+}
+
+// newLocalFromKeystore creates a local signer for the key of the key store file
+func newLocalFromKeystore(cfg Config, chainID uint64) (Signer, error) {
+	keystoreEncrypted, err := os.ReadFile(filepath.Clean(cfg.Keystore.Path))
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("decrypting key from: %v", cfg.Keystore.Path)
+	key, err := keystore.DecryptKey(keystoreEncrypted, cfg.Keystore.Password)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkAddress(cfg.Address, key.Address); err != nil {
+		return nil, err
+	}
+	return NewLocal(key.PrivateKey, chainID), nil
+}
+
+// Address returns the address of the signing key
+func (s *localSigner) Address() common.Address {
+	return crypto.PubkeyToAddress(s.key.PublicKey)
+}
+
+// SignTx signs the tx for the L1 chain
+func (s *localSigner) SignTx(_ context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return types.SignTx(tx, s.signer, s.key)
+}
+
+// checkAddress checks that the address of the key matches the configured one, if any
+func checkAddress(configured, key common.Address) error {
+	if configured != (common.Address{}) && configured != key {
+		return fmt.Errorf("%w: configured %s, key %s", ErrAddressMismatch, configured, key)
+	}
+	return nil
+}
+
+// withSignatureValues returns the tx with the signature values of the signed one. The signed tx must
+// have the same signing hash, and its signature must recover the address of the signer
+func withSignatureValues(signer types.Signer, tx, signedTx *types.Transaction, address common.Address) (*types.Transaction, error) {
+	if signer.Hash(signedTx) != signer.Hash(tx) {
+		return nil, fmt.Errorf("%w: signing hash %s, expected %s", ErrInvalidSignedTx, signer.Hash(signedTx), signer.Hash(tx))
+	}
+
+	v, r, s := signedTx.RawSignatureValues()
+	recoveryID := new(big.Int).Set(v)
+	if signedTx.Type() == types.LegacyTxType {
+		if signedTx.Protected() {
+			// EIP-155: v = recovery id + chain id * 2 + 35
+			recoveryID.Sub(recoveryID, new(big.Int).Add(new(big.Int).Mul(signer.ChainID(), big.NewInt(2)), big.NewInt(35))) //nolint:gomnd
+		} else {
+			recoveryID.Sub(recoveryID, big.NewInt(27)) //nolint:gomnd
+		}
+	}
+	if !recoveryID.IsUint64() || recoveryID.Uint64() > 1 {
+		return nil, fmt.Errorf("%w: invalid signature value v %s", ErrInvalidSignedTx, v)
+	}
+
+	sig := make([]byte, crypto.SignatureLength)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	sig[64] = byte(recoveryID.Uint64())
+
+	// the signature is applied to the original tx, so it keeps the blob sidecar not returned by the remote signers
+	result, err := tx.WithSignature(signer, sig)
+	if err != nil {
+		return nil, err
+	}
+	sender, err := types.Sender(signer, result)
+	if err != nil {
+		return nil, err
+	}
+	if sender != address {
+		return nil, fmt.Errorf("%w: signed by %s, expected %s", ErrInvalidSignedTx, sender, address)
+	}
+	return result, nil
+}
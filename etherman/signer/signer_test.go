@@ -0,0 +1,215 @@
+package signer
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainID = 1337
+
+var (
+	testContract = common.HexToAddress("0x610178dA211FEF7D417bC0e6FeD39F05609AD788")
+	// sequenceBatches selector
+	testSelector = common.FromHex("0xdef57e54")
+)
+
+func newTestTx(data []byte) *types.Transaction {
+	return types.NewTx(&types.DynamicFeeTx{
+		ChainID:   big.NewInt(testChainID),
+		Nonce:     1,
+		GasTipCap: big.NewInt(2),
+		GasFeeCap: big.NewInt(3),
+		Gas:       21000,
+		To:        &testContract,
+		Value:     big.NewInt(4),
+		Data:      data,
+	})
+}
+
+func TestLocalSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	s := NewLocal(key, testChainID)
+	assert.Equal(t, crypto.PubkeyToAddress(key.PublicKey), s.Address())
+
+	signedTx, err := s.SignTx(context.Background(), newTestTx(testSelector))
+	require.NoError(t, err)
+	sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(testChainID)), signedTx)
+	require.NoError(t, err)
+	assert.Equal(t, s.Address(), sender)
+}
+
+func TestPolicy(t *testing.T) {
+	other, from := common.HexToAddress("0x1"), common.HexToAddress("0x2")
+
+	_, err := newPolicy([]string{testContract.String()})
+	assert.ErrorIs(t, err, ErrInvalidPolicy)
+	_, err = newPolicy([]string{testContract.String() + ":0x01"})
+	assert.ErrorIs(t, err, ErrInvalidPolicy)
+	_, err = newPolicy([]string{"0x12:*"})
+	assert.ErrorIs(t, err, ErrInvalidPolicy)
+
+	p, err := newPolicy([]string{testContract.String() + ":0xdef57e54", other.String() + ":approve(address, uint256)"})
+	require.NoError(t, err)
+	assert.NoError(t, p.check(from, newTestTx(append(testSelector, 1, 2))))
+	assert.ErrorIs(t, p.check(from, newTestTx(common.FromHex("0x095ea7b3"))), ErrPolicyViolation)
+	assert.ErrorIs(t, p.check(from, newTestTx(nil)), ErrPolicyViolation)
+	assert.ErrorIs(t, p.check(from, types.NewTx(&types.DynamicFeeTx{Data: testSelector})), ErrPolicyViolation)
+	approveTx := types.NewTx(&types.DynamicFeeTx{To: &other, Data: common.FromHex("0x095ea7b3")})
+	assert.NoError(t, p.check(from, approveTx))
+	unknownTx := types.NewTx(&types.DynamicFeeTx{To: &common.Address{}, Data: testSelector})
+	assert.ErrorIs(t, p.check(from, unknownTx), ErrPolicyViolation)
+
+	// The zero value self-transfers are allowed to recover the nonce
+	assert.NoError(t, p.check(from, types.NewTx(&types.DynamicFeeTx{To: &from, Value: big.NewInt(0)})))
+	assert.ErrorIs(t, p.check(from, types.NewTx(&types.DynamicFeeTx{To: &from, Value: big.NewInt(1)})), ErrPolicyViolation)
+	assert.ErrorIs(t, p.check(from, types.NewTx(&types.DynamicFeeTx{To: &from, Data: testSelector})), ErrPolicyViolation)
+	assert.ErrorIs(t, p.check(other, types.NewTx(&types.DynamicFeeTx{To: &from})), ErrPolicyViolation)
+
+	p, err = newPolicy([]string{testContract.String() + ":0xdef57e54", testContract.String() + ":*"})
+	require.NoError(t, err)
+	assert.NoError(t, p.check(from, newTestTx(nil)))
+
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	s := &policySigner{Signer: NewLocal(key, testChainID), policy: p}
+	_, err = s.SignTx(context.Background(), approveTx)
+	assert.ErrorIs(t, err, ErrPolicyViolation)
+	_, err = s.SignTx(context.Background(), newTestTx(testSelector))
+	assert.NoError(t, err)
+}
+
+// newRemoteSignerServer starts a JSON-RPC server signing the txs with the key, the result
+// is returned as a hex string like web3signer or as an object like Clef
+func newRemoteSignerServer(t *testing.T, key *ecdsa.PrivateKey, clef bool) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			ID     json.RawMessage    `json:"id"`
+			Method string             `json:"method"`
+			Params []remoteSignTxArgs `json:"params"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		require.Len(t, req.Params, 1)
+		args := req.Params[0]
+		tx := types.NewTx(&types.DynamicFeeTx{
+			ChainID:   args.ChainID.ToInt(),
+			Nonce:     uint64(args.Nonce),
+			GasTipCap: args.MaxPriorityFeePerGas.ToInt(),
+			GasFeeCap: args.MaxFeePerGas.ToInt(),
+			Gas:       uint64(args.Gas),
+			To:        args.To,
+			Value:     args.Value.ToInt(),
+			Data:      args.Data,
+		})
+		signedTx, err := types.SignTx(tx, types.LatestSignerForChainID(args.ChainID.ToInt()), key)
+		require.NoError(t, err)
+		raw, err := signedTx.MarshalBinary()
+		require.NoError(t, err)
+
+		var result interface{} = hexutil.Bytes(raw)
+		if clef {
+			result = map[string]interface{}{"raw": hexutil.Bytes(raw), "tx": signedTx}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": result}))
+	}))
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+	tx := newTestTx(testSelector)
+
+	for _, clef := range []bool{false, true} {
+		server := newRemoteSignerServer(t, key, clef)
+		method := MethodEthSignTransaction
+		if clef {
+			method = MethodAccountSignTransaction
+		}
+		s, err := New(Config{Type: TypeRemote, Address: address, Remote: RemoteConfig{URL: server.URL, Method: method}}, testChainID)
+		require.NoError(t, err)
+
+		// the client is dialled once and reused by the signatures
+		for i := 0; i < 2; i++ {
+			signedTx, err := s.SignTx(context.Background(), tx)
+			require.NoError(t, err)
+			sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(testChainID)), signedTx)
+			require.NoError(t, err)
+			assert.Equal(t, address, sender)
+		}
+
+		// the signature of another key is rejected
+		s, err = New(Config{Type: TypeRemote, Address: common.HexToAddress("0x1"), Remote: RemoteConfig{URL: server.URL, Method: method}}, testChainID)
+		require.NoError(t, err)
+		_, err = s.SignTx(context.Background(), tx)
+		assert.ErrorIs(t, err, ErrInvalidSignedTx)
+
+		server.Close()
+	}
+
+	_, err = New(Config{Type: TypeRemote, Remote: RemoteConfig{URL: "http://localhost"}}, testChainID)
+	assert.Error(t, err)
+}
+
+func TestHSMSigner(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(key.PublicKey)
+
+	hsmKey := newHsmKeyMock(t)
+	hsmKey.On("PublicKey").Return(&key.PublicKey, nil)
+	highS := false
+	hsmKey.On("Sign", mock.Anything).Return(func(digest []byte) []byte {
+		sig, err := crypto.Sign(digest, key)
+		require.NoError(t, err)
+		if highS {
+			s := new(big.Int).SetBytes(sig[32:64])
+			new(big.Int).Sub(crypto.S256().Params().N, s).FillBytes(sig[32:64])
+		}
+		return sig[:hsmSignatureLength]
+	}, nil)
+	session := newHsmSessionMock(t)
+	session.On("FindKey", "sequencer").Return(hsmKey, nil)
+	driver := newHsmDriverMock(t)
+	driver.On("OpenSession", uint64(1), "1234").Return(session, nil)
+
+	RegisterHSMDriver("test", driver)
+	s, err := New(Config{Type: TypeHSM, Address: address, HSM: HSMConfig{Driver: "test", Slot: 1, PIN: "1234", KeyLabel: "sequencer"}}, testChainID)
+	require.NoError(t, err)
+	assert.Equal(t, address, s.Address())
+
+	for _, highS = range []bool{false, true} {
+		signedTx, err := s.SignTx(context.Background(), newTestTx(testSelector))
+		require.NoError(t, err)
+		sender, err := types.Sender(types.LatestSignerForChainID(big.NewInt(testChainID)), signedTx)
+		require.NoError(t, err)
+		assert.Equal(t, address, sender)
+	}
+
+	// the key must match the configured address
+	session.On("Close").Return(nil).Once()
+	_, err = New(Config{Type: TypeHSM, Address: common.HexToAddress("0x1"), HSM: HSMConfig{Driver: "test", Slot: 1, PIN: "1234", KeyLabel: "sequencer"}}, testChainID)
+	assert.ErrorIs(t, err, ErrAddressMismatch)
+
+	_, err = New(Config{Type: TypeHSM, HSM: HSMConfig{Driver: "unknown"}}, testChainID)
+	assert.ErrorIs(t, err, ErrUnknownHSMDriver)
+
+	// the hsm signers are rejected when the config is loaded if their driver is not registered
+	assert.NoError(t, Config{Type: TypeHSM, HSM: HSMConfig{Driver: "test"}}.Validate())
+	assert.ErrorIs(t, Config{Type: TypeHSM, HSM: HSMConfig{Driver: "unknown"}}.Validate(), ErrUnknownHSMDriver)
+	assert.ErrorIs(t, Config{Type: "kms"}.Validate(), ErrUnknownSignerType)
+}
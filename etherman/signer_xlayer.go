@@ -0,0 +1,33 @@
+package etherman
+
+import (
+	"context"
+
+	"github.com/0xPolygonHermez/zkevm-node/etherman/signer"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// LoadSigner creates the configured signer for the L1 chain and adds its authorization
+func (etherMan *Client) LoadSigner(cfg signer.Config) (signer.Signer, error) {
+	s, err := signer.New(cfg, etherMan.l1Cfg.L1ChainID)
+	if err != nil {
+		return nil, err
+	}
+	etherMan.AddSigner(s)
+	return s, nil
+}
+
+// AddSigner adds an authorization or replace an existent one to the account of the signer,
+// the txs sent from the account are signed by the signer
+func (etherMan *Client) AddSigner(s signer.Signer) {
+	log.Infof("added signer for address: %v", s.Address().String())
+	etherMan.auth[s.Address()] = bind.TransactOpts{
+		From: s.Address(),
+		Signer: func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			return s.SignTx(context.Background(), tx)
+		},
+	}
+}
@@ -0,0 +1,177 @@
+package ethtxmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+)
+
+// blobReplacementBumpPerMille is the minimum increase of the max fee, the max priority fee and
+// the max fee per blob gas required by the L1 blob pool to replace a pending blob tx, 100%
+const blobReplacementBumpPerMille = 1000
+
+var (
+	// ErrBlobTxNotSupported is returned when a blob tx is added while the custodial assets are enabled,
+	// since the custodial assets service only signs legacy txs
+	ErrBlobTxNotSupported = errors.New("blob txs are not supported by the custodial assets")
+	// ErrBlobGasNotAvailable is returned when the latest L1 block header doesn't contain the blob gas
+	// fields, the L1 network hasn't activated the Cancun fork
+	ErrBlobGasNotAvailable = errors.New("blob gas not available in the latest L1 block")
+)
+
+// isBlob checks if the monitored tx is built as a blob tx
+func (mTx *monitoredTx) isBlob() bool {
+	return mTx.blobSidecar != nil && mTx.blobGasFeeCap != nil && mTx.isDynamicFee()
+}
+
+// AddWithBlobs adds a blob transaction carrying the sidecar blobs to be sent and monitored. The blob
+// txs are always built with the dynamic fees, regardless of the DynamicFee Enable flag
+func (c *Client) AddWithBlobs(ctx context.Context, owner, id string, from common.Address, to *common.Address, value *big.Int, data []byte, gasOffset uint64, sidecar *types.BlobTxSidecar, dbTx pgx.Tx) error {
+	if c.cfg.CustodialAssets.Enable {
+		return ErrBlobTxNotSupported
+	}
+	if to == nil {
+		return errors.New("blob txs can't create contracts")
+	}
+
+	// get next nonce
+	nonce, err := c.etherman.CurrentNonce(ctx, from)
+	if err != nil {
+		err := fmt.Errorf("failed to get current nonce: %w", err)
+		log.Errorf(err.Error())
+		return err
+	}
+	// get gas
+	gas, err := c.etherman.EstimateGas(ctx, from, to, value, data)
+	if err != nil {
+		err := fmt.Errorf("failed to estimate gas: %w, data: %v", err, common.Bytes2Hex(data))
+		log.Error(err.Error())
+		if c.cfg.ForcedGas > 0 {
+			gas = c.cfg.ForcedGas
+		} else {
+			return err
+		}
+	}
+
+	fees, err := c.suggestedDynamicFees(ctx)
+	if err != nil {
+		err := fmt.Errorf("failed to get suggested dynamic fees: %w", err)
+		log.Errorf(err.Error())
+		return err
+	}
+	blobGasFeeCap, _, err := c.suggestedBlobGasFeeCap(ctx)
+	if err != nil {
+		err := fmt.Errorf("failed to get suggested blob gas fee cap: %w", err)
+		log.Errorf(err.Error())
+		return err
+	}
+
+	// create monitored tx
+	mTx := monitoredTx{
+		owner: owner, id: id, from: from, to: to,
+		nonce: nonce, value: value, data: data,
+		gas: gas, gasOffset: gasOffset,
+		gasPrice: fees.gasFeeCap, gasFeeCap: fees.gasFeeCap, gasTipCap: fees.gasTipCap,
+		blobGasFeeCap: blobGasFeeCap, blobSidecar: sidecar,
+		status: MonitoredTxStatusCreated,
+	}
+
+	// add to storage
+	err = c.storage.Add(ctx, mTx, dbTx)
+	if err != nil {
+		err := fmt.Errorf("failed to add tx to get monitored: %w", err)
+		log.Errorf(err.Error())
+		return err
+	}
+
+	mTxLog := log.WithFields("monitoredTx", mTx.id, "createdAt", mTx.createdAt)
+	mTxLog.Infof("created with %d blobs", len(sidecar.Blobs))
+
+	return nil
+}
+
+// suggestedBlobGasFeeCap returns the max fee per blob gas for a new blob tx, the blob base fee of the
+// next L1 block multiplied by the BlobFeeMultiplier, and the blob base fee of the next L1 block
+func (c *Client) suggestedBlobGasFeeCap(ctx context.Context) (*big.Int, *big.Int, error) {
+	header, err := c.etherman.GetLatestBlockHeader(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if header.ExcessBlobGas == nil || header.BlobGasUsed == nil {
+		return nil, nil, ErrBlobGasNotAvailable
+	}
+	nextBlobFee := eip4844.CalcBlobFee(eip4844.CalcExcessBlobGas(*header.ExcessBlobGas, *header.BlobGasUsed))
+
+	fBlobFee := new(big.Float).SetInt(nextBlobFee)
+	blobGasFeeCap, _ := fBlobFee.Mul(fBlobFee, big.NewFloat(c.cfg.DynamicFee.BlobFeeMultiplier)).Int(nil)
+	blobGasFeeCap = maxBigInt(blobGasFeeCap, nextBlobFee)
+
+	return c.limitBlobGasFeeCap(blobGasFeeCap), nextBlobFee, nil
+}
+
+// limitBlobGasFeeCap caps the max fee per blob gas to the MaxBlobFeePerGasLimit
+func (c *Client) limitBlobGasFeeCap(blobGasFeeCap *big.Int) *big.Int {
+	if c.cfg.DynamicFee.MaxBlobFeePerGasLimit > 0 {
+		if limit := new(big.Int).SetUint64(c.cfg.DynamicFee.MaxBlobFeePerGasLimit); blobGasFeeCap.Cmp(limit) == 1 {
+			return limit
+		}
+	}
+	return blobGasFeeCap
+}
+
+// reviewBlobFees updates the max fee, the max priority fee and the max fee per blob gas of a blob
+// monitored tx.
+//
+// The fees are kept while they are not lower than the suggested ones and still cover the base fee
+// and the blob base fee of the next L1 block. Otherwise the tx is replaced: the L1 blob pool only
+// accepts a replacement when all the fees are bumped at least by 100%, and both max fees cover the
+// base fees of the next L1 block increased by 12.5%. The bump is applied over the highest fees sent
+func (c *Client) reviewBlobFees(ctx context.Context, mTx *monitoredTx, mTxLogger *log.Logger) error {
+	suggested, err := c.suggestedDynamicFees(ctx)
+	if err != nil {
+		return err
+	}
+	suggestedBlobGasFeeCap, nextBlobFee, err := c.suggestedBlobGasFeeCap(ctx)
+	if err != nil {
+		return err
+	}
+
+	if mTx.gasFeeCap.Cmp(suggested.gasFeeCap) >= 0 && mTx.gasTipCap.Cmp(suggested.gasTipCap) >= 0 &&
+		mTx.gasFeeCap.Cmp(suggested.nextBaseFee) >= 0 &&
+		mTx.blobGasFeeCap.Cmp(suggestedBlobGasFeeCap) >= 0 && mTx.blobGasFeeCap.Cmp(nextBlobFee) >= 0 {
+		return nil
+	}
+
+	sent := mTx.highestSentFees()
+	gasTipCap := maxBigInt(suggested.gasTipCap, bumpPerMille(sent.GasTipCap, blobReplacementBumpPerMille))
+	minGasFeeCap := new(big.Int).Add(bumpPerMille(suggested.nextBaseFee, baseFeeMaxChangePerMille), gasTipCap)
+	gasFeeCap := maxBigInt(suggested.gasFeeCap, bumpPerMille(sent.GasFeeCap, blobReplacementBumpPerMille), minGasFeeCap)
+	gasFeeCap, gasTipCap = c.limitDynamicFees(gasFeeCap, gasTipCap)
+	blobGasFeeCap := maxBigInt(suggestedBlobGasFeeCap, bumpPerMille(sent.BlobGasFeeCap, blobReplacementBumpPerMille),
+		bumpPerMille(nextBlobFee, baseFeeMaxChangePerMille))
+	blobGasFeeCap = c.limitBlobGasFeeCap(blobGasFeeCap)
+
+	// a replacement below the 100% bump is rejected by the L1 blob pool, the tx is kept until it's mined
+	if gasFeeCap.Cmp(bumpPerMille(sent.GasFeeCap, blobReplacementBumpPerMille)) == -1 ||
+		gasTipCap.Cmp(bumpPerMille(sent.GasTipCap, blobReplacementBumpPerMille)) == -1 ||
+		blobGasFeeCap.Cmp(bumpPerMille(sent.BlobGasFeeCap, blobReplacementBumpPerMille)) == -1 {
+		mTxLogger.Warnf("monitored blob tx fees can't be bumped, max fee %v, max priority fee %v and max fee per blob gas %v reached the limits",
+			sent.GasFeeCap.String(), sent.GasTipCap.String(), sent.BlobGasFeeCap.String())
+		return nil
+	}
+
+	mTxLogger.Infof("monitored blob tx max fee updated from %v to %v, max priority fee updated from %v to %v, max fee per blob gas updated from %v to %v",
+		mTx.gasFeeCap.String(), gasFeeCap.String(), mTx.gasTipCap.String(), gasTipCap.String(), mTx.blobGasFeeCap.String(), blobGasFeeCap.String())
+	mTx.gasFeeCap = gasFeeCap
+	mTx.gasTipCap = gasTipCap
+	mTx.gasPrice = gasFeeCap
+	mTx.blobGasFeeCap = blobGasFeeCap
+	return nil
+}
@@ -0,0 +1,125 @@
+package ethtxmanager
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBlobHeader(excessBlobGas, blobGasUsed uint64) *types.Header {
+	return &types.Header{ExcessBlobGas: &excessBlobGas, BlobGasUsed: &blobGasUsed}
+}
+
+func TestBlobTx(t *testing.T) {
+	blob := kzg4844.Blob{}
+	commitment, err := kzg4844.BlobToCommitment(blob)
+	require.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(blob, commitment)
+	require.NoError(t, err)
+	sidecar := &types.BlobTxSidecar{Blobs: []kzg4844.Blob{blob}, Commitments: []kzg4844.Commitment{commitment}, Proofs: []kzg4844.Proof{proof}}
+
+	to := common.HexToAddress("0x2")
+	mTx := monitoredTx{
+		to:            &to,
+		nonce:         1,
+		value:         big.NewInt(2),
+		data:          []byte("data"),
+		gas:           3,
+		gasOffset:     4,
+		gasPrice:      big.NewInt(6),
+		gasFeeCap:     big.NewInt(6),
+		gasTipCap:     big.NewInt(5),
+		blobGasFeeCap: big.NewInt(8),
+		blobSidecar:   sidecar,
+		history:       map[common.Hash]bool{},
+	}
+
+	tx := mTx.Tx()
+	assert.Equal(t, uint8(types.BlobTxType), tx.Type())
+	assert.Equal(t, uint64(7), tx.Gas())
+	assert.Equal(t, mTx.gasFeeCap, tx.GasFeeCap())
+	assert.Equal(t, mTx.gasTipCap, tx.GasTipCap())
+	assert.Equal(t, mTx.blobGasFeeCap, tx.BlobGasFeeCap())
+	assert.Equal(t, sidecar.BlobHashes(), tx.BlobHashes())
+	assert.Equal(t, sidecar, tx.BlobTxSidecar())
+
+	require.NoError(t, mTx.AddHistory(tx))
+	assert.Equal(t, big.NewInt(8), mTx.historyFees[tx.Hash()].BlobGasFeeCap)
+
+	encoded, err := mTx.blobSidecarBytes()
+	require.NoError(t, err)
+	decoded := &types.BlobTxSidecar{}
+	require.NoError(t, rlp.DecodeBytes(encoded, decoded))
+	assert.Equal(t, sidecar, decoded)
+}
+
+func TestSuggestedBlobGasFeeCap(t *testing.T) {
+	ctx := context.Background()
+	etherman := newEthermanMock(t)
+	c := New(Config{DynamicFee: DynamicFeeConfig{BlobFeeMultiplier: 2}}, etherman, nil, nil)
+
+	etherman.On("GetLatestBlockHeader", ctx).Return(newBlobHeader(0, 0), nil).Once()
+	blobGasFeeCap, nextBlobFee, err := c.suggestedBlobGasFeeCap(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), blobGasFeeCap.Int64())
+	assert.Equal(t, int64(1), nextBlobFee.Int64())
+
+	c.cfg.DynamicFee.BlobFeeMultiplier = 0.5
+	etherman.On("GetLatestBlockHeader", ctx).Return(newBlobHeader(0, 0), nil).Once()
+	blobGasFeeCap, _, err = c.suggestedBlobGasFeeCap(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), blobGasFeeCap.Int64())
+
+	etherman.On("GetLatestBlockHeader", ctx).Return(&types.Header{}, nil).Once()
+	_, _, err = c.suggestedBlobGasFeeCap(ctx)
+	assert.ErrorIs(t, err, ErrBlobGasNotAvailable)
+
+	c = New(Config{CustodialAssets: CustodialAssetsConfig{Enable: true}}, etherman, nil, nil)
+	to := common.HexToAddress("0x2")
+	err = c.AddWithBlobs(ctx, "owner", "id", common.HexToAddress("0x1"), &to, nil, nil, 0, &types.BlobTxSidecar{}, nil)
+	assert.ErrorIs(t, err, ErrBlobTxNotSupported)
+}
+
+func TestReviewBlobFees(t *testing.T) {
+	ctx := context.Background()
+	cfg := DynamicFeeConfig{FeeHistoryBlocks: 1, PriorityFeeStrategy: PriorityFeeStrategyFixed, FixedPriorityFee: 10, MaxFeeStrategy: MaxFeeStrategyBaseFeeMultiplier, BaseFeeMultiplier: 1, BlobFeeMultiplier: 2}
+	logger := log.WithFields("test", "TestReviewBlobFees")
+
+	etherman := newEthermanMock(t)
+	c := New(Config{DynamicFee: cfg}, etherman, nil, nil)
+
+	// the fees are kept while they cover the suggested ones
+	mTx := monitoredTx{gasFeeCap: big.NewInt(200), gasTipCap: big.NewInt(10), gasPrice: big.NewInt(200), blobGasFeeCap: big.NewInt(2), blobSidecar: &types.BlobTxSidecar{}}
+	etherman.On("FeeHistory", ctx, uint64(1), (*big.Int)(nil), []float64(nil)).Return(newFeeHistory([]int64{100, 100}, nil), nil).Once()
+	etherman.On("GetLatestBlockHeader", ctx).Return(newBlobHeader(0, 0), nil).Once()
+	require.NoError(t, c.reviewBlobFees(ctx, &mTx, logger))
+	assert.Equal(t, int64(200), mTx.gasFeeCap.Int64())
+	assert.Equal(t, int64(10), mTx.gasTipCap.Int64())
+	assert.Equal(t, int64(2), mTx.blobGasFeeCap.Int64())
+
+	// the replacement bumps all the fees by 100%
+	etherman.On("FeeHistory", ctx, uint64(1), (*big.Int)(nil), []float64(nil)).Return(newFeeHistory([]int64{100, 200}, nil), nil).Once()
+	etherman.On("GetLatestBlockHeader", ctx).Return(newBlobHeader(0, 0), nil).Once()
+	require.NoError(t, c.reviewBlobFees(ctx, &mTx, logger))
+	assert.Equal(t, int64(400), mTx.gasFeeCap.Int64())
+	assert.Equal(t, int64(20), mTx.gasTipCap.Int64())
+	assert.Equal(t, int64(4), mTx.blobGasFeeCap.Int64())
+	assert.Equal(t, mTx.gasFeeCap, mTx.gasPrice)
+
+	// a replacement is not possible over the max fee per blob gas limit
+	c.cfg.DynamicFee.MaxBlobFeePerGasLimit = 5
+	etherman.On("FeeHistory", ctx, uint64(1), (*big.Int)(nil), []float64(nil)).Return(newFeeHistory([]int64{200, 400}, nil), nil).Once()
+	etherman.On("GetLatestBlockHeader", ctx).Return(newBlobHeader(0, 0), nil).Once()
+	require.NoError(t, c.reviewBlobFees(ctx, &mTx, logger))
+	assert.Equal(t, int64(400), mTx.gasFeeCap.Int64())
+	assert.Equal(t, int64(20), mTx.gasTipCap.Int64())
+	assert.Equal(t, int64(4), mTx.blobGasFeeCap.Int64())
+}
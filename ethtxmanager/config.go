@@ -1,6 +1,9 @@
 package ethtxmanager
 
-import "github.com/0xPolygonHermez/zkevm-node/config/types"
+import (
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/etherman/signer"
+)
 
 // Config is configuration for ethereum transaction manager
 type Config struct {
@@ -13,6 +16,11 @@ type Config struct {
 	// to be read in order to provide the private keys to sign the L1 txs
 	PrivateKeys []types.KeystoreFileConfig `mapstructure:"PrivateKeys"`
 
+	// Signers defines the signers of the L1 txs: key store files, remote signers compatible
+	// with web3signer or Clef and hardware security modules, optionally restricted by a
+	// signing policy. They take precedence over the PrivateKeys of the same address
+	Signers []signer.Config `mapstructure:"Signers"`
+
 	// ForcedGas is the amount of gas to be forced in case of gas estimation error
 	ForcedGas uint64 `mapstructure:"ForcedGas"`
 
@@ -51,4 +59,10 @@ type Config struct {
 
 	// CustodialAssets is the configuration for the custodial assets
 	CustodialAssets CustodialAssetsConfig `mapstructure:"CustodialAssets"`
+
+	// DynamicFee is the configuration of the EIP-1559 txs
+	DynamicFee DynamicFeeConfig `mapstructure:"DynamicFee"`
+
+	// NonceManager is the configuration of the recovery of dropped and stuck txs
+	NonceManager NonceManagerConfig `mapstructure:"NonceManager"`
 }
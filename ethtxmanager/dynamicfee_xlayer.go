@@ -0,0 +1,217 @@
+package ethtxmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+)
+
+const (
+	// replacementBumpPerMille is the minimum increase of both the max fee and the max priority fee
+	// required by the L1 txpool to replace a pending tx, 10%
+	replacementBumpPerMille = 100
+	// baseFeeMaxChangePerMille is the maximum increase of the base fee from a L1 block to the next one, 12.5%
+	baseFeeMaxChangePerMille = 125
+)
+
+var (
+	// ErrEmptyFeeHistory is returned when eth_feeHistory doesn't return the base fee of the next L1 block
+	ErrEmptyFeeHistory = errors.New("empty fee history")
+	// ErrUnknownFeeStrategy is returned when the priority fee or the max fee strategy is not supported
+	ErrUnknownFeeStrategy = errors.New("unknown fee strategy")
+)
+
+// txFees are the fees of a tx sent to the network
+type txFees struct {
+	GasPrice      *big.Int `json:"gasPrice,omitempty"`
+	GasFeeCap     *big.Int `json:"gasFeeCap,omitempty"`
+	GasTipCap     *big.Int `json:"gasTipCap,omitempty"`
+	BlobGasFeeCap *big.Int `json:"blobGasFeeCap,omitempty"`
+}
+
+// dynamicFees are the fees suggested for a dynamic fee tx
+type dynamicFees struct {
+	gasFeeCap   *big.Int
+	gasTipCap   *big.Int
+	nextBaseFee *big.Int
+}
+
+// isDynamicFee checks if the monitored tx is built as a dynamic fee tx
+func (mTx *monitoredTx) isDynamicFee() bool {
+	return mTx.gasFeeCap != nil && mTx.gasTipCap != nil
+}
+
+// dynamicFeeEnabled checks if the new monitored txs are built as dynamic fee txs
+func (c *Client) dynamicFeeEnabled() bool {
+	return c.cfg.DynamicFee.Enable && !c.cfg.CustodialAssets.Enable
+}
+
+// suggestedDynamicFees returns the max fee and the max priority fee per gas for a new tx according
+// to the configured strategies and the fee history of the last L1 blocks
+func (c *Client) suggestedDynamicFees(ctx context.Context) (dynamicFees, error) {
+	cfg := c.cfg.DynamicFee
+
+	var rewardPercentiles []float64
+	if cfg.PriorityFeeStrategy == PriorityFeeStrategyPercentile {
+		rewardPercentiles = []float64{cfg.RewardPercentile}
+	}
+	feeHistory, err := c.etherman.FeeHistory(ctx, cfg.FeeHistoryBlocks, nil, rewardPercentiles)
+	if err != nil {
+		return dynamicFees{}, err
+	}
+	// the fee history contains the base fee of the block after the newest one
+	if len(feeHistory.BaseFee) == 0 {
+		return dynamicFees{}, ErrEmptyFeeHistory
+	}
+	nextBaseFee := feeHistory.BaseFee[len(feeHistory.BaseFee)-1]
+
+	var gasTipCap *big.Int
+	switch cfg.PriorityFeeStrategy {
+	case PriorityFeeStrategyPercentile:
+		sum, count := big.NewInt(0), int64(0)
+		for _, reward := range feeHistory.Reward {
+			if len(reward) > 0 && reward[0] != nil {
+				sum.Add(sum, reward[0])
+				count++
+			}
+		}
+		gasTipCap = big.NewInt(0)
+		if count > 0 {
+			gasTipCap.Div(sum, big.NewInt(count))
+		}
+	case PriorityFeeStrategyFixed:
+		gasTipCap = new(big.Int).SetUint64(cfg.FixedPriorityFee)
+	default:
+		return dynamicFees{}, fmt.Errorf("%w: priority fee strategy %s", ErrUnknownFeeStrategy, cfg.PriorityFeeStrategy)
+	}
+
+	if minPriorityFee := new(big.Int).SetUint64(cfg.MinPriorityFee); gasTipCap.Cmp(minPriorityFee) == -1 {
+		gasTipCap = minPriorityFee
+	}
+	if cfg.MaxPriorityFee > 0 {
+		if maxPriorityFee := new(big.Int).SetUint64(cfg.MaxPriorityFee); gasTipCap.Cmp(maxPriorityFee) == 1 {
+			gasTipCap = maxPriorityFee
+		}
+	}
+
+	var baseFee *big.Int
+	switch cfg.MaxFeeStrategy {
+	case MaxFeeStrategyBaseFeeMultiplier:
+		fBaseFee := new(big.Float).SetInt(nextBaseFee)
+		baseFee, _ = fBaseFee.Mul(fBaseFee, big.NewFloat(cfg.BaseFeeMultiplier)).Int(nil)
+	case MaxFeeStrategyHistoryMax:
+		baseFee = big.NewInt(0)
+		for _, blockBaseFee := range feeHistory.BaseFee {
+			if blockBaseFee.Cmp(baseFee) == 1 {
+				baseFee = blockBaseFee
+			}
+		}
+	default:
+		return dynamicFees{}, fmt.Errorf("%w: max fee strategy %s", ErrUnknownFeeStrategy, cfg.MaxFeeStrategy)
+	}
+	gasFeeCap := new(big.Int).Add(baseFee, gasTipCap)
+
+	gasFeeCap, gasTipCap = c.limitDynamicFees(gasFeeCap, gasTipCap)
+	return dynamicFees{gasFeeCap: gasFeeCap, gasTipCap: gasTipCap, nextBaseFee: nextBaseFee}, nil
+}
+
+// limitDynamicFees caps the max fee per gas to the MaxFeePerGasLimit, the max priority
+// fee can't be greater than the max fee
+func (c *Client) limitDynamicFees(gasFeeCap, gasTipCap *big.Int) (*big.Int, *big.Int) {
+	if c.cfg.DynamicFee.MaxFeePerGasLimit > 0 {
+		if limit := new(big.Int).SetUint64(c.cfg.DynamicFee.MaxFeePerGasLimit); gasFeeCap.Cmp(limit) == 1 {
+			gasFeeCap = limit
+		}
+	}
+	if gasTipCap.Cmp(gasFeeCap) == 1 {
+		gasTipCap = new(big.Int).Set(gasFeeCap)
+	}
+	return gasFeeCap, gasTipCap
+}
+
+// reviewDynamicFees updates the max fee and the max priority fee of a dynamic fee monitored tx.
+//
+// The fees are kept while they are not lower than the suggested ones and the max fee still covers
+// the base fee of the next L1 block. Otherwise the tx is replaced: both fees are bumped at least by
+// 10%, so the L1 txpool accepts the replacement, and the max fee covers the base fee of the next
+// L1 block increased by 12.5%, the maximum increase of a block, so the replacement is not
+// underpriced again in the next block. The bump is applied over the highest fees sent for the
+// monitored tx, so it replaces every previous attempt still in the L1 txpool
+func (c *Client) reviewDynamicFees(ctx context.Context, mTx *monitoredTx, mTxLogger *log.Logger) error {
+	suggested, err := c.suggestedDynamicFees(ctx)
+	if err != nil {
+		return err
+	}
+
+	if mTx.gasFeeCap.Cmp(suggested.gasFeeCap) >= 0 && mTx.gasTipCap.Cmp(suggested.gasTipCap) >= 0 &&
+		mTx.gasFeeCap.Cmp(suggested.nextBaseFee) >= 0 {
+		return nil
+	}
+
+	sent := mTx.highestSentFees()
+	gasTipCap := maxBigInt(suggested.gasTipCap, bumpPerMille(sent.GasTipCap, replacementBumpPerMille))
+	minGasFeeCap := new(big.Int).Add(bumpPerMille(suggested.nextBaseFee, baseFeeMaxChangePerMille), gasTipCap)
+	gasFeeCap := maxBigInt(suggested.gasFeeCap, bumpPerMille(sent.GasFeeCap, replacementBumpPerMille), minGasFeeCap)
+	gasFeeCap, gasTipCap = c.limitDynamicFees(gasFeeCap, gasTipCap)
+
+	// a replacement below the 10% bump is rejected by the L1 txpool, the tx is kept until it's mined
+	if gasFeeCap.Cmp(bumpPerMille(sent.GasFeeCap, replacementBumpPerMille)) == -1 ||
+		gasTipCap.Cmp(bumpPerMille(sent.GasTipCap, replacementBumpPerMille)) == -1 {
+		mTxLogger.Warnf("monitored tx fees can't be bumped, max fee %v and max priority fee %v reached the MaxFeePerGasLimit", sent.GasFeeCap.String(), sent.GasTipCap.String())
+		return nil
+	}
+
+	mTxLogger.Infof("monitored tx max fee updated from %v to %v, max priority fee updated from %v to %v",
+		mTx.gasFeeCap.String(), gasFeeCap.String(), mTx.gasTipCap.String(), gasTipCap.String())
+	mTx.gasFeeCap = gasFeeCap
+	mTx.gasTipCap = gasTipCap
+	mTx.gasPrice = gasFeeCap
+	return nil
+}
+
+// highestSentFees returns the highest fees among the current fees of the monitored tx and the fees recorded
+// for each tx sent to the network. The recorded fees are persisted, so the replacements are bumped over all
+// the previous attempts even after a restart or when the current fees were lowered
+func (mTx *monitoredTx) highestSentFees() txFees {
+	highest := txFees{GasPrice: mTx.gasPrice, GasFeeCap: mTx.gasFeeCap, GasTipCap: mTx.gasTipCap, BlobGasFeeCap: mTx.blobGasFeeCap}
+	for _, fees := range mTx.historyFees {
+		highest.GasPrice = maxFee(highest.GasPrice, fees.GasPrice)
+		highest.GasFeeCap = maxFee(highest.GasFeeCap, fees.GasFeeCap)
+		highest.GasTipCap = maxFee(highest.GasTipCap, fees.GasTipCap)
+		highest.BlobGasFeeCap = maxFee(highest.BlobGasFeeCap, fees.BlobGasFeeCap)
+	}
+	return highest
+}
+
+// maxFee returns the greatest of two fees, a nil fee is ignored
+func maxFee(fee, other *big.Int) *big.Int {
+	if fee == nil || (other != nil && other.Cmp(fee) == 1) {
+		return other
+	}
+	return fee
+}
+
+// bumpPerMille increases the value by the provided per mille rounding up, the result is
+// always greater than the value
+func bumpPerMille(value *big.Int, perMille int64) *big.Int {
+	bump := new(big.Int).Mul(value, big.NewInt(perMille))
+	bump.Add(bump, big.NewInt(999)).Div(bump, big.NewInt(1000))
+	if bump.Sign() == 0 {
+		bump.SetInt64(1)
+	}
+	return bump.Add(bump, value)
+}
+
+// maxBigInt returns the greatest of the provided values
+func maxBigInt(value *big.Int, values ...*big.Int) *big.Int {
+	max := value
+	for _, v := range values {
+		if v.Cmp(max) == 1 {
+			max = v
+		}
+	}
+	return max
+}
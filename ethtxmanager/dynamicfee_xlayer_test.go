@@ -0,0 +1,208 @@
+package ethtxmanager
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/test/dbutils"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newFeeHistory(baseFees []int64, rewards []int64) *ethereum.FeeHistory {
+	feeHistory := &ethereum.FeeHistory{}
+	for _, baseFee := range baseFees {
+		feeHistory.BaseFee = append(feeHistory.BaseFee, big.NewInt(baseFee))
+	}
+	for _, reward := range rewards {
+		feeHistory.Reward = append(feeHistory.Reward, []*big.Int{big.NewInt(reward)})
+	}
+	return feeHistory
+}
+
+func TestSuggestedDynamicFees(t *testing.T) {
+	ctx := context.Background()
+	feeHistory := newFeeHistory([]int64{100, 150, 120, 110}, []int64{10, 20, 30})
+
+	testCases := []struct {
+		name              string
+		cfg               DynamicFeeConfig
+		expectedGasFeeCap int64
+		expectedGasTipCap int64
+	}{
+		{
+			name:              "percentile and base fee multiplier",
+			cfg:               DynamicFeeConfig{FeeHistoryBlocks: 3, PriorityFeeStrategy: PriorityFeeStrategyPercentile, RewardPercentile: 50, MaxFeeStrategy: MaxFeeStrategyBaseFeeMultiplier, BaseFeeMultiplier: 2},
+			expectedGasFeeCap: 240,
+			expectedGasTipCap: 20,
+		},
+		{
+			name:              "fixed and history max",
+			cfg:               DynamicFeeConfig{FeeHistoryBlocks: 3, PriorityFeeStrategy: PriorityFeeStrategyFixed, FixedPriorityFee: 5, MaxFeeStrategy: MaxFeeStrategyHistoryMax},
+			expectedGasFeeCap: 155,
+			expectedGasTipCap: 5,
+		},
+		{
+			name:              "min priority fee",
+			cfg:               DynamicFeeConfig{FeeHistoryBlocks: 3, PriorityFeeStrategy: PriorityFeeStrategyFixed, FixedPriorityFee: 5, MinPriorityFee: 15, MaxFeeStrategy: MaxFeeStrategyHistoryMax},
+			expectedGasFeeCap: 165,
+			expectedGasTipCap: 15,
+		},
+		{
+			name:              "max fee per gas limit",
+			cfg:               DynamicFeeConfig{FeeHistoryBlocks: 3, PriorityFeeStrategy: PriorityFeeStrategyPercentile, RewardPercentile: 50, MaxPriorityFee: 15, MaxFeeStrategy: MaxFeeStrategyBaseFeeMultiplier, BaseFeeMultiplier: 2, MaxFeePerGasLimit: 200},
+			expectedGasFeeCap: 200,
+			expectedGasTipCap: 15,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var rewardPercentiles []float64
+			if tc.cfg.PriorityFeeStrategy == PriorityFeeStrategyPercentile {
+				rewardPercentiles = []float64{tc.cfg.RewardPercentile}
+			}
+			etherman := newEthermanMock(t)
+			etherman.On("FeeHistory", ctx, tc.cfg.FeeHistoryBlocks, (*big.Int)(nil), rewardPercentiles).Return(feeHistory, nil).Once()
+
+			c := New(Config{DynamicFee: tc.cfg}, etherman, nil, nil)
+			fees, err := c.suggestedDynamicFees(ctx)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedGasFeeCap, fees.gasFeeCap.Int64())
+			assert.Equal(t, tc.expectedGasTipCap, fees.gasTipCap.Int64())
+			assert.Equal(t, int64(110), fees.nextBaseFee.Int64())
+		})
+	}
+
+	etherman := newEthermanMock(t)
+	c := New(Config{DynamicFee: DynamicFeeConfig{PriorityFeeStrategy: "unknown"}}, etherman, nil, nil)
+	etherman.On("FeeHistory", ctx, uint64(0), (*big.Int)(nil), []float64(nil)).Return(feeHistory, nil).Once()
+	_, err := c.suggestedDynamicFees(ctx)
+	assert.ErrorIs(t, err, ErrUnknownFeeStrategy)
+}
+
+func TestReviewDynamicFees(t *testing.T) {
+	ctx := context.Background()
+	cfg := DynamicFeeConfig{FeeHistoryBlocks: 1, PriorityFeeStrategy: PriorityFeeStrategyFixed, FixedPriorityFee: 10, MaxFeeStrategy: MaxFeeStrategyBaseFeeMultiplier, BaseFeeMultiplier: 1}
+	logger := log.WithFields("test", "TestReviewDynamicFees")
+
+	etherman := newEthermanMock(t)
+	c := New(Config{DynamicFee: cfg}, etherman, nil, nil)
+
+	// the fees are kept while they cover the suggested ones
+	mTx := monitoredTx{gasFeeCap: big.NewInt(200), gasTipCap: big.NewInt(10), gasPrice: big.NewInt(200)}
+	etherman.On("FeeHistory", ctx, uint64(1), (*big.Int)(nil), []float64(nil)).Return(newFeeHistory([]int64{100, 100}, nil), nil).Once()
+	require.NoError(t, c.reviewDynamicFees(ctx, &mTx, logger))
+	assert.Equal(t, int64(200), mTx.gasFeeCap.Int64())
+	assert.Equal(t, int64(10), mTx.gasTipCap.Int64())
+
+	// the replacement bumps both fees by 10% and covers a 12.5% base fee increase
+	etherman.On("FeeHistory", ctx, uint64(1), (*big.Int)(nil), []float64(nil)).Return(newFeeHistory([]int64{100, 200}, nil), nil).Once()
+	require.NoError(t, c.reviewDynamicFees(ctx, &mTx, logger))
+	assert.Equal(t, int64(236), mTx.gasFeeCap.Int64())
+	assert.Equal(t, int64(11), mTx.gasTipCap.Int64())
+	assert.Equal(t, mTx.gasFeeCap, mTx.gasPrice)
+
+	// a replacement is not possible over the max fee per gas limit
+	c.cfg.DynamicFee.MaxFeePerGasLimit = 250
+	etherman.On("FeeHistory", ctx, uint64(1), (*big.Int)(nil), []float64(nil)).Return(newFeeHistory([]int64{200, 300}, nil), nil).Once()
+	require.NoError(t, c.reviewDynamicFees(ctx, &mTx, logger))
+	assert.Equal(t, int64(236), mTx.gasFeeCap.Int64())
+	assert.Equal(t, int64(11), mTx.gasTipCap.Int64())
+
+	// the replacement is bumped over the highest fees sent, not over the current ones
+	c.cfg.DynamicFee.MaxFeePerGasLimit = 0
+	mTx = monitoredTx{
+		gasFeeCap: big.NewInt(200), gasTipCap: big.NewInt(10), gasPrice: big.NewInt(200),
+		historyFees: map[common.Hash]txFees{
+			common.HexToHash("0x1"): {GasPrice: big.NewInt(200), GasFeeCap: big.NewInt(200), GasTipCap: big.NewInt(10)},
+			common.HexToHash("0x2"): {GasPrice: big.NewInt(400), GasFeeCap: big.NewInt(400), GasTipCap: big.NewInt(20)},
+		},
+	}
+	etherman.On("FeeHistory", ctx, uint64(1), (*big.Int)(nil), []float64(nil)).Return(newFeeHistory([]int64{100, 200}, nil), nil).Once()
+	require.NoError(t, c.reviewDynamicFees(ctx, &mTx, logger))
+	assert.Equal(t, int64(440), mTx.gasFeeCap.Int64())
+	assert.Equal(t, int64(22), mTx.gasTipCap.Int64())
+}
+
+func TestBumpPerMille(t *testing.T) {
+	assert.Equal(t, int64(110), bumpPerMille(big.NewInt(100), replacementBumpPerMille).Int64())
+	assert.Equal(t, int64(113), bumpPerMille(big.NewInt(100), baseFeeMaxChangePerMille).Int64())
+	assert.Equal(t, int64(2), bumpPerMille(big.NewInt(1), replacementBumpPerMille).Int64())
+	assert.Equal(t, int64(1), bumpPerMille(big.NewInt(0), replacementBumpPerMille).Int64())
+}
+
+func TestDynamicFeeTx(t *testing.T) {
+	to := common.HexToAddress("0x2")
+	mTx := monitoredTx{
+		to:        &to,
+		nonce:     1,
+		value:     big.NewInt(2),
+		data:      []byte("data"),
+		gas:       3,
+		gasOffset: 4,
+		gasPrice:  big.NewInt(6),
+		gasFeeCap: big.NewInt(6),
+		gasTipCap: big.NewInt(5),
+		history:   map[common.Hash]bool{},
+	}
+
+	tx := mTx.Tx()
+	assert.Equal(t, uint8(types.DynamicFeeTxType), tx.Type())
+	assert.Equal(t, uint64(7), tx.Gas())
+	assert.Equal(t, mTx.gasFeeCap, tx.GasFeeCap())
+	assert.Equal(t, mTx.gasTipCap, tx.GasTipCap())
+
+	require.NoError(t, mTx.AddHistory(tx))
+	assert.Equal(t, txFees{GasPrice: big.NewInt(6), GasFeeCap: big.NewInt(6), GasTipCap: big.NewInt(5)}, mTx.historyFees[tx.Hash()])
+}
+
+func TestAddGetAndUpdateDynamicFees(t *testing.T) {
+	dbCfg := dbutils.NewStateConfigFromEnv()
+	require.NoError(t, dbutils.InitOrResetState(dbCfg))
+
+	storage, err := NewPostgresStorage(dbCfg)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	to := common.HexToAddress("0x2")
+	mTx := monitoredTx{
+		owner: "owner", id: "id", from: common.HexToAddress("0x1"), to: &to,
+		gas: 3, gasPrice: big.NewInt(100), gasFeeCap: big.NewInt(100), gasTipCap: big.NewInt(10),
+		status: MonitoredTxStatusCreated, history: map[common.Hash]bool{},
+	}
+	require.NoError(t, storage.Add(ctx, mTx, nil))
+
+	returnedMtx, err := storage.Get(ctx, "owner", "id", nil)
+	require.NoError(t, err)
+	assert.Equal(t, mTx.gasFeeCap, returnedMtx.gasFeeCap)
+	assert.Equal(t, mTx.gasTipCap, returnedMtx.gasTipCap)
+	assert.Empty(t, returnedMtx.historyFees)
+
+	tx := returnedMtx.Tx()
+	require.NoError(t, returnedMtx.AddHistory(tx))
+	returnedMtx.gasFeeCap, returnedMtx.gasTipCap = big.NewInt(120), big.NewInt(11)
+	require.NoError(t, storage.Update(ctx, returnedMtx, nil))
+
+	returnedMtx, err = storage.Get(ctx, "owner", "id", nil)
+	require.NoError(t, err)
+	assert.Equal(t, big.NewInt(120), returnedMtx.gasFeeCap)
+	assert.Equal(t, big.NewInt(11), returnedMtx.gasTipCap)
+	require.Len(t, returnedMtx.historyFees, 1)
+	assert.Equal(t, 0, returnedMtx.historyFees[tx.Hash()].GasFeeCap.Cmp(big.NewInt(100)))
+	assert.Equal(t, 0, returnedMtx.historyFees[tx.Hash()].GasTipCap.Cmp(big.NewInt(10)))
+
+	// the fees that don't fit in a uint64 are kept
+	gasFeeCap, ok := new(big.Int).SetString("100000000000000000000", 10)
+	require.True(t, ok)
+	returnedMtx.gasFeeCap = gasFeeCap
+	require.NoError(t, storage.Update(ctx, returnedMtx, nil))
+	returnedMtx, err = storage.Get(ctx, "owner", "id", nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, gasFeeCap.Cmp(returnedMtx.gasFeeCap))
+}
@@ -0,0 +1,59 @@
+package ethtxmanager
+
+const (
+	// PriorityFeeStrategyPercentile uses the average of the RewardPercentile of the priority fees
+	// paid in the last FeeHistoryBlocks L1 blocks
+	PriorityFeeStrategyPercentile = "percentile"
+	// PriorityFeeStrategyFixed always uses the FixedPriorityFee
+	PriorityFeeStrategyFixed = "fixed"
+
+	// MaxFeeStrategyBaseFeeMultiplier sets the max fee to the base fee of the next L1 block
+	// multiplied by the BaseFeeMultiplier plus the priority fee
+	MaxFeeStrategyBaseFeeMultiplier = "baseFeeMultiplier"
+	// MaxFeeStrategyHistoryMax sets the max fee to the highest base fee of the last FeeHistoryBlocks
+	// L1 blocks and the next one plus the priority fee
+	MaxFeeStrategyHistoryMax = "historyMax"
+)
+
+// DynamicFeeConfig is the config of the EIP-1559 txs sent to L1
+type DynamicFeeConfig struct {
+	// Enable sends type-2 txs instead of legacy txs. It has no effect when the custodial assets
+	// are enabled, since the custodial assets service only signs legacy txs
+	Enable bool `mapstructure:"Enable"`
+
+	// FeeHistoryBlocks is the number of L1 blocks requested with eth_feeHistory
+	FeeHistoryBlocks uint64 `mapstructure:"FeeHistoryBlocks"`
+
+	// PriorityFeeStrategy is the strategy used to set the max priority fee per gas, "percentile" or "fixed"
+	PriorityFeeStrategy string `mapstructure:"PriorityFeeStrategy"`
+
+	// RewardPercentile is the percentile of the priority fees paid in each L1 block used by the percentile strategy
+	RewardPercentile float64 `mapstructure:"RewardPercentile"`
+
+	// FixedPriorityFee is the max priority fee per gas in wei used by the fixed strategy
+	FixedPriorityFee uint64 `mapstructure:"FixedPriorityFee"`
+
+	// MinPriorityFee is the lower bound in wei of the max priority fee per gas
+	MinPriorityFee uint64 `mapstructure:"MinPriorityFee"`
+
+	// MaxPriorityFee is the upper bound in wei of the max priority fee per gas, 0 means no limit
+	MaxPriorityFee uint64 `mapstructure:"MaxPriorityFee"`
+
+	// MaxFeeStrategy is the strategy used to set the max fee per gas, "baseFeeMultiplier" or "historyMax"
+	MaxFeeStrategy string `mapstructure:"MaxFeeStrategy"`
+
+	// BaseFeeMultiplier multiplies the base fee of the next L1 block in the baseFeeMultiplier strategy
+	BaseFeeMultiplier float64 `mapstructure:"BaseFeeMultiplier"`
+
+	// MaxFeePerGasLimit is the upper bound in wei of the max fee per gas, including the replacements
+	// of a tx, 0 means no limit
+	MaxFeePerGasLimit uint64 `mapstructure:"MaxFeePerGasLimit"`
+
+	// BlobFeeMultiplier multiplies the blob base fee of the next L1 block to set the max fee per blob gas
+	// of the blob txs
+	BlobFeeMultiplier float64 `mapstructure:"BlobFeeMultiplier"`
+
+	// MaxBlobFeePerGasLimit is the upper bound in wei of the max fee per blob gas, including the
+	// replacements of a blob tx, 0 means no limit
+	MaxBlobFeePerGasLimit uint64 `mapstructure:"MaxBlobFeePerGasLimit"`
+}
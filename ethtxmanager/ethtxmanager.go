@@ -81,22 +81,34 @@ func (c *Client) Add(ctx context.Context, owner, id string, from common.Address,
 		}
 	}
 
-	// get gas price
-	gasPrice, err := c.suggestedGasPrice(ctx)
-	if err != nil {
-		err := fmt.Errorf("failed to get suggested gas price: %w", err)
-		log.Errorf(err.Error())
-		return err
-	}
-
 	// create monitored tx
 	mTx := monitoredTx{
 		owner: owner, id: id, from: from, to: to,
 		nonce: nonce, value: value, data: data,
-		gas: gas, gasOffset: gasOffset, gasPrice: gasPrice,
+		gas: gas, gasOffset: gasOffset,
 		status: MonitoredTxStatusCreated,
 	}
 
+	// XLayer dynamic fee tx
+	if c.dynamicFeeEnabled() {
+		fees, err := c.suggestedDynamicFees(ctx)
+		if err != nil {
+			err := fmt.Errorf("failed to get suggested dynamic fees: %w", err)
+			log.Errorf(err.Error())
+			return err
+		}
+		mTx.gasFeeCap, mTx.gasTipCap, mTx.gasPrice = fees.gasFeeCap, fees.gasTipCap, fees.gasFeeCap
+	} else {
+		// get gas price
+		gasPrice, err := c.suggestedGasPrice(ctx)
+		if err != nil {
+			err := fmt.Errorf("failed to get suggested gas price: %w", err)
+			log.Errorf(err.Error())
+			return err
+		}
+		mTx.gasPrice = gasPrice
+	}
+
 	// add to storage
 	err = c.storage.Add(ctx, mTx, dbTx)
 	if err != nil {
@@ -186,10 +198,11 @@ func (c *Client) buildResult(ctx context.Context, mTx monitoredTx) (MonitoredTxR
 	}
 
 	result := MonitoredTxResult{
-		ID:          mTx.id,
-		Status:      mTx.status,
-		BlockNumber: mTx.blockNumber,
-		Txs:         txs,
+		ID:              mTx.id,
+		Status:          mTx.status,
+		BlockNumber:     mTx.blockNumber,
+		Txs:             txs,
+		NonceRecoveries: mTx.nonceRecoveries,
 	}
 
 	return result, nil
@@ -257,6 +270,11 @@ func (c *Client) monitorTxs(ctx context.Context) error {
 
 	log.Infof("found %v monitored tx to process", len(mTxs))
 
+	// XLayer recover the nonces of the senders before monitoring their txs
+	if c.nonceManagerEnabled() {
+		c.manageNonces(ctx, mTxs)
+	}
+
 	wg := sync.WaitGroup{}
 	wg.Add(len(mTxs))
 	for _, mTx := range mTxs {
@@ -385,14 +403,11 @@ func (c *Client) monitorTx(ctx context.Context, mTx monitoredTx, logger *log.Log
 		logger.Debugf("unsigned tx %v created", tx.Hash().String())
 
 		// sign tx
-		if c.cfg.CustodialAssets.Enable {
-			signedTx, err = c.signTx(mTx, tx)
-			if err != nil {
-				metrics.HaltCount()
-				logger.Fatalf("failed to sign tx %v: %v", tx.Hash().String(), err)
-			}
-		} else {
-			signedTx, err = c.etherman.SignTx(ctx, mTx.from, tx)
+		// XLayer sign with the custodial assets service or the signer of the sender loaded in the etherman
+		signedTx, err = c.signerFor(mTx).SignTx(ctx, tx)
+		if err != nil && c.cfg.CustodialAssets.Enable {
+			metrics.HaltCount()
+			logger.Fatalf("failed to sign tx %v: %v", tx.Hash().String(), err)
 		}
 		if err != nil {
 			logger.Errorf("failed to sign tx %v: %v", tx.Hash().String(), err)
@@ -536,6 +551,28 @@ func (c *Client) reviewMonitoredTx(ctx context.Context, mTx *monitoredTx, mTxLog
 		mTx.gas = gas
 	}
 
+	// XLayer blob tx
+	if mTx.isBlob() {
+		err := c.reviewBlobFees(ctx, mTx, mTxLogger)
+		if err != nil {
+			err := fmt.Errorf("failed to review blob fees: %w", err)
+			mTxLogger.Errorf(err.Error())
+			return err
+		}
+		return nil
+	}
+
+	// XLayer dynamic fee tx
+	if mTx.isDynamicFee() {
+		err := c.reviewDynamicFees(ctx, mTx, mTxLogger)
+		if err != nil {
+			err := fmt.Errorf("failed to review dynamic fees: %w", err)
+			mTxLogger.Errorf(err.Error())
+			return err
+		}
+		return nil
+	}
+
 	// get gas price
 	gasPrice, err := c.suggestedGasPrice(ctx)
 	if err != nil {
@@ -10,6 +10,10 @@ const (
 	Prefix = "ethtxmanager_"
 	// HaltCountName is the name of the metric that counts the halt count.
 	HaltCountName = Prefix + "halt_count"
+	// NonceRecoveryCountName is the name of the metric that counts the nonce recoveries by action.
+	NonceRecoveryCountName = Prefix + "nonce_recovery_count"
+	// NonceRecoveryActionLabelName is the name of the label with the action of the nonce recovery.
+	NonceRecoveryActionLabelName = "action"
 )
 
 // Register the metrics for the sequencer package.
@@ -21,10 +25,26 @@ func Register() {
 		},
 	}
 
+	var counterVecs = []metrics.CounterVecOpts{
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: NonceRecoveryCountName,
+				Help: "[ETHTXMANAGER] total count of nonce recoveries",
+			},
+			Labels: []string{NonceRecoveryActionLabelName},
+		},
+	}
+
 	metrics.RegisterCounters(counters...)
+	metrics.RegisterCounterVecs(counterVecs...)
 }
 
 // HaltCount increases the counter for the eth-tx-manager halt count.
 func HaltCount() {
 	metrics.CounterAdd(HaltCountName, 1)
 }
+
+// NonceRecoveryCount increases the counter for the nonce recoveries of the action.
+func NonceRecoveryCount(action string) {
+	metrics.CounterVecInc(NonceRecoveryCountName, action)
+}
@@ -2,11 +2,14 @@ package ethtxmanager
 
 import (
 	"encoding/hex"
+	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/holiman/uint256"
 )
 
 const (
@@ -76,9 +79,21 @@ type monitoredTx struct {
 	// tx gas offset
 	gasOffset uint64
 
-	// tx gas price
+	// tx gas price, it's the same as gasFeeCap for dynamic fee txs
 	gasPrice *big.Int
 
+	// tx max fee per gas, nil for legacy txs
+	gasFeeCap *big.Int
+
+	// tx max priority fee per gas, nil for legacy txs
+	gasTipCap *big.Int
+
+	// tx max fee per blob gas, nil for non blob txs
+	blobGasFeeCap *big.Int
+
+	// blobSidecar contains the blobs carried by the tx, nil for non blob txs
+	blobSidecar *types.BlobTxSidecar
+
 	// status of this monitoring
 	status MonitoredTxStatus
 
@@ -92,6 +107,14 @@ type monitoredTx struct {
 	// sent to the network
 	history map[common.Hash]bool
 
+	// historyFees represent the fees of each transaction
+	// in the history when it was sent to the network
+	historyFees map[common.Hash]txFees
+
+	// nonceRecoveries are the decisions taken by the nonce manager
+	// to recover the nonce of this monitored tx
+	nonceRecoveries []NonceRecovery
+
 	// createdAt date time it was created
 	createdAt time.Time
 
@@ -101,6 +124,35 @@ type monitoredTx struct {
 
 // Tx uses the current information to build a tx
 func (mTx monitoredTx) Tx() *types.Transaction {
+	if mTx.isBlob() {
+		// the chain ID is set by the signer
+		return types.NewTx(&types.BlobTx{
+			To:         *mTx.to,
+			Nonce:      mTx.nonce,
+			Value:      uint256.MustFromBig(mTx.value),
+			Data:       mTx.data,
+			Gas:        mTx.gas + mTx.gasOffset,
+			GasFeeCap:  uint256.MustFromBig(mTx.gasFeeCap),
+			GasTipCap:  uint256.MustFromBig(mTx.gasTipCap),
+			BlobFeeCap: uint256.MustFromBig(mTx.blobGasFeeCap),
+			BlobHashes: mTx.blobSidecar.BlobHashes(),
+			Sidecar:    mTx.blobSidecar,
+		})
+	}
+
+	if mTx.isDynamicFee() {
+		// the chain ID is set by the signer
+		return types.NewTx(&types.DynamicFeeTx{
+			To:        mTx.to,
+			Nonce:     mTx.nonce,
+			Value:     mTx.value,
+			Data:      mTx.data,
+			Gas:       mTx.gas + mTx.gasOffset,
+			GasFeeCap: mTx.gasFeeCap,
+			GasTipCap: mTx.gasTipCap,
+		})
+	}
+
 	tx := types.NewTx(&types.LegacyTx{
 		To:       mTx.to,
 		Nonce:    mTx.nonce,
@@ -114,11 +166,15 @@ func (mTx monitoredTx) Tx() *types.Transaction {
 }
 
 // AddHistory adds a transaction to the monitoring history
-func (mTx monitoredTx) AddHistory(tx *types.Transaction) error {
+func (mTx *monitoredTx) AddHistory(tx *types.Transaction) error {
 	if _, found := mTx.history[tx.Hash()]; found {
 		return ErrAlreadyExists
 	}
 	mTx.history[tx.Hash()] = true
+	if mTx.historyFees == nil {
+		mTx.historyFees = make(map[common.Hash]txFees)
+	}
+	mTx.historyFees[tx.Hash()] = txFees{GasPrice: tx.GasPrice(), GasFeeCap: tx.GasFeeCap(), GasTipCap: tx.GasTipCap(), BlobGasFeeCap: tx.BlobGasFeeCap()}
 	return nil
 }
 
@@ -170,6 +226,51 @@ func (mTx *monitoredTx) historyHashSlice() []common.Hash {
 	return history
 }
 
+// gasFeeCapStringPtr returns the current gasFeeCap field as a decimal string pointer
+func (mTx *monitoredTx) gasFeeCapStringPtr() *string {
+	return bigIntStringPtr(mTx.gasFeeCap)
+}
+
+// gasTipCapStringPtr returns the current gasTipCap field as a decimal string pointer
+func (mTx *monitoredTx) gasTipCapStringPtr() *string {
+	return bigIntStringPtr(mTx.gasTipCap)
+}
+
+// blobGasFeeCapStringPtr returns the current blobGasFeeCap field as a decimal string pointer
+func (mTx *monitoredTx) blobGasFeeCapStringPtr() *string {
+	return bigIntStringPtr(mTx.blobGasFeeCap)
+}
+
+// bigIntStringPtr returns the value as a decimal string pointer, the fees are stored as decimal
+// strings since they may not fit in a uint64
+func bigIntStringPtr(value *big.Int) *string {
+	if value == nil {
+		return nil
+	}
+	s := value.String()
+	return &s
+}
+
+// bigIntFromStringPtr parses a decimal string pointer, nil is returned for a nil string
+func bigIntFromStringPtr(s *string) (*big.Int, error) {
+	if s == nil {
+		return nil, nil
+	}
+	value, ok := new(big.Int).SetString(*s, 10) //nolint:gomnd
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal value %s", *s)
+	}
+	return value, nil
+}
+
+// blobSidecarBytes returns the current blobSidecar field RLP encoded
+func (mTx *monitoredTx) blobSidecarBytes() ([]byte, error) {
+	if mTx.blobSidecar == nil {
+		return nil, nil
+	}
+	return rlp.EncodeToBytes(mTx.blobSidecar)
+}
+
 // blockNumberU64Ptr returns the current blockNumber as a uint64 pointer
 func (mTx *monitoredTx) blockNumberU64Ptr() *uint64 {
 	var blockNumber *uint64
@@ -182,10 +283,11 @@ func (mTx *monitoredTx) blockNumberU64Ptr() *uint64 {
 
 // MonitoredTxResult represents the result of a execution of a monitored tx
 type MonitoredTxResult struct {
-	ID          string
-	Status      MonitoredTxStatus
-	BlockNumber *big.Int
-	Txs         map[common.Hash]TxResult
+	ID              string
+	Status          MonitoredTxStatus
+	BlockNumber     *big.Int
+	Txs             map[common.Hash]TxResult
+	NonceRecoveries []NonceRecovery
 }
 
 // TxResult represents the result of a execution of a ethereum transaction in the block chain
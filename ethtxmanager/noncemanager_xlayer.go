@@ -0,0 +1,471 @@
+package ethtxmanager
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/ethtxmanager/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// NonceRecoveryResend is recorded when the fees of a dropped or stuck tx are bumped to resend it
+	NonceRecoveryResend = "resend"
+	// NonceRecoverySelfTransfer is recorded when a zero-value self-transfer is sent to replace a
+	// stuck nonce or to fill a nonce gap blocking the monitored tx
+	NonceRecoverySelfTransfer = "selfTransfer"
+	// NonceRecoveryResequence is recorded when the monitored tx is moved to a new nonce
+	NonceRecoveryResequence = "resequence"
+)
+
+var (
+	// errFeesLimitReached is returned when the fees can't be bumped because of the configured limits
+	errFeesLimitReached = errors.New("fees can't be bumped over the configured limits")
+	// errSelfTransferNotSupported is returned when the self-transfers can't be signed
+	errSelfTransferNotSupported = errors.New("self-transfers can't be signed by the custodial assets service")
+)
+
+// NonceRecovery is a decision taken by the nonce manager to recover the nonce of a monitored tx,
+// the recoveries are stored with the monitored tx so they can be audited
+type NonceRecovery struct {
+	// Action is the action taken: resend, selfTransfer or resequence
+	Action string `json:"action"`
+	// Reason explains why the action was taken
+	Reason string `json:"reason"`
+	// Nonce is the recovered nonce
+	Nonce uint64 `json:"nonce"`
+	// NewNonce is the nonce assigned to the monitored tx when it's re-sequenced
+	NewNonce *uint64 `json:"newNonce,omitempty"`
+	// TxHash is the hash of the self-transfer
+	TxHash *common.Hash `json:"txHash,omitempty"`
+	// Fees are the fees of the resent tx or of the self-transfer
+	Fees *txFees `json:"fees,omitempty"`
+	// CreatedAt is when the action was taken
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// lastNonceRecovery returns the last recovery of the nonce recorded in the monitored tx,
+// including the re-sequences to the nonce
+func (mTx *monitoredTx) lastNonceRecovery(nonce uint64) *NonceRecovery {
+	for i := len(mTx.nonceRecoveries) - 1; i >= 0; i-- {
+		recovery := mTx.nonceRecoveries[i]
+		if recovery.Nonce == nonce || (recovery.NewNonce != nil && *recovery.NewNonce == nonce) {
+			return &recovery
+		}
+	}
+	return nil
+}
+
+// countNonceRecoveries returns the number of recoveries of the nonce with the action
+func (mTx *monitoredTx) countNonceRecoveries(action string, nonce uint64) uint64 {
+	var count uint64
+	for _, recovery := range mTx.nonceRecoveries {
+		if recovery.Action == action && recovery.Nonce == nonce {
+			count++
+		}
+	}
+	return count
+}
+
+// nonceManagerEnabled returns true if the nonces of the monitored txs are reviewed by the nonce manager
+func (c *Client) nonceManagerEnabled() bool {
+	return c.cfg.NonceManager.Enable
+}
+
+// manageNonces reviews the nonces of the monitored txs of each sender before they are monitored,
+// the monitored txs are updated in place and stored with the recoveries taken
+func (c *Client) manageNonces(ctx context.Context, mTxs []monitoredTx) {
+	senders := make(map[common.Address][]*monitoredTx)
+	var order []common.Address
+	for i := range mTxs {
+		// the reorged txs were mined, they are not sent again until they are reviewed by the caller
+		if mTxs[i].status == MonitoredTxStatusReorged {
+			continue
+		}
+		from := mTxs[i].from
+		if _, found := senders[from]; !found {
+			order = append(order, from)
+		}
+		senders[from] = append(senders[from], &mTxs[i])
+	}
+
+	for _, from := range order {
+		logger := log.WithFields("sender", from.String())
+		err := c.manageSenderNonces(ctx, from, senders[from], logger)
+		if err != nil {
+			logger.Errorf("failed to manage nonces: %v", err)
+		}
+	}
+}
+
+// manageSenderNonces recovers the nonces of the monitored txs of a sender:
+//   - the monitored txs whose nonce was consumed by another tx or is held by another monitored tx
+//     are re-sequenced to the next free nonces, followed by the dependent monitored txs created
+//     after them that are not in the network, so the monitored txs keep the order they were created in
+//   - the nonce gaps below the monitored txs that are not filled by the L1 txpool are filled with
+//     zero-value self-transfers
+//   - the monitored tx holding the next nonce to be mined is resent with higher fees when it's
+//     dropped or stuck, and its nonce is replaced with a zero-value self-transfer after MaxResends
+func (c *Client) manageSenderNonces(ctx context.Context, from common.Address, mTxs []*monitoredTx, logger *log.Logger) error {
+	sort.SliceStable(mTxs, func(i, j int) bool { return mTxs[i].createdAt.Before(mTxs[j].createdAt) })
+
+	currentNonce, err := c.etherman.CurrentNonce(ctx, from)
+	if err != nil {
+		return fmt.Errorf("failed to get current nonce: %w", err)
+	}
+	pendingNonce, err := c.etherman.PendingNonce(ctx, from)
+	if err != nil {
+		return fmt.Errorf("failed to get pending nonce: %w", err)
+	}
+
+	err = c.resequenceNonces(ctx, mTxs, currentNonce, pendingNonce, logger)
+	if err != nil {
+		return err
+	}
+	err = c.fillNonceGaps(ctx, mTxs, currentNonce, pendingNonce, logger)
+	if err != nil {
+		return err
+	}
+	for _, mTx := range mTxs {
+		if mTx.nonce == currentNonce && mTx.status == MonitoredTxStatusSent {
+			return c.recoverStuckNonce(ctx, mTx, logger)
+		}
+	}
+	return nil
+}
+
+// resequenceNonces moves to the next nonces not used by the sender the monitored txs whose nonce
+// was consumed by another tx or is held by a monitored tx created before, and the monitored txs
+// created after a re-sequenced one, so they keep the order they were created in.
+//
+// A monitored tx sent with a nonce still available can't be moved while it's in the network,
+// otherwise both txs could be mined sending duplicated data to L1
+func (c *Client) resequenceNonces(ctx context.Context, mTxs []*monitoredTx, currentNonce, pendingNonce uint64, logger *log.Logger) error {
+	nextNonce := currentNonce
+	if pendingNonce > nextNonce {
+		nextNonce = pendingNonce
+	}
+	for _, mTx := range mTxs {
+		if mTx.nonce >= nextNonce {
+			nextNonce = mTx.nonce + 1
+		}
+	}
+
+	holders := make(map[uint64]*monitoredTx)
+	resequenced := false
+	for _, mTx := range mTxs {
+		var reason string
+		holder, held := holders[mTx.nonce]
+		switch {
+		case mTx.nonce < currentNonce:
+			mined, err := c.historyWasMined(ctx, *mTx)
+			if err != nil {
+				// the later monitored txs are not re-sequenced before this one to keep the order
+				return err
+			}
+			if mined {
+				continue
+			}
+			reason = fmt.Sprintf("nonce consumed by another tx, current nonce %d", currentNonce)
+		case held || resequenced:
+			found, err := c.historyFoundInNetwork(ctx, *mTx)
+			if err != nil {
+				return err
+			}
+			if found {
+				logger.Warnf("monitored tx %v can't be re-sequenced, nonce %d is in the network", mTx.id, mTx.nonce)
+				if !held {
+					holders[mTx.nonce] = mTx
+				}
+				continue
+			}
+			reason = "created after a re-sequenced monitored tx"
+			if held {
+				reason = fmt.Sprintf("nonce held by monitored tx %v", holder.id)
+			}
+		default:
+			holders[mTx.nonce] = mTx
+			continue
+		}
+
+		newNonce := nextNonce
+		nextNonce++
+		c.addNonceRecovery(mTx, NonceRecovery{Action: NonceRecoveryResequence, Reason: reason, Nonce: mTx.nonce, NewNonce: &newNonce}, logger)
+		mTx.nonce = newNonce
+		holders[newNonce] = mTx
+		resequenced = true
+		err := c.storage.Update(ctx, *mTx, nil)
+		if err != nil {
+			return fmt.Errorf("failed to update re-sequenced monitored tx %v: %w", mTx.id, err)
+		}
+	}
+	return nil
+}
+
+// fillNonceGaps sends a zero-value self-transfer for each nonce below the monitored txs that is
+// neither held by a monitored tx nor by a tx in the L1 txpool, since the later txs of the sender
+// can't be mined until the gap is filled. A gap is filled again if it's still open after the
+// StuckTxTimeout
+func (c *Client) fillNonceGaps(ctx context.Context, mTxs []*monitoredTx, currentNonce, pendingNonce uint64, logger *log.Logger) error {
+	// the nonces below the pending nonce are held by the txs in the L1 txpool
+	fromNonce := currentNonce
+	if pendingNonce > fromNonce {
+		fromNonce = pendingNonce
+	}
+	held := make(map[uint64]*monitoredTx)
+	nonces := make([]uint64, 0, len(mTxs))
+	for _, mTx := range mTxs {
+		if _, found := held[mTx.nonce]; mTx.nonce < fromNonce || found {
+			continue
+		}
+		held[mTx.nonce] = mTx
+		nonces = append(nonces, mTx.nonce)
+	}
+	sort.Slice(nonces, func(i, j int) bool { return nonces[i] < nonces[j] })
+
+	for _, nonce := range nonces {
+		blocked := held[nonce]
+		for gap := fromNonce; gap < nonce; gap++ {
+			if recovery := blocked.lastNonceRecovery(gap); recovery != nil && time.Since(recovery.CreatedAt) < c.cfg.NonceManager.StuckTxTimeout.Duration {
+				continue
+			}
+			tx, err := c.sendSelfTransfer(ctx, *blocked, gap, nil)
+			if err != nil {
+				return fmt.Errorf("failed to fill nonce gap %d: %w", gap, err)
+			}
+			txHash := tx.Hash()
+			c.addNonceRecovery(blocked, NonceRecovery{Action: NonceRecoverySelfTransfer, Reason: fmt.Sprintf("nonce gap below nonce %d", nonce),
+				Nonce: gap, TxHash: &txHash, Fees: newTxFees(tx)}, logger)
+			err = c.storage.Update(ctx, *blocked, nil)
+			if err != nil {
+				return fmt.Errorf("failed to update monitored tx %v: %w", blocked.id, err)
+			}
+		}
+		fromNonce = nonce + 1
+	}
+	return nil
+}
+
+// recoverStuckNonce recovers the monitored tx holding the next nonce to be mined when it's not mined
+// after the StuckTxTimeout since it was created or recovered. The fees are bumped to resend it up to
+// MaxResends times, then the nonce is replaced with a zero-value self-transfer and the monitored tx
+// is re-sequenced once the self-transfer is mined
+func (c *Client) recoverStuckNonce(ctx context.Context, mTx *monitoredTx, logger *log.Logger) error {
+	lastAttempt := mTx.createdAt
+	if recovery := mTx.lastNonceRecovery(mTx.nonce); recovery != nil {
+		lastAttempt = recovery.CreatedAt
+	}
+	if time.Since(lastAttempt) < c.cfg.NonceManager.StuckTxTimeout.Duration {
+		return nil
+	}
+
+	found, err := c.historyFoundInNetwork(ctx, *mTx)
+	if err != nil {
+		return err
+	}
+	reason := fmt.Sprintf("not mined after %v", c.cfg.NonceManager.StuckTxTimeout.Duration)
+	if !found {
+		reason = fmt.Sprintf("dropped from the network, not mined after %v", c.cfg.NonceManager.StuckTxTimeout.Duration)
+	}
+
+	if mTx.countNonceRecoveries(NonceRecoveryResend, mTx.nonce) < c.cfg.NonceManager.MaxResends {
+		fees, err := c.bumpFees(mTx)
+		if err == nil {
+			c.addNonceRecovery(mTx, NonceRecovery{Action: NonceRecoveryResend, Reason: reason, Nonce: mTx.nonce, Fees: fees}, logger)
+			err = c.storage.Update(ctx, *mTx, nil)
+			if err != nil {
+				return fmt.Errorf("failed to update monitored tx %v: %w", mTx.id, err)
+			}
+			return nil
+		}
+		if !errors.Is(err, errFeesLimitReached) {
+			return err
+		}
+		logger.Warnf("monitored tx %v can't be resent: %v", mTx.id, err)
+	}
+
+	// the L1 blob pool doesn't accept a regular tx replacing a blob tx
+	if mTx.isBlob() {
+		logger.Warnf("nonce %d of blob monitored tx %v can't be replaced with a self-transfer", mTx.nonce, mTx.id)
+		return nil
+	}
+
+	minFees := newTxFees(mTx.Tx())
+	if recovery := mTx.lastNonceRecovery(mTx.nonce); recovery != nil && recovery.Action == NonceRecoverySelfTransfer {
+		minFees = recovery.Fees
+	}
+	tx, err := c.sendSelfTransfer(ctx, *mTx, mTx.nonce, minFees)
+	if err != nil {
+		return fmt.Errorf("failed to replace nonce %d: %w", mTx.nonce, err)
+	}
+	txHash := tx.Hash()
+	c.addNonceRecovery(mTx, NonceRecovery{Action: NonceRecoverySelfTransfer, Reason: "replace stuck nonce, " + reason,
+		Nonce: mTx.nonce, TxHash: &txHash, Fees: newTxFees(tx)}, logger)
+	err = c.storage.Update(ctx, *mTx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to update monitored tx %v: %w", mTx.id, err)
+	}
+	return nil
+}
+
+// bumpFees bumps the highest fees sent for the monitored tx so the resent tx replaces the previous
+// ones in the L1 txpool, the new fees are returned
+func (c *Client) bumpFees(mTx *monitoredTx) (*txFees, error) {
+	perMille := int64(replacementBumpPerMille)
+	if mTx.isBlob() {
+		perMille = blobReplacementBumpPerMille
+	}
+
+	sent := mTx.highestSentFees()
+	if mTx.gasFeeCap == nil {
+		minGasPrice := bumpPerMille(sent.GasPrice, perMille)
+		gasPrice := c.limitGasPrice(minGasPrice)
+		if gasPrice.Cmp(minGasPrice) == -1 {
+			return nil, errFeesLimitReached
+		}
+		mTx.gasPrice = gasPrice
+		return &txFees{GasPrice: gasPrice}, nil
+	}
+
+	minGasFeeCap, minGasTipCap := bumpPerMille(sent.GasFeeCap, perMille), bumpPerMille(sent.GasTipCap, perMille)
+	gasFeeCap, gasTipCap := c.limitDynamicFees(minGasFeeCap, minGasTipCap)
+	if gasFeeCap.Cmp(minGasFeeCap) == -1 || gasTipCap.Cmp(minGasTipCap) == -1 {
+		return nil, errFeesLimitReached
+	}
+	fees := &txFees{GasPrice: gasFeeCap, GasFeeCap: gasFeeCap, GasTipCap: gasTipCap}
+	if mTx.isBlob() {
+		minBlobGasFeeCap := bumpPerMille(sent.BlobGasFeeCap, perMille)
+		blobGasFeeCap := c.limitBlobGasFeeCap(minBlobGasFeeCap)
+		if blobGasFeeCap.Cmp(minBlobGasFeeCap) == -1 {
+			return nil, errFeesLimitReached
+		}
+		mTx.blobGasFeeCap = blobGasFeeCap
+		fees.BlobGasFeeCap = blobGasFeeCap
+	}
+	mTx.gasFeeCap, mTx.gasTipCap, mTx.gasPrice = gasFeeCap, gasTipCap, gasFeeCap
+	return fees, nil
+}
+
+// sendSelfTransfer signs and sends a zero-value self-transfer from the sender of the monitored tx
+// with the nonce. The fees are the suggested ones, bumped over the minFees when provided so the
+// self-transfer replaces the tx with the same nonce in the L1 txpool
+func (c *Client) sendSelfTransfer(ctx context.Context, mTx monitoredTx, nonce uint64, minFees *txFees) (*types.Transaction, error) {
+	if c.cfg.CustodialAssets.Enable {
+		return nil, errSelfTransferNotSupported
+	}
+
+	var tx *types.Transaction
+	if c.dynamicFeeEnabled() {
+		suggested, err := c.suggestedDynamicFees(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get suggested dynamic fees: %w", err)
+		}
+		gasFeeCap, gasTipCap := suggested.gasFeeCap, suggested.gasTipCap
+		if minFees != nil {
+			minGasFeeCap := bumpPerMille(minFees.GasFeeCap, replacementBumpPerMille)
+			minGasTipCap := bumpPerMille(minFees.GasTipCap, replacementBumpPerMille)
+			gasFeeCap, gasTipCap = c.limitDynamicFees(maxBigInt(gasFeeCap, minGasFeeCap), maxBigInt(gasTipCap, minGasTipCap))
+			if gasFeeCap.Cmp(minGasFeeCap) == -1 || gasTipCap.Cmp(minGasTipCap) == -1 {
+				return nil, errFeesLimitReached
+			}
+		}
+		tx = types.NewTx(&types.DynamicFeeTx{
+			To:        &mTx.from,
+			Nonce:     nonce,
+			Value:     big.NewInt(0),
+			Gas:       params.TxGas,
+			GasFeeCap: gasFeeCap,
+			GasTipCap: gasTipCap,
+		})
+	} else {
+		gasPrice, err := c.suggestedGasPrice(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get suggested gas price: %w", err)
+		}
+		if minFees != nil {
+			minGasPrice := bumpPerMille(minFees.GasPrice, replacementBumpPerMille)
+			gasPrice = c.limitGasPrice(maxBigInt(gasPrice, minGasPrice))
+			if gasPrice.Cmp(minGasPrice) == -1 {
+				return nil, errFeesLimitReached
+			}
+		}
+		tx = types.NewTx(&types.LegacyTx{
+			To:       &mTx.from,
+			Nonce:    nonce,
+			Value:    big.NewInt(0),
+			Gas:      params.TxGas,
+			GasPrice: gasPrice,
+		})
+	}
+
+	signedTx, err := c.signerFor(mTx).SignTx(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign self-transfer: %w", err)
+	}
+	err = c.etherman.SendTx(ctx, signedTx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send self-transfer %v: %w", signedTx.Hash().String(), err)
+	}
+	return signedTx, nil
+}
+
+// limitGasPrice caps the gas price to the MaxGasPriceLimit
+func (c *Client) limitGasPrice(gasPrice *big.Int) *big.Int {
+	if c.cfg.MaxGasPriceLimit > 0 {
+		if limit := new(big.Int).SetUint64(c.cfg.MaxGasPriceLimit); gasPrice.Cmp(limit) == 1 {
+			return limit
+		}
+	}
+	return gasPrice
+}
+
+// historyWasMined checks if any tx of the monitored tx history was mined
+func (c *Client) historyWasMined(ctx context.Context, mTx monitoredTx) (bool, error) {
+	for txHash := range mTx.history {
+		mined, _, err := c.etherman.CheckTxWasMined(ctx, txHash)
+		if err != nil {
+			return false, fmt.Errorf("failed to check if tx %v was mined: %w", txHash.String(), err)
+		}
+		if mined {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// historyFoundInNetwork checks if any tx of the monitored tx history is found in the network. All the L1
+// providers are asked, since the tx may have been sent through a provider the others haven't heard from
+func (c *Client) historyFoundInNetwork(ctx context.Context, mTx monitoredTx) (bool, error) {
+	for txHash := range mTx.history {
+		_, _, err := c.etherman.GetTxFromAllProviders(ctx, txHash)
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return false, fmt.Errorf("failed to get tx %v: %w", txHash.String(), err)
+		}
+		return true, nil
+	}
+	return false, nil
+}
+
+// addNonceRecovery records the recovery in the monitored tx
+func (c *Client) addNonceRecovery(mTx *monitoredTx, recovery NonceRecovery, logger *log.Logger) {
+	recovery.CreatedAt = time.Now().UTC().Round(time.Microsecond)
+	mTx.nonceRecoveries = append(mTx.nonceRecoveries, recovery)
+	metrics.NonceRecoveryCount(recovery.Action)
+	logger.Infof("monitored tx %v nonce %d recovered with %s: %s", mTx.id, recovery.Nonce, recovery.Action, recovery.Reason)
+}
+
+// newTxFees returns the fees of the tx
+func newTxFees(tx *types.Transaction) *txFees {
+	return &txFees{GasPrice: tx.GasPrice(), GasFeeCap: tx.GasFeeCap(), GasTipCap: tx.GasTipCap(), BlobGasFeeCap: tx.BlobGasFeeCap()}
+}
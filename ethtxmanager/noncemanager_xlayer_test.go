@@ -0,0 +1,248 @@
+package ethtxmanager
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/config/types"
+	"github.com/0xPolygonHermez/zkevm-node/etherman/signer"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/test/dbutils"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+var nonceManagerConfigForTests = Config{
+	GasPriceMarginFactor: 1,
+	NonceManager: NonceManagerConfig{
+		Enable:         true,
+		StuckTxTimeout: types.NewDuration(time.Hour),
+		MaxResends:     1,
+	},
+}
+
+func newNonceManagerTestClient(t *testing.T) (*Client, *ethermanMock, *PostgresStorage) {
+	dbCfg := dbutils.NewStateConfigFromEnv()
+	require.NoError(t, dbutils.InitOrResetState(dbCfg))
+	storage, err := NewPostgresStorage(dbCfg)
+	require.NoError(t, err)
+
+	etherman := newEthermanMock(t)
+	etherman.On("SignTx", mock.Anything, mock.Anything, mock.Anything).Return(
+		func(_ context.Context, _ common.Address, tx *ethTypes.Transaction) (*ethTypes.Transaction, error) {
+			return tx, nil
+		}).Maybe()
+	return New(nonceManagerConfigForTests, etherman, storage, nil), etherman, storage
+}
+
+func newNonceManagerTestTx(id string, nonce uint64, status MonitoredTxStatus, createdAt time.Time, history ...common.Hash) monitoredTx {
+	to := common.HexToAddress("0x2")
+	mTx := monitoredTx{
+		owner: "owner", id: id, from: common.HexToAddress("0x1"), to: &to,
+		nonce: nonce, gas: 21000, gasPrice: big.NewInt(100),
+		status: status, history: map[common.Hash]bool{}, createdAt: createdAt,
+	}
+	for _, txHash := range history {
+		mTx.history[txHash] = true
+	}
+	return mTx
+}
+
+func TestManageNoncesResequence(t *testing.T) {
+	ctx := context.Background()
+	c, etherman, storage := newNonceManagerTestClient(t)
+	from := common.HexToAddress("0x1")
+	consumedTxHash, sentTxHash := common.HexToHash("0x10"), common.HexToHash("0x11")
+	now := time.Now()
+
+	mTxs := []monitoredTx{
+		// created after the others, its nonce is also held by the sent one
+		newNonceManagerTestTx("duplicated", 5, MonitoredTxStatusCreated, now.Add(-time.Minute)),
+		// its nonce was consumed by another tx
+		newNonceManagerTestTx("consumed", 3, MonitoredTxStatusSent, now.Add(-3*time.Minute), consumedTxHash),
+		// sent and in the network, it can't be moved
+		newNonceManagerTestTx("sent", 5, MonitoredTxStatusSent, now.Add(-2*time.Minute), sentTxHash),
+	}
+	for _, mTx := range mTxs {
+		require.NoError(t, storage.Add(ctx, mTx, nil))
+	}
+
+	etherman.On("CurrentNonce", ctx, from).Return(uint64(5), nil).Once()
+	etherman.On("PendingNonce", ctx, from).Return(uint64(6), nil).Once()
+	etherman.On("CheckTxWasMined", ctx, consumedTxHash).Return(false, nil, nil).Once()
+	etherman.On("GetTxFromAllProviders", ctx, sentTxHash).Return(&ethTypes.Transaction{}, true, nil).Once()
+
+	c.manageNonces(ctx, mTxs)
+
+	expectedNonces := map[string]uint64{"consumed": 6, "sent": 5, "duplicated": 7}
+	for _, mTx := range mTxs {
+		assert.Equal(t, expectedNonces[mTx.id], mTx.nonce)
+		stored, err := storage.Get(ctx, "owner", mTx.id, nil)
+		require.NoError(t, err)
+		assert.Equal(t, expectedNonces[mTx.id], stored.nonce)
+	}
+
+	stored, err := storage.Get(ctx, "owner", "duplicated", nil)
+	require.NoError(t, err)
+	require.Len(t, stored.nonceRecoveries, 1)
+	recovery := stored.nonceRecoveries[0]
+	assert.Equal(t, NonceRecoveryResequence, recovery.Action)
+	assert.Equal(t, uint64(5), recovery.Nonce)
+	require.NotNil(t, recovery.NewNonce)
+	assert.Equal(t, uint64(7), *recovery.NewNonce)
+	assert.Equal(t, "nonce held by monitored tx sent", recovery.Reason)
+
+	stored, err = storage.Get(ctx, "owner", "sent", nil)
+	require.NoError(t, err)
+	assert.Empty(t, stored.nonceRecoveries)
+}
+
+func TestFillNonceGaps(t *testing.T) {
+	ctx := context.Background()
+	c, etherman, storage := newNonceManagerTestClient(t)
+	from := common.HexToAddress("0x1")
+	logger := log.WithFields("test", "TestFillNonceGaps")
+
+	mTx := newNonceManagerTestTx("blocked", 4, MonitoredTxStatusSent, time.Now())
+	require.NoError(t, storage.Add(ctx, mTx, nil))
+
+	// the nonce 1 is in the L1 txpool, the nonces 2 and 3 are filled with self-transfers
+	etherman.On("SuggestedGasPrice", ctx).Return(big.NewInt(50), nil).Twice()
+	for _, nonce := range []uint64{2, 3} {
+		nonce := nonce
+		etherman.On("SendTx", ctx, mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
+			return tx.Nonce() == nonce && *tx.To() == from && tx.Value().Sign() == 0 && tx.Gas() == params.TxGas
+		})).Return(nil).Once()
+	}
+	require.NoError(t, c.fillNonceGaps(ctx, []*monitoredTx{&mTx}, 1, 2, logger))
+	require.Len(t, mTx.nonceRecoveries, 2)
+	for i, recovery := range mTx.nonceRecoveries {
+		assert.Equal(t, NonceRecoverySelfTransfer, recovery.Action)
+		assert.Equal(t, uint64(i+2), recovery.Nonce)
+		assert.NotNil(t, recovery.TxHash)
+		assert.Equal(t, int64(50), recovery.Fees.GasPrice.Int64())
+	}
+
+	// the gaps are not filled again before the StuckTxTimeout
+	require.NoError(t, c.fillNonceGaps(ctx, []*monitoredTx{&mTx}, 1, 2, logger))
+
+	stored, err := storage.Get(ctx, "owner", "blocked", nil)
+	require.NoError(t, err)
+	assert.Len(t, stored.nonceRecoveries, 2)
+}
+
+func TestFillNonceGapsWithPolicySigner(t *testing.T) {
+	ctx := context.Background()
+	dbCfg := dbutils.NewStateConfigFromEnv()
+	require.NoError(t, dbutils.InitOrResetState(dbCfg))
+	storage, err := NewPostgresStorage(dbCfg)
+	require.NoError(t, err)
+	logger := log.WithFields("test", "TestFillNonceGapsWithPolicySigner")
+
+	// the signer is only allowed to call a contract
+	password := "testonly"
+	account, err := keystore.StoreKey(t.TempDir(), password, keystore.LightScryptN, keystore.LightScryptP)
+	require.NoError(t, err)
+	policySigner, err := signer.New(signer.Config{
+		Type:     signer.TypeLocal,
+		Keystore: types.KeystoreFileConfig{Path: account.URL.Path, Password: password},
+		Policy:   []string{common.HexToAddress("0x2").String() + ":*"},
+	}, params.AllDevChainProtocolChanges.ChainID.Uint64())
+	require.NoError(t, err)
+
+	etherman := newEthermanMock(t)
+	etherman.On("SignTx", mock.Anything, account.Address, mock.Anything).Return(
+		func(ctx context.Context, _ common.Address, tx *ethTypes.Transaction) (*ethTypes.Transaction, error) {
+			return policySigner.SignTx(ctx, tx)
+		})
+	c := New(nonceManagerConfigForTests, etherman, storage, nil)
+
+	mTx := newNonceManagerTestTx("blocked", 3, MonitoredTxStatusSent, time.Now())
+	mTx.from = account.Address
+	require.NoError(t, storage.Add(ctx, mTx, nil))
+
+	// the self-transfer filling the nonce 2 is signed despite the policy
+	etherman.On("SuggestedGasPrice", ctx).Return(big.NewInt(50), nil).Once()
+	etherman.On("SendTx", ctx, mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
+		sender, err := ethTypes.Sender(ethTypes.LatestSignerForChainID(tx.ChainId()), tx)
+		return err == nil && sender == account.Address && tx.Nonce() == 2 && *tx.To() == account.Address
+	})).Return(nil).Once()
+	require.NoError(t, c.fillNonceGaps(ctx, []*monitoredTx{&mTx}, 1, 2, logger))
+	require.Len(t, mTx.nonceRecoveries, 1)
+	assert.Equal(t, NonceRecoverySelfTransfer, mTx.nonceRecoveries[0].Action)
+	assert.NotNil(t, mTx.nonceRecoveries[0].TxHash)
+}
+
+func TestRecoverStuckNonce(t *testing.T) {
+	ctx := context.Background()
+	c, etherman, storage := newNonceManagerTestClient(t)
+	from := common.HexToAddress("0x1")
+	logger := log.WithFields("test", "TestRecoverStuckNonce")
+	txHash := common.HexToHash("0x10")
+
+	mTx := newNonceManagerTestTx("stuck", 1, MonitoredTxStatusSent, time.Now().Add(-2*time.Hour), txHash)
+	require.NoError(t, storage.Add(ctx, mTx, nil))
+
+	// the stuck tx is resent with higher fees
+	etherman.On("GetTxFromAllProviders", ctx, txHash).Return(&ethTypes.Transaction{}, true, nil).Once()
+	require.NoError(t, c.recoverStuckNonce(ctx, &mTx, logger))
+	assert.Equal(t, int64(110), mTx.gasPrice.Int64())
+	require.Len(t, mTx.nonceRecoveries, 1)
+	assert.Equal(t, NonceRecoveryResend, mTx.nonceRecoveries[0].Action)
+
+	// nothing is done before the StuckTxTimeout
+	require.NoError(t, c.recoverStuckNonce(ctx, &mTx, logger))
+	assert.Len(t, mTx.nonceRecoveries, 1)
+
+	// once the resends are exhausted the nonce is replaced with a self-transfer
+	mTx.nonceRecoveries[0].CreatedAt = time.Now().Add(-2 * time.Hour)
+	etherman.On("GetTxFromAllProviders", ctx, txHash).Return(nil, false, ethereum.NotFound).Once()
+	etherman.On("SuggestedGasPrice", ctx).Return(big.NewInt(50), nil).Once()
+	etherman.On("SendTx", ctx, mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
+		return tx.Nonce() == 1 && *tx.To() == from && tx.GasPrice().Int64() == 121
+	})).Return(nil).Once()
+	require.NoError(t, c.recoverStuckNonce(ctx, &mTx, logger))
+	require.Len(t, mTx.nonceRecoveries, 2)
+	recovery := mTx.nonceRecoveries[1]
+	assert.Equal(t, NonceRecoverySelfTransfer, recovery.Action)
+	assert.Contains(t, recovery.Reason, "dropped")
+	assert.NotNil(t, recovery.TxHash)
+
+	stored, err := storage.Get(ctx, "owner", "stuck", nil)
+	require.NoError(t, err)
+	assert.Equal(t, mTx.nonceRecoveries[1].Action, stored.nonceRecoveries[1].Action)
+	assert.Equal(t, int64(110), stored.gasPrice.Int64())
+}
+
+func TestBumpFees(t *testing.T) {
+	c := New(Config{MaxGasPriceLimit: 105}, nil, nil, nil)
+
+	mTx := monitoredTx{gasPrice: big.NewInt(100)}
+	_, err := c.bumpFees(&mTx)
+	assert.ErrorIs(t, err, errFeesLimitReached)
+	assert.Equal(t, int64(100), mTx.gasPrice.Int64())
+
+	mTx = monitoredTx{gasPrice: big.NewInt(200), gasFeeCap: big.NewInt(200), gasTipCap: big.NewInt(10)}
+	fees, err := c.bumpFees(&mTx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(220), fees.GasFeeCap.Int64())
+	assert.Equal(t, int64(11), fees.GasTipCap.Int64())
+	assert.Equal(t, mTx.gasFeeCap, mTx.gasPrice)
+
+	// the blob txs are replaced when all the fees are doubled
+	mTx = monitoredTx{gasPrice: big.NewInt(200), gasFeeCap: big.NewInt(200), gasTipCap: big.NewInt(10), blobGasFeeCap: big.NewInt(30),
+		blobSidecar: &ethTypes.BlobTxSidecar{}}
+	fees, err = c.bumpFees(&mTx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(400), fees.GasFeeCap.Int64())
+	assert.Equal(t, int64(20), fees.GasTipCap.Int64())
+	assert.Equal(t, int64(60), mTx.blobGasFeeCap.Int64())
+}
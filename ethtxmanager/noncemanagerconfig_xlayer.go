@@ -0,0 +1,18 @@
+package ethtxmanager
+
+import "github.com/0xPolygonHermez/zkevm-node/config/types"
+
+// NonceManagerConfig is the config of the nonce manager, which recovers the nonces of the L1
+// senders when a tx is dropped from the L1 txpool or stays stuck blocking the later txs
+type NonceManagerConfig struct {
+	// Enable reviews the nonces of the monitored txs of each sender in every monitoring cycle
+	Enable bool `mapstructure:"Enable"`
+
+	// StuckTxTimeout is the time a tx blocking the nonce of its sender can stay without being mined
+	// since it was sent or recovered, before it's considered stuck or dropped and recovered again
+	StuckTxTimeout types.Duration `mapstructure:"StuckTxTimeout"`
+
+	// MaxResends is the number of times the fees of a stuck tx are bumped to resend it, once reached
+	// the nonce is replaced with a zero-value self-transfer and the monitored tx is re-sequenced
+	MaxResends uint64 `mapstructure:"MaxResends"`
+}
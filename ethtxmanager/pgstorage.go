@@ -9,6 +9,8 @@ import (
 
 	"github.com/0xPolygonHermez/zkevm-node/db"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/jackc/pgconn"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
@@ -36,14 +38,21 @@ func NewPostgresStorage(dbCfg db.Config) (*PostgresStorage, error) {
 func (s *PostgresStorage) Add(ctx context.Context, mTx monitoredTx, dbTx pgx.Tx) error {
 	conn := s.dbConn(dbTx)
 	cmd := `
-        INSERT INTO state.monitored_txs (owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at)
-                                 VALUES (   $1, $2,        $3,      $4,    $5,    $6,   $7,  $8,         $9,       $10,    $11,       $12,     $13,        $14,        $15)`
+        INSERT INTO state.monitored_txs (owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, gas_fee_cap, gas_tip_cap, blob_gas_fee_cap, blob_sidecar, status, block_num, history, history_fees, nonce_recoveries, created_at, updated_at)
+                                 VALUES (   $1, $2,        $3,      $4,    $5,    $6,   $7,  $8,         $9,       $10,         $11,         $12,              $13,          $14,    $15,       $16,     $17,          $18,              $19,        $20,        $21)`
 
-	_, err := conn.Exec(ctx, cmd, mTx.owner,
+	blobSidecar, err := mTx.blobSidecarBytes()
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(ctx, cmd, mTx.owner,
 		mTx.id, mTx.from.String(), mTx.toStringPtr(),
 		mTx.nonce, mTx.valueU64Ptr(), mTx.dataStringPtr(),
-		mTx.gas, mTx.gasOffset, mTx.gasPrice.Uint64(), string(mTx.status), mTx.blockNumberU64Ptr(),
-		mTx.historyStringSlice(), time.Now().UTC().Round(time.Microsecond),
+		mTx.gas, mTx.gasOffset, mTx.gasPrice.Uint64(), mTx.gasFeeCapStringPtr(), mTx.gasTipCapStringPtr(),
+		mTx.blobGasFeeCapStringPtr(), blobSidecar,
+		string(mTx.status), mTx.blockNumberU64Ptr(), mTx.historyStringSlice(), mTx.historyFees, mTx.nonceRecoveries,
+		time.Now().UTC().Round(time.Microsecond),
 		time.Now().UTC().Round(time.Microsecond))
 
 	if err != nil {
@@ -61,7 +70,7 @@ func (s *PostgresStorage) Add(ctx context.Context, mTx monitoredTx, dbTx pgx.Tx)
 func (s *PostgresStorage) Get(ctx context.Context, owner, id string, dbTx pgx.Tx) (monitoredTx, error) {
 	conn := s.dbConn(dbTx)
 	cmd := `
-        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at
+        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, gas_fee_cap::TEXT, gas_tip_cap::TEXT, blob_gas_fee_cap::TEXT, blob_sidecar, status, block_num, history, history_fees, nonce_recoveries, created_at, updated_at
           FROM state.monitored_txs
          WHERE owner = $1 
            AND id = $2`
@@ -85,7 +94,7 @@ func (s *PostgresStorage) GetByStatus(ctx context.Context, owner *string, status
 
 	conn := s.dbConn(dbTx)
 	cmd := `
-        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at
+        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, gas_fee_cap::TEXT, gas_tip_cap::TEXT, blob_gas_fee_cap::TEXT, blob_sidecar, status, block_num, history, history_fees, nonce_recoveries, created_at, updated_at
           FROM state.monitored_txs
          WHERE (owner = $1 OR $1 IS NULL)`
 	if hasStatusToFilter {
@@ -128,7 +137,7 @@ func (s *PostgresStorage) GetByStatus(ctx context.Context, owner *string, status
 func (s *PostgresStorage) GetByBlock(ctx context.Context, fromBlock, toBlock *uint64, dbTx pgx.Tx) ([]monitoredTx, error) {
 	conn := s.dbConn(dbTx)
 	cmd := `
-        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, status, block_num, history, created_at, updated_at
+        SELECT owner, id, from_addr, to_addr, nonce, value, data, gas, gas_offset, gas_price, gas_fee_cap::TEXT, gas_tip_cap::TEXT, blob_gas_fee_cap::TEXT, blob_sidecar, status, block_num, history, history_fees, nonce_recoveries, created_at, updated_at
           FROM state.monitored_txs
          WHERE (block_num >= $1 OR $1 IS NULL)
            AND (block_num <= $2 OR $2 IS NULL)
@@ -184,10 +193,16 @@ func (s *PostgresStorage) Update(ctx context.Context, mTx monitoredTx, dbTx pgx.
              , gas = $8
              , gas_offset = $9
              , gas_price = $10
-             , status = $11
-             , block_num = $12
-             , history = $13
-             , updated_at = $14
+             , gas_fee_cap = $11
+             , gas_tip_cap = $12
+             , blob_gas_fee_cap = $13
+             , blob_sidecar = $14
+             , status = $15
+             , block_num = $16
+             , history = $17
+             , history_fees = $18
+             , nonce_recoveries = $19
+             , updated_at = $20
          WHERE owner = $1
            AND id = $2`
 
@@ -197,11 +212,18 @@ func (s *PostgresStorage) Update(ctx context.Context, mTx monitoredTx, dbTx pgx.
 		bn = &tmp
 	}
 
-	_, err := conn.Exec(ctx, cmd, mTx.owner,
+	blobSidecar, err := mTx.blobSidecarBytes()
+	if err != nil {
+		return err
+	}
+
+	_, err = conn.Exec(ctx, cmd, mTx.owner,
 		mTx.id, mTx.from.String(), mTx.toStringPtr(),
 		mTx.nonce, mTx.valueU64Ptr(), mTx.dataStringPtr(),
-		mTx.gas, mTx.gasOffset, mTx.gasPrice.Uint64(), string(mTx.status), bn,
-		mTx.historyStringSlice(), time.Now().UTC().Round(time.Microsecond))
+		mTx.gas, mTx.gasOffset, mTx.gasPrice.Uint64(), mTx.gasFeeCapStringPtr(), mTx.gasTipCapStringPtr(),
+		mTx.blobGasFeeCapStringPtr(), blobSidecar,
+		string(mTx.status), bn, mTx.historyStringSlice(), mTx.historyFees, mTx.nonceRecoveries,
+		time.Now().UTC().Round(time.Microsecond))
 
 	if err != nil {
 		return err
@@ -213,16 +235,19 @@ func (s *PostgresStorage) Update(ctx context.Context, mTx monitoredTx, dbTx pgx.
 // scanMtx scans a row and fill the provided instance of monitoredTx with
 // the row data
 func (s *PostgresStorage) scanMtx(row pgx.Row, mTx *monitoredTx) error {
-	// id, from, to, nonce, value, data, gas, gas_offset, gas_price, status, history, created_at, updated_at
+	// id, from, to, nonce, value, data, gas, gas_offset, gas_price, gas_fee_cap, gas_tip_cap, blob_gas_fee_cap, blob_sidecar, status, history, history_fees, nonce_recoveries, created_at, updated_at
 	var from, status string
 	var to, data *string
 	var history []string
+	var historyFees map[common.Hash]txFees
 	var value, blockNumber *uint64
+	var gasFeeCap, gasTipCap, blobGasFeeCap *string
 	var gasPrice uint64
+	var blobSidecar []byte
 
 	err := row.Scan(&mTx.owner, &mTx.id, &from, &to, &mTx.nonce, &value,
-		&data, &mTx.gas, &mTx.gasOffset, &gasPrice, &status, &blockNumber, &history,
-		&mTx.createdAt, &mTx.updatedAt)
+		&data, &mTx.gas, &mTx.gasOffset, &gasPrice, &gasFeeCap, &gasTipCap, &blobGasFeeCap, &blobSidecar,
+		&status, &blockNumber, &history, &historyFees, &mTx.nonceRecoveries, &mTx.createdAt, &mTx.updatedAt)
 	if err != nil {
 		return err
 	}
@@ -251,6 +276,21 @@ func (s *PostgresStorage) scanMtx(row pgx.Row, mTx *monitoredTx) error {
 		tmp := *blockNumber
 		mTx.blockNumber = big.NewInt(0).SetUint64(tmp)
 	}
+	if mTx.gasFeeCap, err = bigIntFromStringPtr(gasFeeCap); err != nil {
+		return err
+	}
+	if mTx.gasTipCap, err = bigIntFromStringPtr(gasTipCap); err != nil {
+		return err
+	}
+	if mTx.blobGasFeeCap, err = bigIntFromStringPtr(blobGasFeeCap); err != nil {
+		return err
+	}
+	if len(blobSidecar) > 0 {
+		mTx.blobSidecar = &types.BlobTxSidecar{}
+		if err := rlp.DecodeBytes(blobSidecar, mTx.blobSidecar); err != nil {
+			return err
+		}
+	}
 
 	h := make(map[common.Hash]bool, len(history))
 	for _, txHash := range history {
@@ -258,6 +298,11 @@ func (s *PostgresStorage) scanMtx(row pgx.Row, mTx *monitoredTx) error {
 	}
 	mTx.history = h
 
+	if historyFees == nil {
+		historyFees = make(map[common.Hash]txFees, len(history))
+	}
+	mTx.historyFees = historyFees
+
 	return nil
 }
 
@@ -0,0 +1,50 @@
+package ethtxmanager
+
+import (
+	"context"
+
+	"github.com/0xPolygonHermez/zkevm-node/etherman/signer"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// custodialSigner signs the txs of a monitored tx with the custodial assets service
+type custodialSigner struct {
+	c   *Client
+	mTx monitoredTx
+}
+
+// Address returns the sender of the monitored tx
+func (s custodialSigner) Address() common.Address {
+	return s.mTx.from
+}
+
+// SignTx signs the tx with the custodial assets service
+func (s custodialSigner) SignTx(_ context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return s.c.signTx(s.mTx, tx)
+}
+
+// ethermanSigner signs the txs with the authorization of the sender loaded in the etherman,
+// either a key store file or a configured signer
+type ethermanSigner struct {
+	etherman ethermanInterface
+	from     common.Address
+}
+
+// Address returns the sender of the txs
+func (s ethermanSigner) Address() common.Address {
+	return s.from
+}
+
+// SignTx signs the tx with the authorization of the sender
+func (s ethermanSigner) SignTx(ctx context.Context, tx *types.Transaction) (*types.Transaction, error) {
+	return s.etherman.SignTx(ctx, s.from, tx)
+}
+
+// signerFor returns the signer of the monitored tx
+func (c *Client) signerFor(mTx monitoredTx) signer.Signer {
+	if c.cfg.CustodialAssets.Enable {
+		return custodialSigner{c: c, mTx: mTx}
+	}
+	return ethermanSigner{etherman: c.etherman, from: mTx.from}
+}
@@ -0,0 +1,58 @@
+package gasprice
+
+import (
+	"math/big"
+	"sort"
+)
+
+// TxTip is the tip paid by a transaction along with the gas it used.
+type TxTip struct {
+	Tip     *big.Int
+	GasUsed uint64
+}
+
+// CollectTxsTips returns the tips sorted in ascending order, skipping the ones
+// below ignorePrice. If limit is greater than zero, at most limit tips are returned.
+func CollectTxsTips(tips []TxTip, limit int, ignorePrice *big.Int) []TxTip {
+	sorted := make([]TxTip, len(tips))
+	copy(sorted, tips)
+	sort.Stable(tipSorter(sorted))
+
+	var collected []TxTip
+	for _, tip := range sorted {
+		if ignorePrice != nil && tip.Tip.Cmp(ignorePrice) == -1 {
+			continue
+		}
+		collected = append(collected, tip)
+		if limit > 0 && len(collected) >= limit {
+			break
+		}
+	}
+	return collected
+}
+
+// RewardPercentiles returns the tip paid at each of the given percentiles of an l2 block.
+// Like eth_feeHistory in geth, each transaction is weighted by the gas it used, so
+// percentiles must be sorted in ascending order and range from 0 to 100.
+func RewardPercentiles(tips []TxTip, blockGasUsed uint64, percentiles []float64) []*big.Int {
+	rewards := make([]*big.Int, len(percentiles))
+	sorted := CollectTxsTips(tips, 0, nil)
+	if len(sorted) == 0 {
+		for i := range rewards {
+			rewards[i] = big.NewInt(0)
+		}
+		return rewards
+	}
+
+	txIndex := 0
+	sumGasUsed := sorted[0].GasUsed
+	for i, p := range percentiles {
+		thresholdGasUsed := uint64(float64(blockGasUsed) * p / 100) // nolint:gomnd
+		for sumGasUsed < thresholdGasUsed && txIndex < len(sorted)-1 {
+			txIndex++
+			sumGasUsed += sorted[txIndex].GasUsed
+		}
+		rewards[i] = new(big.Int).Set(sorted[txIndex].Tip)
+	}
+	return rewards
+}
@@ -0,0 +1,43 @@
+package gasprice
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectTxsTips(t *testing.T) {
+	tips := []TxTip{
+		{Tip: big.NewInt(30), GasUsed: 21000},
+		{Tip: big.NewInt(10), GasUsed: 21000},
+		{Tip: big.NewInt(20), GasUsed: 21000},
+		{Tip: big.NewInt(5), GasUsed: 21000},
+	}
+
+	collected := CollectTxsTips(tips, 2, big.NewInt(10))
+	assert.Equal(t, 2, len(collected))
+	assert.Equal(t, big.NewInt(10), collected[0].Tip)
+	assert.Equal(t, big.NewInt(20), collected[1].Tip)
+
+	collected = CollectTxsTips(tips, 0, nil)
+	assert.Equal(t, 4, len(collected))
+	assert.Equal(t, big.NewInt(5), collected[0].Tip)
+	assert.Equal(t, big.NewInt(30), collected[3].Tip)
+	// the input must be kept untouched
+	assert.Equal(t, big.NewInt(30), tips[0].Tip)
+}
+
+func TestRewardPercentiles(t *testing.T) {
+	tips := []TxTip{
+		{Tip: big.NewInt(3), GasUsed: 50000},
+		{Tip: big.NewInt(1), GasUsed: 25000},
+		{Tip: big.NewInt(2), GasUsed: 25000},
+	}
+
+	rewards := RewardPercentiles(tips, 100000, []float64{0, 25, 30, 50, 100})
+	assert.Equal(t, []*big.Int{big.NewInt(1), big.NewInt(1), big.NewInt(2), big.NewInt(2), big.NewInt(3)}, rewards)
+
+	rewards = RewardPercentiles(nil, 0, []float64{10, 90})
+	assert.Equal(t, []*big.Int{big.NewInt(0), big.NewInt(0)}, rewards)
+}
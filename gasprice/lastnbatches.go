@@ -117,19 +117,14 @@ func (g *LastNL2BlocksGasPrice) getL2BlockTxsTips(ctx context.Context, l2BlockNu
 		}
 		return
 	}
-	sorter := newSorter(txs)
-	sort.Sort(sorter)
+	tips := make([]TxTip, 0, len(txs))
+	for _, tx := range txs {
+		tips = append(tips, TxTip{Tip: tx.GasTipCap(), GasUsed: tx.Gas()})
+	}
 
 	var prices []*big.Int
-	for _, tx := range sorter.txs {
-		tip := tx.GasTipCap()
-		if ignorePrice != nil && tip.Cmp(ignorePrice) == -1 {
-			continue
-		}
-		prices = append(prices, tip)
-		if len(prices) >= limit {
-			break
-		}
+	for _, tip := range CollectTxsTips(tips, limit, ignorePrice) {
+		prices = append(prices, tip.Tip)
 	}
 	select {
 	case result <- results{prices, nil}:
@@ -2,29 +2,13 @@ package gasprice
 
 import (
 	"math/big"
-
-	"github.com/ethereum/go-ethereum/core/types"
 )
 
-type txSorter struct {
-	txs []*types.Transaction
-}
-
-func newSorter(txs []*types.Transaction) *txSorter {
-	return &txSorter{
-		txs: txs,
-	}
-}
+type tipSorter []TxTip
 
-func (s *txSorter) Len() int { return len(s.txs) }
-func (s *txSorter) Swap(i, j int) {
-	s.txs[i], s.txs[j] = s.txs[j], s.txs[i]
-}
-func (s *txSorter) Less(i, j int) bool {
-	tip1 := s.txs[i].GasTipCap()
-	tip2 := s.txs[j].GasTipCap()
-	return tip1.Cmp(tip2) < 0
-}
+func (s tipSorter) Len() int           { return len(s) }
+func (s tipSorter) Less(i, j int) bool { return s[i].Tip.Cmp(s[j].Tip) < 0 }
+func (s tipSorter) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
 
 type bigIntArray []*big.Int
 
@@ -7,6 +7,7 @@ import (
 	"net/http"
 	"path"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/metrics"
@@ -20,6 +21,10 @@ type ApiAuthConfig struct {
 	Enabled bool `mapstructure:"Enabled"`
 	// ApiKeys defines the api keys
 	ApiKeys []KeyItem `mapstructure:"ApiKeys"`
+	// DefaultMethodWeight defines the compute units used by a request to a method without weight, 0 means 1
+	DefaultMethodWeight uint64 `mapstructure:"DefaultMethodWeight"`
+	// MethodWeights defines the compute units used by a request to each method
+	MethodWeights []MethodWeight `mapstructure:"MethodWeights"`
 }
 
 // KeyItem is the api key item
@@ -30,16 +35,39 @@ type KeyItem struct {
 	Key string `mapstructure:"Key"`
 	// Timeout defines the timeout
 	Timeout string `mapstructure:"Timeout"`
+	// RequestsPerSecond defines the max number of requests per second of the key, 0 means no limit
+	RequestsPerSecond int `mapstructure:"RequestsPerSecond"`
+	// DailyComputeUnits defines the max compute units used by the key in a UTC day, 0 means no limit
+	DailyComputeUnits uint64 `mapstructure:"DailyComputeUnits"`
+	// AllowedMethods defines the only methods the key can call, all the methods are allowed when empty. A method ending with * matches all the methods with that prefix
+	AllowedMethods []string `mapstructure:"AllowedMethods"`
+	// DeniedMethods defines the methods the key can't call. A method ending with * matches all the methods with that prefix
+	DeniedMethods []string `mapstructure:"DeniedMethods"`
+}
+
+// MethodWeight defines the compute units used by a request to a method
+type MethodWeight struct {
+	// Method defines the method, a method ending with * matches all the methods with that prefix
+	Method string `mapstructure:"Method"`
+	// Weight defines the compute units used by each request
+	Weight uint64 `mapstructure:"Weight"`
 }
 
 type apiAllow struct {
-	allowKeys map[string]keyItem
-	enable    bool
+	allowKeys     map[string]keyItem
+	enable        bool
+	defaultWeight uint64
+	methodWeights []MethodWeight
+	usage         map[string]*keyUsage
+	sync.RWMutex
 }
 
 type keyItem struct {
-	project string
-	timeout time.Time
+	project           string
+	timeout           time.Time
+	dailyComputeUnits uint64
+	allowedMethods    []string
+	deniedMethods     []string
 }
 
 var al apiAllow
@@ -49,10 +77,20 @@ func InitApiAuth(a ApiAuthConfig) {
 	setApiAuth(a)
 }
 
-// setApiAuth sets the api authentication
+// setApiAuth sets the api authentication, the usage of the keys is kept so the
+// quotas are not reset when the config is reloaded
 func setApiAuth(a ApiAuthConfig) {
+	al.Lock()
+	defer al.Unlock()
 	al.enable = a.Enabled
+	al.defaultWeight = a.DefaultMethodWeight
+	if al.defaultWeight == 0 {
+		al.defaultWeight = 1
+	}
+	al.methodWeights = make([]MethodWeight, len(a.MethodWeights))
+	copy(al.methodWeights, a.MethodWeights)
 	var tmp = make(map[string]keyItem)
+	var usage = make(map[string]*keyUsage)
 	for _, k := range a.ApiKeys {
 		k.Key = strings.ToLower(k.Key)
 		parse, err := time.Parse("2006-01-02", k.Timeout)
@@ -64,15 +102,31 @@ func setApiAuth(a ApiAuthConfig) {
 			log.Warnf("project [%s], key [%s] is invalid, key = md5(Project+Timeout)", k.Project, k.Key)
 			continue
 		}
-		tmp[k.Key] = keyItem{project: k.Project, timeout: parse}
+		tmp[k.Key] = keyItem{
+			project:           k.Project,
+			timeout:           parse,
+			dailyComputeUnits: k.DailyComputeUnits,
+			allowedMethods:    k.AllowedMethods,
+			deniedMethods:     k.DeniedMethods,
+		}
+		u, ok := al.usage[k.Key]
+		if !ok {
+			u = &keyUsage{}
+		}
+		u.setRequestsPerSecond(k.RequestsPerSecond)
+		usage[k.Key] = u
 	}
 	al.allowKeys = tmp
+	al.usage = usage
 }
 
 func check(key string) error {
 	key = strings.ToLower(key)
-	if item, ok := al.allowKeys[key]; ok && time.Now().Before(item.timeout) {
-		metrics.RequestAuthCount(al.allowKeys[key].project)
+	al.RLock()
+	item, ok := al.allowKeys[key]
+	al.RUnlock()
+	if ok && time.Now().Before(item.timeout) {
+		metrics.RequestAuthCount(item.project)
 		return nil
 	} else if ok && time.Now().After(item.timeout) {
 		log.Warnf("project [%s], key [%s] has expired, ", item.project, key)
@@ -85,7 +139,10 @@ func check(key string) error {
 
 func apiAuthHandlerFunc(handlerFunc http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if al.enable {
+		al.RLock()
+		enable := al.enable
+		al.RUnlock()
+		if enable {
 			if er := check(path.Base(r.URL.Path)); er != nil {
 				err := handleNoAuthErr(w, er)
 				if err != nil {
@@ -0,0 +1,135 @@
+package jsonrpc
+
+import (
+	"net/http"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/metrics"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"golang.org/x/time/rate"
+)
+
+const day = 24 * time.Hour
+
+// keyUsage is the usage of an api key, it's kept when the config is reloaded
+type keyUsage struct {
+	limiter           *rate.Limiter
+	requestsPerSecond int
+	// day is the UTC day of the used compute units
+	day          time.Time
+	computeUnits uint64
+	sync.Mutex
+}
+
+// setRequestsPerSecond updates the rate limit of the key keeping the state of the limiter
+func (u *keyUsage) setRequestsPerSecond(requestsPerSecond int) {
+	u.Lock()
+	defer u.Unlock()
+	if requestsPerSecond == u.requestsPerSecond {
+		return
+	}
+	u.requestsPerSecond = requestsPerSecond
+	if requestsPerSecond <= 0 {
+		u.limiter = nil
+	} else if u.limiter == nil {
+		u.limiter = rate.NewLimiter(rate.Limit(requestsPerSecond), requestsPerSecond)
+	} else {
+		u.limiter.SetLimit(rate.Limit(requestsPerSecond))
+		u.limiter.SetBurst(requestsPerSecond)
+	}
+}
+
+// allow returns true if a request is allowed by the rate limit of the key, along with the rate limit
+func (u *keyUsage) allow() (bool, int) {
+	u.Lock()
+	defer u.Unlock()
+	return u.limiter == nil || u.limiter.Allow(), u.requestsPerSecond
+}
+
+// useComputeUnits adds the compute units of a request to the usage of the current day, the
+// compute units are not added and false is returned when the request is over the daily limit
+func (u *keyUsage) useComputeUnits(units, dailyLimit uint64, now time.Time) bool {
+	u.Lock()
+	defer u.Unlock()
+	today := now.UTC().Truncate(day)
+	if !u.day.Equal(today) {
+		u.day = today
+		u.computeUnits = 0
+	}
+	if dailyLimit > 0 && u.computeUnits+units > dailyLimit {
+		return false
+	}
+	u.computeUnits += units
+	return true
+}
+
+// matchMethod checks if a method is in a list of methods, a method ending with * matches all the methods with that prefix
+func matchMethod(methods []string, method string) bool {
+	for _, m := range methods {
+		if m == method || (strings.HasSuffix(m, "*") && strings.HasPrefix(method, strings.TrimSuffix(m, "*"))) {
+			return true
+		}
+	}
+	return false
+}
+
+// methodAllowed checks the allowlist and the denylist of the key, the denylist takes precedence
+func (k keyItem) methodAllowed(method string) bool {
+	if matchMethod(k.deniedMethods, method) {
+		return false
+	}
+	return len(k.allowedMethods) == 0 || matchMethod(k.allowedMethods, method)
+}
+
+// methodWeight returns the compute units used by a request to a method, the first matching weight is used
+func (a *apiAllow) methodWeight(method string) uint64 {
+	for _, w := range a.methodWeights {
+		if matchMethod([]string{w.Method}, method) {
+			return w.Weight
+		}
+	}
+	return a.defaultWeight
+}
+
+// apiKeyAllow checks a request against the policies of the api key of the request: the allowed
+// and denied methods, the requests per second and the daily compute units. The validity of the
+// key is checked before by the api authentication handler
+func apiKeyAllow(httpRequest *http.Request, method string) types.Error {
+	if httpRequest == nil || httpRequest.URL == nil {
+		return nil
+	}
+	key := strings.ToLower(path.Base(httpRequest.URL.Path))
+
+	al.RLock()
+	enable := al.enable
+	item, ok := al.allowKeys[key]
+	usage := al.usage[key]
+	weight := al.methodWeight(method)
+	al.RUnlock()
+	if !enable || !ok || usage == nil {
+		return nil
+	}
+
+	if !item.methodAllowed(method) {
+		metrics.RequestKeyRejectedCount(item.project, metrics.RequestKeyRejectedTypeMethodNotAllowed)
+		return types.NewRPCError(types.InvalidRequestErrorCode, "method %s is not allowed for this api key", method)
+	}
+
+	if allowed, requestsPerSecond := usage.allow(); !allowed {
+		metrics.RequestKeyRejectedCount(item.project, metrics.RequestKeyRejectedTypeRateLimit)
+		return types.NewQuotaExceededError(types.QuotaRequestsPerSecond, uint64(requestsPerSecond), time.Second)
+	}
+
+	now := time.Now()
+	if !usage.useComputeUnits(weight, item.dailyComputeUnits, now) {
+		metrics.RequestKeyRejectedCount(item.project, metrics.RequestKeyRejectedTypeComputeUnits)
+		nextDay := now.UTC().Truncate(day).Add(day)
+		return types.NewQuotaExceededError(types.QuotaDailyComputeUnits, item.dailyComputeUnits, nextDay.Sub(now))
+	}
+	metrics.RequestKeyComputeUnits(item.project, weight)
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package jsonrpc
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyItemMethodAllowed(t *testing.T) {
+	item := keyItem{
+		allowedMethods: []string{"eth_*", "debug_traceTransaction"},
+		deniedMethods:  []string{"eth_sendRawTransaction"},
+	}
+	assert.True(t, item.methodAllowed("eth_blockNumber"))
+	assert.True(t, item.methodAllowed("debug_traceTransaction"))
+	assert.False(t, item.methodAllowed("debug_traceBlockByNumber"))
+	assert.False(t, item.methodAllowed("eth_sendRawTransaction"))
+	assert.False(t, item.methodAllowed("net_version"))
+
+	assert.True(t, keyItem{}.methodAllowed("net_version"))
+}
+
+func TestKeyUsage(t *testing.T) {
+	usage := &keyUsage{}
+	allowed, _ := usage.allow()
+	assert.True(t, allowed)
+
+	usage.setRequestsPerSecond(1)
+	allowed, limit := usage.allow()
+	assert.True(t, allowed)
+	assert.Equal(t, 1, limit)
+	allowed, _ = usage.allow()
+	assert.False(t, allowed)
+
+	now := time.Date(2024, 1, 1, 23, 0, 0, 0, time.UTC)
+	assert.True(t, usage.useComputeUnits(6, 10, now))
+	assert.False(t, usage.useComputeUnits(6, 10, now))
+	assert.True(t, usage.useComputeUnits(4, 10, now))
+	// The compute units are reset at the start of the next UTC day
+	assert.True(t, usage.useComputeUnits(6, 10, now.Add(time.Hour)))
+}
+
+func TestApiKeyPolicies(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	project := "tiered"
+	timeout := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	key := fmt.Sprintf("%x", md5.Sum([]byte(project+timeout)))
+	cfg := ApiAuthConfig{
+		Enabled:       true,
+		MethodWeights: []MethodWeight{{Method: "eth_block*", Weight: 5}},
+		ApiKeys: []KeyItem{{
+			Project:           project,
+			Key:               key,
+			Timeout:           timeout,
+			DailyComputeUnits: 12,
+			AllowedMethods:    []string{"eth_*"},
+			DeniedMethods:     []string{"eth_sendRawTransaction"},
+		}},
+	}
+	setApiAuth(cfg)
+	defer setApiAuth(ApiAuthConfig{})
+	url := s.ServerURL + "/" + key
+
+	res, err := client.JSONRPCCall(url, "net_version")
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidRequestErrorCode, res.Error.Code)
+	assert.Equal(t, "method net_version is not allowed for this api key", res.Error.Message)
+
+	res, err = client.JSONRPCCall(url, "eth_sendRawTransaction", "0x00")
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidRequestErrorCode, res.Error.Code)
+
+	m.State.On("GetLastL2BlockNumber", context.Background(), nil).Return(uint64(1), nil).Times(3)
+	for i := 0; i < 2; i++ {
+		res, err = client.JSONRPCCall(url, "eth_blockNumber")
+		require.NoError(t, err)
+		require.Nil(t, res.Error)
+	}
+
+	res, err = client.JSONRPCCall(url, "eth_blockNumber")
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.QuotaExceededErrorCode, res.Error.Code)
+	assert.Equal(t, "quota exceeded: daily_compute_units", res.Error.Message)
+	require.NotNil(t, res.Error.Data)
+	var data types.QuotaExceededData
+	require.NoError(t, json.Unmarshal(*res.Error.Data, &data))
+	assert.Equal(t, types.QuotaDailyComputeUnits, data.Quota)
+	assert.Equal(t, uint64(12), data.Limit)
+	assert.True(t, data.RetryAfter > 0 && data.RetryAfter <= uint64(24*time.Hour/time.Second), data.RetryAfter)
+
+	// The used compute units are kept when the policies are reloaded
+	cfg.ApiKeys[0].DailyComputeUnits = 15
+	setApiAuth(cfg)
+	res, err = client.JSONRPCCall(url, "eth_blockNumber")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	res, err = client.JSONRPCCall(url, "eth_blockNumber")
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.QuotaExceededErrorCode, res.Error.Code)
+}
@@ -16,6 +16,10 @@ type ApiRelayConfig struct {
 }
 
 func shouldRelay(localCfg ApiRelayConfig, name string) bool {
+	// Private txs are never relayed, they are only sent to the sequencer node
+	if name == sendPrivateTransactionMethod {
+		return false
+	}
 	enable := localCfg.Enabled && localCfg.DestURI != ""
 	contained := types.Contains(localCfg.RPCs, name)
 	if getApolloConfig().Enable() {
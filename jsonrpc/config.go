@@ -111,6 +111,19 @@ type Config struct {
 
 	// ApiRelay defines the relay configuration for the API
 	ApiRelay ApiRelayConfig `mapstructure:"ApiRelay"`
+
+	// AdminApiKeys defines the keys accepted by the admin endpoints, sent as a
+	// bearer token in the Authorization header. Admin endpoints reject every
+	// request when it is empty
+	AdminApiKeys []string `mapstructure:"AdminApiKeys"`
+
+	// PreconfirmationURI is the websocket URI of the sequencer preconfirmation server, or of the trusted
+	// RPC node the preconfirmations are relayed from. The preconfirmations are disabled when it's empty
+	PreconfirmationURI string `mapstructure:"PreconfirmationURI"`
+
+	// PreconfirmationAuthKey is the key sent as a bearer token in the Authorization header
+	// when subscribing to the preconfirmation server
+	PreconfirmationAuthKey string `mapstructure:"PreconfirmationAuthKey"`
 }
 
 // ZKCountersLimits defines the ZK Counter limits
@@ -0,0 +1,95 @@
+package jsonrpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// APIAdmin represents the admin API prefix.
+	APIAdmin = "admin"
+
+	adminAuthorizationPrefix = "Bearer "
+)
+
+// AdminEndpoints contains implementations for the "admin" RPC endpoints,
+// every request must be authenticated with one of the configured admin keys
+type AdminEndpoints struct {
+	cfg  Config
+	pool types.PoolInterface
+}
+
+// NewAdminEndpoints returns AdminEndpoints
+func NewAdminEndpoints(cfg Config, pool types.PoolInterface) *AdminEndpoints {
+	return &AdminEndpoints{cfg: cfg, pool: pool}
+}
+
+// EvictTx evicts a pending tx from the pool by hash, the sequencer drops it
+// from the worker once it sees the tx as evicted unless it is executing it
+func (a *AdminEndpoints) EvictTx(httpRequest *http.Request, hash types.ArgHash) (interface{}, types.Error) {
+	if rpcErr := a.authenticate(httpRequest); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	err := a.pool.EvictTx(context.Background(), hash.Hash())
+	if errors.Is(err, pool.ErrNotFound) {
+		return RPCErrorResponse(types.DefaultErrorCode, "transaction not found", nil, false)
+	} else if errors.Is(err, pool.ErrTxNotPending) {
+		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
+	} else if errors.Is(err, pool.ErrTxWIP) {
+		return RPCErrorResponse(types.TxWIPErrorCode, err.Error(), nil, false)
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to evict transaction", err, true)
+	}
+
+	log.Infof("tx %s evicted from the pool", hash.Hash().String())
+	return []common.Hash{hash.Hash()}, nil
+}
+
+// EvictTxBySenderAndNonce evicts the pending txs of a sender with the given nonce
+// from the pool, the sequencer drops them from the worker once it sees them as evicted
+func (a *AdminEndpoints) EvictTxBySenderAndNonce(httpRequest *http.Request, from common.Address, nonce types.ArgUint64) (interface{}, types.Error) {
+	if rpcErr := a.authenticate(httpRequest); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	hashes, err := a.pool.EvictTxsByFromAndNonce(context.Background(), from, uint64(nonce))
+	if errors.Is(err, pool.ErrNotFound) {
+		return RPCErrorResponse(types.DefaultErrorCode, "pending transaction not found", nil, false)
+	} else if errors.Is(err, pool.ErrTxWIP) {
+		return RPCErrorResponse(types.TxWIPErrorCode, err.Error(), nil, false)
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to evict transaction", err, true)
+	}
+
+	log.Infof("txs %v of %s with nonce %d evicted from the pool", hashes, from.String(), nonce)
+	return hashes, nil
+}
+
+// authenticate checks the request carries one of the configured admin keys
+func (a *AdminEndpoints) authenticate(httpRequest *http.Request) types.Error {
+	noAuthErr := types.NewRPCError(types.InvalidRequestErrorCode, "admin authentication required")
+	if httpRequest == nil {
+		return noAuthErr
+	}
+
+	authorization := httpRequest.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, adminAuthorizationPrefix) {
+		return noAuthErr
+	}
+	key := []byte(strings.TrimPrefix(authorization, adminAuthorizationPrefix))
+	for _, adminKey := range a.cfg.AdminApiKeys {
+		if subtle.ConstantTimeCompare(key, []byte(adminKey)) == 1 {
+			return nil
+		}
+	}
+	return noAuthErr
+}
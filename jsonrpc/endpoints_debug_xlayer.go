@@ -3,6 +3,7 @@ package jsonrpc
 import (
 	"context"
 	"errors"
+	"fmt"
 
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/0xPolygonHermez/zkevm-node/log"
@@ -35,3 +36,71 @@ func (d *DebugEndpoints) buildInnerTransaction(ctx context.Context, hash common.
 
 	return result.TraceResult, nil
 }
+
+// traceCallConfig is the trace config of debug_traceCall, extended with the
+// state and block overrides applied to the call
+type traceCallConfig struct {
+	traceConfig
+	StateOverrides *types.StateOverride  `json:"stateOverrides"`
+	BlockOverrides *types.BlockOverrides `json:"blockOverrides"`
+}
+
+// TraceCall creates a response for debug_traceCall request.
+// See https://geth.ethereum.org/docs/interacting-with-geth/rpc/ns-debug#debugtracecall
+func (d *DebugEndpoints) TraceCall(arg *types.TxArgs, blockArg *types.BlockNumberOrHash, cfg *traceCallConfig) (interface{}, types.Error) {
+	ctx := context.Background()
+	if arg == nil {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
+	}
+	block, respErr := getL2BlockByArg(ctx, d.state, d.etherman, blockArg, nil)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	traceCfg := cfg
+	if traceCfg == nil {
+		traceCfg = &traceCallConfig{traceConfig: *defaultTraceConfig}
+	}
+	blockOverrides, err := traceCfg.BlockOverrides.ToBlockOverrides()
+	if err != nil {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+	}
+
+	// If the caller didn't supply the gas limit in the message, then we set it to maximum possible => block gas limit
+	if arg.Gas == nil || uint64(*arg.Gas) <= 0 {
+		header, err := d.state.GetL2BlockHeaderByNumber(ctx, block.NumberU64(), nil)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get block header", err, true)
+		}
+
+		gas := types.ArgUint64(header.GasLimit)
+		arg.Gas = &gas
+	}
+
+	defaultSenderAddress := common.HexToAddress(state.DefaultSenderAddress)
+	sender, tx, err := arg.ToTransaction(ctx, d.state, state.MaxTxGasLimit, block.Root(), defaultSenderAddress, nil)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
+	}
+
+	stateTraceConfig := state.TraceConfig{
+		DisableStack:     traceCfg.DisableStack,
+		DisableStorage:   traceCfg.DisableStorage,
+		EnableMemory:     traceCfg.EnableMemory,
+		EnableReturnData: traceCfg.EnableReturnData,
+		Tracer:           traceCfg.Tracer,
+		TracerConfig:     traceCfg.TracerConfig,
+		Limit:            traceCfg.Limit,
+	}
+	blockNumber := block.NumberU64()
+	result, err := d.state.DebugCall(ctx, tx, sender, &blockNumber, traceCfg.StateOverrides.ToStateOverride(), blockOverrides, stateTraceConfig, nil)
+	if errors.Is(err, state.ErrStateAndStateDiffOverride) || errors.Is(err, state.ErrBlockOverrideTimestamp) ||
+		errors.Is(err, state.ErrBlockOverrideTimestampRange) {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+	} else if err != nil {
+		errorMessage := fmt.Sprintf("failed to get trace: %v", err.Error())
+		return nil, types.NewRPCError(types.DefaultErrorCode, errorMessage)
+	}
+
+	return result.TraceResult, nil
+}
@@ -0,0 +1,68 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceCall(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	to := common.HexToAddress("0x2")
+	txArgs := types.TxArgs{
+		To:   &to,
+		Gas:  types.ArgUint64Ptr(24000),
+		Data: types.ArgBytesPtr([]byte("data")),
+	}
+	overrideTime := uint64(100)
+	traceCfg := map[string]interface{}{
+		"tracer": "callTracer",
+		"stateOverrides": map[string]interface{}{
+			to.String(): map[string]interface{}{"balance": hex.EncodeBig(big.NewInt(100))},
+		},
+		"blockOverrides": map[string]interface{}{"time": hex.EncodeUint64(overrideTime)},
+	}
+	expectedStateOverride := state.StateOverride{to: {Balance: big.NewInt(100)}}
+	defaultSender := common.HexToAddress(state.DefaultSenderAddress)
+	trace := `{"type":"CALL","from":"` + defaultSender.String() + `","to":"` + to.String() + `","gas":"0x5dc0","gasUsed":"0x5208","input":"0x64617461","value":"0x0"}`
+
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, nil).Return(block, nil).Twice()
+	m.State.
+		On("DebugCall", context.Background(), mock.MatchedBy(func(tx *ethTypes.Transaction) bool {
+			return *tx.To() == to && tx.Gas() == 24000 && string(tx.Data()) == "data"
+		}), defaultSender, &blockNumOneUint64, expectedStateOverride, &state.BlockOverrides{Time: &overrideTime}, tracerIs("callTracer"), nil).
+		Return(&runtime.ExecutionResult{TraceResult: json.RawMessage(trace)}, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("debug_traceCall", txArgs, "0x1", traceCfg)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.JSONEq(t, trace, string(res.Result))
+
+	// a block override the state rejects is reported as an invalid param
+	m.State.
+		On("DebugCall", context.Background(), mock.Anything, defaultSender, &blockNumOneUint64, expectedStateOverride, mock.Anything, mock.Anything, nil).
+		Return(nil, fmt.Errorf("%w: 4294967296 seconds after the block timestamp", state.ErrBlockOverrideTimestampRange)).
+		Once()
+
+	res, err = s.JSONRPCCall("debug_traceCall", txArgs, "0x1", traceCfg)
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidParamsErrorCode, res.Error.Code)
+	assert.Contains(t, res.Error.Message, state.ErrBlockOverrideTimestampRange.Error())
+}
@@ -38,6 +38,8 @@ type EthEndpoints struct {
 	etherman types.EthermanInterface
 	storage  storageInterface
 	dgpMan   DynamicGPManager
+	// XLayer preconfirmations
+	preconfirmations *preconfirmations
 }
 
 // NewEthEndpoints creates an new instance of Eth
@@ -50,6 +52,11 @@ func NewEthEndpoints(cfg Config, chainID uint64, p types.PoolInterface, s types.
 		lastPrice: new(big.Int).SetUint64(1), //nolint:gomnd
 	}
 	go e.runDynamicGPSuggester()
+	// XLayer preconfirmations
+	if cfg.PreconfirmationURI != "" {
+		e.preconfirmations = newPreconfirmations(e.notifyPreconfirmation)
+		go e.relayPreconfirmations()
+	}
 	s.RegisterNewL2BlockEventHandler(e.onNewL2Block)
 
 	return e
@@ -70,7 +77,7 @@ func (e *EthEndpoints) BlockNumber() (interface{}, types.Error) {
 // executed contract and potential error.
 // Note, this function doesn't make any changes in the state/blockchain and is
 // useful to execute view/pure methods and retrieve values.
-func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash, stateOverride *types.StateOverride) (interface{}, types.Error) {
 	ctx := context.Background()
 	if arg == nil {
 		return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
@@ -107,8 +114,15 @@ func (e *EthEndpoints) Call(arg *types.TxArgs, blockArg *types.BlockNumberOrHash
 		return RPCErrorResponse(types.DefaultErrorCode, "failed to convert arguments into an unsigned transaction", err, false)
 	}
 
-	result, err := e.state.ProcessUnsignedTransaction(ctx, tx, sender, blockToProcess, true, nil)
-	if err != nil {
+	var result *runtime.ExecutionResult
+	if stateOverride != nil {
+		result, err = e.state.ProcessUnsignedTransactionWithStateOverride(ctx, tx, sender, blockToProcess, true, stateOverride.ToStateOverride(), nil)
+	} else {
+		result, err = e.state.ProcessUnsignedTransaction(ctx, tx, sender, blockToProcess, true, nil)
+	}
+	if errors.Is(err, state.ErrStateAndStateDiffOverride) {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+	} else if err != nil {
 		errMsg := fmt.Sprintf("failed to execute the unsigned transaction: %v", err.Error())
 		logError := !executor.IsROMOutOfCountersError(executor.RomErrorCode(err)) && !errors.Is(err, runtime.ErrOutOfGas)
 		return RPCErrorResponse(types.DefaultErrorCode, errMsg, nil, logError)
@@ -165,7 +179,7 @@ func (e *EthEndpoints) getCoinbaseFromSequencerNode() (interface{}, types.Error)
 // Note that the estimate may be significantly more than the amount of gas actually
 // used by the transaction, for a variety of reasons including EVM mechanics and
 // node performance.
-func (e *EthEndpoints) EstimateGas(arg *types.TxArgs, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+func (e *EthEndpoints) EstimateGas(arg *types.TxArgs, blockArg *types.BlockNumberOrHash, stateOverride *types.StateOverride) (interface{}, types.Error) {
 	ctx := context.Background()
 	if arg == nil {
 		return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
@@ -200,7 +214,9 @@ func (e *EthEndpoints) EstimateGas(arg *types.TxArgs, blockArg *types.BlockNumbe
 	}
 	var gasEstimation uint64
 	var returnValue []byte
-	if e.enableEstimateGasOpt() {
+	if stateOverride != nil {
+		gasEstimation, returnValue, err = e.state.EstimateGasWithStateOverride(tx, sender, isGasFreeSender, blockToProcess, stateOverride.ToStateOverride(), nil)
+	} else if e.enableEstimateGasOpt() {
 		gasEstimation, returnValue, err = e.state.EstimateGasOpt(tx, sender, isGasFreeSender, blockToProcess, nil, e.enableEstimateGasUltraOpt())
 	} else {
 		gasEstimation, returnValue, err = e.state.EstimateGas(tx, sender, isGasFreeSender, blockToProcess, nil)
@@ -305,9 +321,14 @@ func (e *EthEndpoints) GetBalance(address types.ArgAddress, blockArg *types.Bloc
 }
 
 func (e *EthEndpoints) getBlockByArg(ctx context.Context, blockArg *types.BlockNumberOrHash, dbTx pgx.Tx) (*state.L2Block, types.Error) {
+	return getL2BlockByArg(ctx, e.state, e.etherman, blockArg, dbTx)
+}
+
+// getL2BlockByArg resolves the l2 block referenced by a block number or hash argument
+func getL2BlockByArg(ctx context.Context, st types.StateInterface, etherman types.EthermanInterface, blockArg *types.BlockNumberOrHash, dbTx pgx.Tx) (*state.L2Block, types.Error) {
 	// If no block argument is provided, return the latest block
 	if blockArg == nil {
-		block, err := e.state.GetLastL2Block(ctx, dbTx)
+		block, err := st.GetLastL2Block(ctx, dbTx)
 		if err != nil {
 			return nil, types.NewRPCError(types.DefaultErrorCode, "failed to get the last block number from state")
 		}
@@ -316,7 +337,7 @@ func (e *EthEndpoints) getBlockByArg(ctx context.Context, blockArg *types.BlockN
 
 	// If we have a block hash, try to get the block by hash
 	if blockArg.IsHash() {
-		block, err := e.state.GetL2BlockByHash(ctx, blockArg.Hash().Hash(), dbTx)
+		block, err := st.GetL2BlockByHash(ctx, blockArg.Hash().Hash(), dbTx)
 		if errors.Is(err, state.ErrNotFound) {
 			return nil, types.NewRPCError(types.DefaultErrorCode, "header for hash not found")
 		} else if err != nil {
@@ -326,11 +347,11 @@ func (e *EthEndpoints) getBlockByArg(ctx context.Context, blockArg *types.BlockN
 	}
 
 	// Otherwise, try to get the block by number
-	blockNum, rpcErr := blockArg.Number().GetNumericBlockNumber(ctx, e.state, e.etherman, dbTx)
+	blockNum, rpcErr := blockArg.Number().GetNumericBlockNumber(ctx, st, etherman, dbTx)
 	if rpcErr != nil {
 		return nil, rpcErr
 	}
-	block, err := e.state.GetL2BlockByNumber(context.Background(), blockNum, dbTx)
+	block, err := st.GetL2BlockByNumber(context.Background(), blockNum, dbTx)
 	if errors.Is(err, state.ErrNotFound) || block == nil {
 		return nil, types.NewRPCError(types.DefaultErrorCode, "header not found")
 	} else if err != nil {
@@ -1163,9 +1184,15 @@ func (e *EthEndpoints) Subscribe(wsConn *concurrentWsConn, name string, logFilte
 		if logFilter != nil {
 			lf = *logFilter
 		}
+		// XLayer resumable log subscriptions
+		if lf.isResumable() {
+			return e.newResumableLogFilter(ctx, wsConn, lf)
+		}
 		return e.newFilter(ctx, wsConn, lf, nil)
 	case "pendingTransactions", "newPendingTransactions":
 		return e.newPendingTransactionFilter(wsConn)
+	case preconfirmedTransactionsSubscription:
+		return e.newPreconfirmationFilter(wsConn)
 	case "syncing":
 		return nil, types.NewRPCError(types.DefaultErrorCode, "not supported yet")
 	default:
@@ -1196,6 +1223,11 @@ func (e *EthEndpoints) onNewL2Block(event state.NewL2BlockEvent) {
 	wg.Add(1)
 	go e.notifyNewLogs(&wg, event)
 
+	// XLayer preconfirmations
+	if e.preconfirmations != nil {
+		e.preconfirmations.resolve(&event.Block, event.Logs)
+	}
+
 	wg.Wait()
 	log.Debugf("[onNewL2Block] new l2 block %v took %v to send the messages to all ws connections", event.Block.NumberU64(), time.Since(start))
 }
@@ -1254,6 +1286,11 @@ func (e *EthEndpoints) notifyNewLogs(wg *sync.WaitGroup, event state.NewL2BlockE
 			log.Debugf("[notifyNewLogs] took %v to filter logs", time.Since(start))
 
 			start = time.Now()
+			// XLayer resumable log subscriptions
+			if replay := filter.Parameters.(LogFilter).replay; replay != nil {
+				replay.live(f, logs)
+				continue
+			}
 			for _, l := range logs {
 				data, err := json.Marshal(l)
 				if err != nil {
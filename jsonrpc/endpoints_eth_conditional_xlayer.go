@@ -0,0 +1,71 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+)
+
+const (
+	sendRawTransactionConditionalMethod = "eth_sendRawTransactionConditional"
+)
+
+// SendRawTransactionConditional adds a tx to the pool that is only processed by the sequencer if the given
+// known accounts storage slots and block number and timestamp bounds hold in the L2 block that includes it
+func (e *EthEndpoints) SendRawTransactionConditional(httpRequest *http.Request, input string, conditional pool.TxConditional) (interface{}, types.Error) {
+	if err := conditional.Validate(); err != nil {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+	}
+
+	if e.cfg.SequencerNodeURI != "" {
+		return e.relayConditionalTxToSequencerNode(input, conditional)
+	}
+
+	ip := ""
+	if httpRequest != nil {
+		ips := httpRequest.Header.Get("X-Forwarded-For")
+		if ips != "" {
+			ip = strings.Split(ips, ",")[0]
+		}
+	}
+	return e.tryToAddConditionalTxToPool(input, ip, conditional)
+}
+
+func (e *EthEndpoints) relayConditionalTxToSequencerNode(input string, conditional pool.TxConditional) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(e.cfg.SequencerNodeURI, sendRawTransactionConditionalMethod, input, conditional)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to relay conditional tx to the sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	return res.Result, nil
+}
+
+func (e *EthEndpoints) tryToAddConditionalTxToPool(input, ip string, conditional pool.TxConditional) (interface{}, types.Error) {
+	tx, err := hexToTx(input)
+	if err != nil {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, "invalid tx input", err, false)
+	}
+	log.Infof("adding conditional TX to the pool: %v", tx.Hash().Hex())
+
+	dgp := getDynamicGp(e.cfg.DynamicGP.Enabled, e.dgpMan.lastPrice)
+	e.pool.AddDynamicGp(dgp)
+	if err := e.pool.AddConditionalTx(context.Background(), *tx, ip, conditional); err != nil {
+		if errors.Is(err, pool.ErrInvalidTxConditional) {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+		}
+		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
+	}
+	log.Infof("conditional TX added to the pool: %v", tx.Hash().Hex())
+
+	return tx.Hash().Hex(), nil
+}
@@ -0,0 +1,53 @@
+package jsonrpc
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendRawTransactionConditional(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	tx := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{})
+	txBinary, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	rawTx := hex.EncodeToHex(txBinary)
+
+	blockNumberMax := uint64(10)
+	conditional := pool.TxConditional{
+		KnownAccounts: map[common.Address]pool.KnownAccount{
+			common.HexToAddress("0x2"): {StorageSlots: map[common.Hash]common.Hash{{31: 1}: {31: 2}}},
+		},
+		BlockNumberMax: &blockNumberMax,
+	}
+
+	txMatchByHash := mock.MatchedBy(func(t ethTypes.Transaction) bool {
+		return t.Hash() == tx.Hash()
+	})
+	m.Pool.On("AddConditionalTx", context.Background(), txMatchByHash, "", conditional).Return(nil).Once()
+
+	res, err := s.JSONRPCCall("eth_sendRawTransactionConditional", rawTx, conditional)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, `"`+tx.Hash().Hex()+`"`, string(res.Result))
+
+	// Storage root conditions are not supported
+	root := common.Hash{1}
+	res, err = s.JSONRPCCall("eth_sendRawTransactionConditional", rawTx, pool.TxConditional{
+		KnownAccounts: map[common.Address]pool.KnownAccount{common.HexToAddress("0x2"): {StorageRoot: &root}},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidParamsErrorCode, res.Error.Code)
+}
@@ -0,0 +1,71 @@
+package jsonrpc
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+)
+
+const (
+	sendPrivateTransactionMethod = "eth_sendPrivateTransaction"
+)
+
+// SendPrivateTransaction adds a tx to the pool without broadcasting it, the tx is never returned by the
+// pending tx filters and subscriptions nor by the txpool namespace. A valid api key is required
+func (e *EthEndpoints) SendPrivateTransaction(httpRequest *http.Request, input string) (interface{}, types.Error) {
+	if httpRequest == nil {
+		return RPCErrorResponse(types.InvalidRequestErrorCode, "private transactions require a valid api key", nil, false)
+	}
+	key := path.Base(httpRequest.URL.Path)
+	if err := check(key); err != nil {
+		return RPCErrorResponse(types.InvalidRequestErrorCode, "private transactions require a valid api key", nil, false)
+	}
+
+	if e.cfg.SequencerNodeURI != "" {
+		return e.relayPrivateTxToSequencerNode(key, input)
+	}
+
+	ip := ""
+	ips := httpRequest.Header.Get("X-Forwarded-For")
+	if ips != "" {
+		ip = strings.Split(ips, ",")[0]
+	}
+	return e.tryToAddPrivateTxToPool(input, ip)
+}
+
+func (e *EthEndpoints) relayPrivateTxToSequencerNode(key, input string) (interface{}, types.Error) {
+	// The api key is forwarded so the sequencer node can authenticate the private tx too
+	uri := strings.TrimSuffix(e.cfg.SequencerNodeURI, "/") + "/" + key
+	res, err := client.JSONRPCCall(uri, sendPrivateTransactionMethod, input)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to relay private tx to the sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	return res.Result, nil
+}
+
+func (e *EthEndpoints) tryToAddPrivateTxToPool(input, ip string) (interface{}, types.Error) {
+	tx, err := hexToTx(input)
+	if err != nil {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, "invalid tx input", err, false)
+	}
+	log.Infof("adding private TX to the pool: %v", tx.Hash().Hex())
+
+	dgp := getDynamicGp(e.cfg.DynamicGP.Enabled, e.dgpMan.lastPrice)
+	e.pool.AddDynamicGp(dgp)
+	if err := e.pool.AddPrivateTx(context.Background(), *tx, ip); err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
+	}
+	log.Infof("private TX added to the pool: %v", tx.Hash().Hex())
+
+	return tx.Hash().Hex(), nil
+}
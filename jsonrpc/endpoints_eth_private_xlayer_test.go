@@ -0,0 +1,51 @@
+package jsonrpc
+
+import (
+	"context"
+	"crypto/md5"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendPrivateTransaction(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	project := "private"
+	timeout := time.Now().Add(24 * time.Hour).Format("2006-01-02")
+	key := fmt.Sprintf("%x", md5.Sum([]byte(project+timeout)))
+	setApiAuth(ApiAuthConfig{ApiKeys: []KeyItem{{Project: project, Key: key, Timeout: timeout}}})
+	defer setApiAuth(ApiAuthConfig{})
+
+	tx := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(1), big.NewInt(1), []byte{})
+	txBinary, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	rawTx := hex.EncodeToHex(txBinary)
+
+	// Without an api key the tx is rejected
+	res, err := s.JSONRPCCall("eth_sendPrivateTransaction", rawTx)
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidRequestErrorCode, res.Error.Code)
+
+	txMatchByHash := mock.MatchedBy(func(t ethTypes.Transaction) bool {
+		return t.Hash() == tx.Hash()
+	})
+	m.Pool.On("AddPrivateTx", context.Background(), txMatchByHash, "").Return(nil).Once()
+
+	res, err = client.JSONRPCCall(s.ServerURL+"/"+key, "eth_sendPrivateTransaction", rawTx)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, `"`+tx.Hash().Hex()+`"`, string(res.Result))
+}
@@ -11,6 +11,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/gasprice"
 	"github.com/0xPolygonHermez/zkevm-node/hex"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/metrics"
@@ -449,3 +450,140 @@ func getDynamicGp(enableDgp bool, dgp *big.Int) *big.Int {
 	}
 	return dgp
 }
+
+const (
+	// maxFeeHistoryBlockCount is the max number of blocks that can be requested in a single eth_feeHistory call
+	maxFeeHistoryBlockCount = 1024
+	// maxFeeHistoryRewardPercentiles is the max number of reward percentiles that can be requested
+	maxFeeHistoryRewardPercentiles = 100
+)
+
+// FeeHistory returns the gas used ratio and the effective gas price percentiles
+// for a range of l2 blocks ending at newestBlock
+func (e *EthEndpoints) FeeHistory(blockCount types.ArgUint64, newestBlock types.BlockNumber, rewardPercentiles []float64) (interface{}, types.Error) {
+	if e.isDisabled("eth_feeHistory") {
+		return RPCErrorResponse(types.DefaultErrorCode, "not supported yet", nil, true)
+	}
+	if len(rewardPercentiles) > maxFeeHistoryRewardPercentiles {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("too many reward percentiles, max is %d", maxFeeHistoryRewardPercentiles), nil, false)
+	}
+	for i, p := range rewardPercentiles {
+		if p < 0 || p > 100 { // nolint:gomnd
+			return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("invalid reward percentile: %f", p), nil, false)
+		}
+		if i > 0 && p < rewardPercentiles[i-1] {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("invalid reward percentile: #%d:%f > #%d:%f", i-1, rewardPercentiles[i-1], i, p), nil, false)
+		}
+	}
+	if blockCount == 0 {
+		return types.FeeHistory{GasUsedRatio: []float64{}}, nil
+	}
+	if blockCount > maxFeeHistoryBlockCount {
+		blockCount = maxFeeHistoryBlockCount
+	}
+
+	ctx := context.Background()
+	newestBlockNumber, rpcErr := newestBlock.GetNumericBlockNumber(ctx, e.state, e.etherman, nil)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	if uint64(blockCount) > newestBlockNumber+1 {
+		blockCount = types.ArgUint64(newestBlockNumber + 1)
+	}
+	oldestBlockNumber := newestBlockNumber + 1 - uint64(blockCount)
+
+	result := types.FeeHistory{
+		OldestBlock:   types.ArgUint64(oldestBlockNumber),
+		BaseFeePerGas: make([]types.ArgBig, blockCount+1),
+		GasUsedRatio:  make([]float64, blockCount),
+	}
+	if len(rewardPercentiles) > 0 {
+		result.Reward = make([][]types.ArgBig, blockCount)
+	}
+
+	for i := uint64(0); i < uint64(blockCount); i++ {
+		l2Block, err := e.state.GetL2BlockByNumber(ctx, oldestBlockNumber+i, nil)
+		if errors.Is(err, state.ErrNotFound) {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("block %d not found", oldestBlockNumber+i), nil, false)
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load block from state by number %v", oldestBlockNumber+i), err, true)
+		}
+
+		// the base fee stored in the l2 block header is reported as is, the rewards
+		// are computed from the effective gas price of the receipts
+		if l2Block.BaseFee() != nil {
+			result.BaseFeePerGas[i] = types.ArgBig(*l2Block.BaseFee())
+		}
+		if l2Block.GasLimit() > 0 {
+			result.GasUsedRatio[i] = float64(l2Block.GasUsed()) / float64(l2Block.GasLimit())
+		}
+		if len(rewardPercentiles) == 0 {
+			continue
+		}
+
+		receipts, err := e.state.GetReceiptsByL2BlockNumber(ctx, l2Block.NumberU64(), nil)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load receipts for block %v", l2Block.NumberU64()), err, true)
+		}
+		tips := make([]gasprice.TxTip, 0, len(receipts))
+		for _, r := range receipts {
+			tip := r.Receipt.EffectiveGasPrice
+			if tip == nil {
+				tip = r.Tx.GasPrice()
+			}
+			tips = append(tips, gasprice.TxTip{Tip: tip, GasUsed: r.Receipt.GasUsed})
+		}
+
+		rewards := gasprice.RewardPercentiles(tips, l2Block.GasUsed(), rewardPercentiles)
+		result.Reward[i] = make([]types.ArgBig, len(rewards))
+		for j, reward := range rewards {
+			result.Reward[i][j] = types.ArgBig(*reward)
+		}
+	}
+	result.BaseFeePerGas[blockCount] = result.BaseFeePerGas[blockCount-1]
+
+	return result, nil
+}
+
+// MaxPriorityFeePerGas returns the suggested priority fee per gas. As zkEVM
+// doesn't charge a base fee, the whole suggested gas price is the priority fee
+func (e *EthEndpoints) MaxPriorityFeePerGas() (interface{}, types.Error) {
+	if e.isDisabled("eth_maxPriorityFeePerGas") {
+		return RPCErrorResponse(types.DefaultErrorCode, "not supported yet", nil, true)
+	}
+	return e.GasPrice()
+}
+
+// GetBlockReceipts returns the receipts of all the txs of a block, all the receipts and
+// their logs are loaded from the state at once
+func (e *EthEndpoints) GetBlockReceipts(blockArg types.BlockNumberOrHash) (interface{}, types.Error) {
+	ctx := context.Background()
+	block, respErr := e.getBlockByArg(ctx, &blockArg, nil)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	receipts, err := e.state.GetReceiptsByL2BlockNumber(ctx, block.NumberU64(), nil)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load receipts for block %v", block.NumberU64()), err, true)
+	}
+
+	return newReceipts(receipts, false)
+}
+
+// newReceipts builds the receipts response of a list of txs, the l2 hash of the txs is only included when requested
+func newReceipts(receipts []state.TransactionReceipt, includeL2Hash bool) (interface{}, types.Error) {
+	res := make([]types.Receipt, 0, len(receipts))
+	for _, r := range receipts {
+		var l2Hash *common.Hash
+		if includeL2Hash {
+			l2Hash = r.L2Hash
+		}
+		receipt, err := types.NewReceipt(r.Tx, r.Receipt, l2Hash)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to build the receipt response", err, true)
+		}
+		res = append(res, receipt)
+	}
+	return res, nil
+}
@@ -0,0 +1,150 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeHistory(t *testing.T) {
+	s, m, c := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	txs := []*ethTypes.Transaction{
+		ethTypes.NewTx(&ethTypes.LegacyTx{Nonce: 1, GasPrice: big.NewInt(10), Gas: 21000, To: state.Ptr(common.HexToAddress("0x1"))}),
+		ethTypes.NewTx(&ethTypes.LegacyTx{Nonce: 2, GasPrice: big.NewInt(20), Gas: 21000, To: state.Ptr(common.HexToAddress("0x1"))}),
+	}
+	receipts := []*ethTypes.Receipt{
+		{TxHash: txs[0].Hash(), GasUsed: 21000, EffectiveGasPrice: big.NewInt(5)},
+		{TxHash: txs[1].Hash(), GasUsed: 21000, EffectiveGasPrice: big.NewInt(20)},
+	}
+
+	emptyBlock := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(4), GasLimit: 84000}))
+	fullBlock := state.NewL2Block(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(5), GasLimit: 84000, GasUsed: 42000}), txs, nil, receipts, trie.NewStackTrie(nil))
+
+	m.State.On("GetL2BlockByNumber", context.Background(), uint64(4), nil).Return(emptyBlock, nil).Once()
+	m.State.On("GetL2BlockByNumber", context.Background(), uint64(5), nil).Return(fullBlock, nil).Once()
+	m.State.On("GetReceiptsByL2BlockNumber", context.Background(), uint64(4), nil).Return([]state.TransactionReceipt{}, nil).Once()
+	m.State.On("GetReceiptsByL2BlockNumber", context.Background(), uint64(5), nil).Return([]state.TransactionReceipt{
+		{Tx: *txs[0], Receipt: receipts[0]},
+		{Tx: *txs[1], Receipt: receipts[1]},
+	}, nil).Once()
+
+	feeHistory, err := c.FeeHistory(context.Background(), 2, big.NewInt(5), []float64{25, 75})
+	require.NoError(t, err)
+
+	assert.Equal(t, big.NewInt(4), feeHistory.OldestBlock)
+	assert.Equal(t, []float64{0, 0.5}, feeHistory.GasUsedRatio)
+	assert.Equal(t, 3, len(feeHistory.BaseFee))
+	require.Equal(t, 2, len(feeHistory.Reward))
+	assert.Equal(t, []*big.Int{big.NewInt(0), big.NewInt(0)}, feeHistory.Reward[0])
+	assert.Equal(t, []*big.Int{big.NewInt(5), big.NewInt(20)}, feeHistory.Reward[1])
+}
+
+func TestCallWithStateOverride(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	from := common.HexToAddress("0x1")
+	txArgs := types.TxArgs{
+		From: &from,
+		To:   state.HexToAddressPtr("0x2"),
+		Gas:  types.ArgUint64Ptr(24000),
+		Data: types.ArgBytesPtr([]byte("data")),
+	}
+	overrideNonce := uint64(3)
+	stateOverride := map[string]interface{}{
+		from.String(): map[string]interface{}{
+			"nonce":   hex.EncodeUint64(overrideNonce),
+			"balance": hex.EncodeBig(big.NewInt(100)),
+		},
+	}
+	expectedStateOverride := state.StateOverride{
+		from: {Nonce: &overrideNonce, Balance: big.NewInt(100)},
+	}
+
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
+	m.State.On("GetLastL2BlockNumber", context.Background(), nil).Return(blockNumOne.Uint64(), nil).Once()
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, nil).Return(block, nil).Once()
+	m.State.On("GetNonce", context.Background(), from, blockRoot).Return(uint64(0), nil).Once()
+	m.State.
+		On("ProcessUnsignedTransactionWithStateOverride", context.Background(), mock.AnythingOfType("*types.Transaction"), from, nilUint64, true, expectedStateOverride, nil).
+		Return(&runtime.ExecutionResult{ReturnValue: []byte("hello world")}, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("eth_call", txArgs, latest, stateOverride)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result types.ArgBytes
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	assert.Equal(t, []byte("hello world"), []byte(result))
+}
+
+func newTestTransactionReceipts(t *testing.T, blockNumber uint64) []state.TransactionReceipt {
+	privateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, big.NewInt(1))
+	require.NoError(t, err)
+
+	blockHash := common.HexToHash("0xb10c")
+	receipts := make([]state.TransactionReceipt, 0, 2)
+	for i := uint64(0); i < 2; i++ {
+		tx, err := auth.Signer(auth.From, ethTypes.NewTransaction(i, common.HexToAddress("0x111"), big.NewInt(2), 21000, big.NewInt(4), nil))
+		require.NoError(t, err)
+		l2Hash := common.BigToHash(big.NewInt(int64(i + 1)))
+		receipt := &ethTypes.Receipt{
+			Status:            ethTypes.ReceiptStatusSuccessful,
+			CumulativeGasUsed: 21000 * (i + 1),
+			GasUsed:           21000,
+			TxHash:            tx.Hash(),
+			BlockHash:         blockHash,
+			BlockNumber:       new(big.Int).SetUint64(blockNumber),
+			TransactionIndex:  uint(i),
+			Logs: []*ethTypes.Log{
+				{TxHash: tx.Hash(), TxIndex: uint(i), BlockHash: blockHash, BlockNumber: blockNumber, Index: uint(i), Topics: []common.Hash{common.HexToHash("0x1")}},
+			},
+		}
+		receipts = append(receipts, state.TransactionReceipt{Tx: *tx, Receipt: receipt, L2Hash: &l2Hash})
+	}
+	return receipts
+}
+
+func TestGetBlockReceipts(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	receipts := newTestTransactionReceipts(t, blockNumOne.Uint64())
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, nil).Return(block, nil).Once()
+	m.State.On("GetReceiptsByL2BlockNumber", context.Background(), blockNumOneUint64, nil).Return(receipts, nil).Once()
+
+	res, err := s.JSONRPCCall("eth_getBlockReceipts", hex.EncodeUint64(blockNumOneUint64))
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result []types.Receipt
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	require.Len(t, result, len(receipts))
+	for i, r := range result {
+		assert.Equal(t, receipts[i].Tx.Hash(), r.TxHash)
+		assert.Equal(t, types.ArgUint64(i), r.TxIndex)
+		assert.Equal(t, types.ArgUint64(blockNumOneUint64), r.BlockNumber)
+		require.Len(t, r.Logs, 1)
+		assert.Nil(t, r.TxL2Hash)
+	}
+}
@@ -0,0 +1,434 @@
+package jsonrpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// APIOts represents the otterscan API prefix.
+	APIOts = "ots"
+
+	// otsAPILevel is the version of the otterscan API supported by the endpoints
+	otsAPILevel = 8
+
+	// maxOtsSearchPageSize is the max number of txs that can be requested in a page of ots_searchTransactions*
+	// and ots_getBlockTransactions
+	maxOtsSearchPageSize = 100
+
+	// methodSelectorLength is the length of the input kept for the txs returned by ots_getBlockTransactions
+	methodSelectorLength = 4
+)
+
+// OtsEndpoints contains implementations for the "ots" RPC endpoints used by the otterscan block explorer
+type OtsEndpoints struct {
+	cfg      Config
+	state    types.StateInterface
+	etherman types.EthermanInterface
+	eth      *EthEndpoints
+}
+
+// NewOtsEndpoints returns OtsEndpoints, the internal txs are loaded through
+// the eth endpoints to use the inner txs cache
+func NewOtsEndpoints(cfg Config, state types.StateInterface, etherman types.EthermanInterface, eth *EthEndpoints) *OtsEndpoints {
+	return &OtsEndpoints{
+		cfg:      cfg,
+		state:    state,
+		etherman: etherman,
+		eth:      eth,
+	}
+}
+
+// GetApiLevel returns the version of the otterscan API supported by the node
+func (o *OtsEndpoints) GetApiLevel() (interface{}, types.Error) { //nolint:revive,stylecheck
+	return otsAPILevel, nil
+}
+
+// HasCode checks if there is code deployed in an address
+func (o *OtsEndpoints) HasCode(address types.ArgAddress, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	ctx := context.Background()
+	block, rpcErr := getL2BlockByArg(ctx, o.state, o.etherman, blockArg, nil)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	code, err := o.state.GetCode(ctx, address.Address(), block.Root())
+	if errors.Is(err, state.ErrNotFound) {
+		return false, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get code", err, true)
+	}
+
+	return len(code) > 0, nil
+}
+
+// GetInternalOperations returns the operations of a tx that moved value between
+// accounts, other than the tx itself: transfers, contract deployments and self destructs
+func (o *OtsEndpoints) GetInternalOperations(hash types.ArgHash) (interface{}, types.Error) {
+	innerTxs, rpcErr := o.innerTxs(hash)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	operations := []types.OtsInternalOperation{}
+	for _, innerTx := range innerTxs {
+		if innerTx.Dept.Sign() == 0 || innerTx.IsError {
+			continue
+		}
+		value := hex.DecodeBig(innerTx.CallValueWei)
+		operation := types.OtsInternalOperation{
+			From:  common.HexToAddress(innerTx.From),
+			To:    common.HexToAddress(innerTx.To),
+			Value: types.ArgBig(*value),
+		}
+		switch innerTx.CallType {
+		case "call", "callcode":
+			if value.Sign() == 0 {
+				continue
+			}
+			operation.Type = types.OtsOperationTransfer
+		case "create":
+			operation.Type = types.OtsOperationCreate
+		case "create2":
+			operation.Type = types.OtsOperationCreate2
+		case "selfdestruct":
+			operation.Type = types.OtsOperationSelfDestruct
+		default:
+			continue
+		}
+		operations = append(operations, operation)
+	}
+
+	return operations, nil
+}
+
+// TraceTransaction returns all the call frames of a tx in execution order
+func (o *OtsEndpoints) TraceTransaction(hash types.ArgHash) (interface{}, types.Error) {
+	innerTxs, rpcErr := o.innerTxs(hash)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	entries := make([]types.OtsTraceEntry, 0, len(innerTxs))
+	for _, innerTx := range innerTxs {
+		entry := types.OtsTraceEntry{
+			Type:  strings.ToUpper(innerTx.CallType),
+			Depth: int(innerTx.Dept.Int64()),
+			From:  common.HexToAddress(innerTx.From),
+			To:    common.HexToAddress(innerTx.To),
+		}
+		// Delegate and static calls don't transfer value
+		if innerTx.CallType != "delegatecall" && innerTx.CallType != "staticcall" {
+			entry.Value = (*types.ArgBig)(hex.DecodeBig(innerTx.CallValueWei))
+		}
+		var err error
+		entry.Input, err = decodeInnerTxData(innerTx.Input)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to decode the input of the trace", err, true)
+		}
+		entry.Output, err = decodeInnerTxData(innerTx.Output)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to decode the output of the trace", err, true)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// GetTransactionError returns the revert data of a failed tx, or empty data if the tx didn't fail
+func (o *OtsEndpoints) GetTransactionError(hash types.ArgHash) (interface{}, types.Error) {
+	innerTxs, rpcErr := o.innerTxs(hash)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	if len(innerTxs) == 0 || !innerTxs[0].IsError {
+		return types.ArgBytes{}, nil
+	}
+	output, err := decodeInnerTxData(innerTxs[0].Output)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to decode the output of the trace", err, true)
+	}
+	return output, nil
+}
+
+// SearchTransactionsBefore returns a page of txs sent from or to an address in the blocks before the given one,
+// from the newest to the oldest. The block number 0 starts the search from the latest block. A page can have
+// more than pageSize txs since the txs of a block are never split across pages. The search stops at the first
+// block indexed by address, a page cut by it is not the last page when there are older blocks not indexed
+func (o *OtsEndpoints) SearchTransactionsBefore(address types.ArgAddress, blockNumber uint64, pageSize uint64) (interface{}, types.Error) {
+	ctx := context.Background()
+	if rpcErr := checkOtsPageSize(pageSize); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	firstPage := blockNumber == 0
+	if firstPage {
+		lastBlockNumber, err := o.state.GetLastL2BlockNumber(ctx, nil)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to get the last block number from state", err, true)
+		}
+		blockNumber = lastBlockNumber + 1
+	}
+	firstIndexedBlockNumber, rpcErr := o.checkAddressIndexed(ctx, blockNumber-1)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	receipts, err := o.state.GetReceiptsByAddressBefore(ctx, address.Address(), blockNumber, pageSize, nil)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load txs of address %v", address.Address().String()), err, true)
+	}
+
+	// A short page is the last one when all the blocks with txs are indexed, otherwise it's cut by the first block indexed
+	lastPage := uint64(len(receipts)) < pageSize && firstIndexedBlockNumber <= 1
+	return newOtsSearchResult(receipts, firstPage, lastPage)
+}
+
+// SearchTransactionsAfter returns a page of txs sent from or to an address in the blocks after the given one,
+// from the newest to the oldest. The block number 0 starts the search from the genesis block. A page can have
+// more than pageSize txs since the txs of a block are never split across pages
+func (o *OtsEndpoints) SearchTransactionsAfter(address types.ArgAddress, blockNumber uint64, pageSize uint64) (interface{}, types.Error) {
+	ctx := context.Background()
+	if rpcErr := checkOtsPageSize(pageSize); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	if _, rpcErr := o.checkAddressIndexed(ctx, blockNumber+1); rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	receipts, err := o.state.GetReceiptsByAddressAfter(ctx, address.Address(), blockNumber, pageSize, nil)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load txs of address %v", address.Address().String()), err, true)
+	}
+
+	return newOtsSearchResult(receipts, uint64(len(receipts)) < pageSize, blockNumber == 0)
+}
+
+// GetBlockDetails returns a block without its txs along with the fees paid by them
+func (o *OtsEndpoints) GetBlockDetails(number types.BlockNumber) (interface{}, types.Error) {
+	ctx := context.Background()
+	blockNumber, rpcErr := number.GetNumericBlockNumber(ctx, o.state, o.etherman, nil)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	block, err := o.state.GetL2BlockByNumber(ctx, blockNumber, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load block from state by number %v", blockNumber), err, true)
+	}
+
+	receipts, err := o.state.GetReceiptsByL2BlockNumber(ctx, blockNumber, nil)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load receipts for block %v", blockNumber), err, true)
+	}
+	totalFees := big.NewInt(0)
+	for _, r := range receipts {
+		gasPrice := r.Tx.GasPrice()
+		if r.Receipt.EffectiveGasPrice != nil {
+			gasPrice = r.Receipt.EffectiveGasPrice
+		}
+		fee := new(big.Int).Mul(new(big.Int).SetUint64(r.Receipt.GasUsed), gasPrice)
+		totalFees.Add(totalFees, fee)
+	}
+
+	rpcBlock, err := types.NewBlock(ctx, o.state, state.Ptr(block.Hash()), block, nil, false, false, nil, nil)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't build block response for block by number %v", blockNumber), err, true)
+	}
+	rpcBlock.Transactions = nil
+
+	return types.OtsBlockDetails{
+		Block: types.OtsBlock{
+			Block:            rpcBlock,
+			TransactionCount: types.ArgUint64(len(block.Transactions())),
+		},
+		TotalFees: types.ArgBig(*totalFees),
+	}, nil
+}
+
+// GetBlockTransactions returns a page of the txs of a block along with their receipts, the first page holds the
+// last txs of the block. The input of the txs is cut to the method selector and the logs are left out of the receipts
+func (o *OtsEndpoints) GetBlockTransactions(number types.BlockNumber, pageNumber uint64, pageSize uint64) (interface{}, types.Error) {
+	ctx := context.Background()
+	if rpcErr := checkOtsPageSize(pageSize); rpcErr != nil {
+		return nil, rpcErr
+	}
+	blockNumber, rpcErr := number.GetNumericBlockNumber(ctx, o.state, o.etherman, nil)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	block, err := o.state.GetL2BlockByNumber(ctx, blockNumber, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load block from state by number %v", blockNumber), err, true)
+	}
+
+	receipts, err := o.state.GetReceiptsByL2BlockNumber(ctx, blockNumber, nil)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load receipts for block %v", blockNumber), err, true)
+	}
+
+	rpcBlock, err := types.NewBlock(ctx, o.state, state.Ptr(block.Hash()), block, nil, false, false, nil, nil)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't build block response for block by number %v", blockNumber), err, true)
+	}
+
+	pageEnd := uint64(len(receipts))
+	if pageNumber*pageSize < pageEnd {
+		pageEnd -= pageNumber * pageSize
+	} else {
+		pageEnd = 0
+	}
+	pageStart := uint64(0)
+	if pageEnd > pageSize {
+		pageStart = pageEnd - pageSize
+	}
+
+	rpcBlock.Transactions = make([]types.TransactionOrHash, 0, pageEnd-pageStart)
+	res := types.OtsBlockTransactions{
+		Receipts: make([]types.Receipt, 0, pageEnd-pageStart),
+	}
+	for _, r := range receipts[pageStart:pageEnd] {
+		tx, err := types.NewTransaction(r.Tx, r.Receipt, false, nil)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to build the transaction response", err, true)
+		}
+		if len(tx.Input) > methodSelectorLength {
+			tx.Input = tx.Input[:methodSelectorLength]
+		}
+		receipt, err := types.NewReceipt(r.Tx, r.Receipt, nil)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to build the receipt response", err, true)
+		}
+		receipt.Logs = nil
+		rpcBlock.Transactions = append(rpcBlock.Transactions, types.TransactionOrHash{Tx: tx})
+		res.Receipts = append(res.Receipts, receipt)
+	}
+	res.FullBlock = types.OtsBlock{
+		Block:            rpcBlock,
+		TransactionCount: types.ArgUint64(len(block.Transactions())),
+	}
+
+	return res, nil
+}
+
+// GetTransactionBySenderAndNonce returns the hash of the tx sent by an address with a given nonce
+func (o *OtsEndpoints) GetTransactionBySenderAndNonce(address types.ArgAddress, nonce types.ArgUint64) (interface{}, types.Error) {
+	ctx := context.Background()
+	tx, err := o.state.GetTransactionBySenderAndNonce(ctx, address.Address(), uint64(nonce), nil)
+	if errors.Is(err, state.ErrNotFound) {
+		// The tx may have been sent in a l2 block that isn't indexed by address
+		if _, rpcErr := o.checkAddressIndexed(ctx, 0); rpcErr != nil {
+			return nil, rpcErr
+		}
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load the tx of address %v with nonce %v", address.Address().String(), uint64(nonce)), err, true)
+	}
+
+	return tx.Hash(), nil
+}
+
+// GetContractCreator returns the tx that deployed a contract and its sender, the
+// contracts deployed by other contracts are not found
+func (o *OtsEndpoints) GetContractCreator(address types.ArgAddress) (interface{}, types.Error) {
+	ctx := context.Background()
+	receipt, err := o.state.GetContractCreationReceipt(ctx, address.Address(), nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load the creation tx of contract %v", address.Address().String()), err, true)
+	}
+
+	creator, err := state.GetSender(receipt.Tx)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get the sender of the creation tx", err, true)
+	}
+
+	return types.OtsContractCreator{
+		Hash:    receipt.Tx.Hash(),
+		Creator: creator,
+	}, nil
+}
+
+// innerTxs returns the call frames of a tx built by eth_getInternalTransactions
+func (o *OtsEndpoints) innerTxs(hash types.ArgHash) ([]*InnerTx, types.Error) {
+	res, rpcErr := o.eth.GetInternalTransactions(hash)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	innerTxs, ok := res.([]*InnerTx)
+	if !ok {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "failed to get the internal transactions")
+	}
+	return innerTxs, nil
+}
+
+// checkAddressIndexed fails if the txs of a l2 block can't be found by address, since the l2 blocks
+// stored before the address index was created are not indexed. The first block indexed is returned
+func (o *OtsEndpoints) checkAddressIndexed(ctx context.Context, blockNumber uint64) (uint64, types.Error) {
+	firstBlockNumber, err := o.state.GetFirstAddressIndexedL2BlockNumber(ctx, nil)
+	if err != nil {
+		_, rpcErr := RPCErrorResponse(types.DefaultErrorCode, "failed to get the first block indexed by address", err, true)
+		return 0, rpcErr
+	}
+	if blockNumber < firstBlockNumber {
+		_, rpcErr := RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("the txs are indexed by address from block %v", firstBlockNumber), nil, false)
+		return 0, rpcErr
+	}
+	return firstBlockNumber, nil
+}
+
+func decodeInnerTxData(data string) (types.ArgBytes, error) {
+	if data == "" {
+		return types.ArgBytes{}, nil
+	}
+	return hex.DecodeHex(data)
+}
+
+func checkOtsPageSize(pageSize uint64) types.Error {
+	if pageSize == 0 || pageSize > maxOtsSearchPageSize {
+		_, rpcErr := RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("page size must be between 1 and %v", maxOtsSearchPageSize), nil, false)
+		return rpcErr
+	}
+	return nil
+}
+
+// newOtsSearchResult builds a page of txs of an address from receipts sorted from the oldest to the newest
+func newOtsSearchResult(receipts []state.TransactionReceipt, firstPage, lastPage bool) (interface{}, types.Error) {
+	res := types.OtsSearchResult{
+		Txs:       make([]*types.Transaction, 0, len(receipts)),
+		Receipts:  make([]types.OtsReceipt, 0, len(receipts)),
+		FirstPage: firstPage,
+		LastPage:  lastPage,
+	}
+	for i := len(receipts) - 1; i >= 0; i-- {
+		r := receipts[i]
+		tx, err := types.NewTransaction(r.Tx, r.Receipt, false, nil)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to build the transaction response", err, true)
+		}
+		receipt, err := types.NewReceipt(r.Tx, r.Receipt, nil)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to build the receipt response", err, true)
+		}
+		res.Txs = append(res.Txs, tx)
+		res.Receipts = append(res.Receipts, types.OtsReceipt{Receipt: receipt, Timestamp: types.ArgUint64(r.BlockTime)})
+	}
+	return res, nil
+}
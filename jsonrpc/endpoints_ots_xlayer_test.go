@@ -0,0 +1,306 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOtsGetApiLevel(t *testing.T) {
+	s, _, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	res, err := s.JSONRPCCall("ots_getApiLevel")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, "8", string(res.Result))
+}
+
+func TestOtsHasCode(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, nil).Return(block, nil).Twice()
+	m.State.On("GetCode", context.Background(), common.HexToAddress("0x1"), blockRoot).Return([]byte{0x60, 0x00}, nil).Once()
+	m.State.On("GetCode", context.Background(), common.HexToAddress("0x2"), blockRoot).Return(nil, state.ErrNotFound).Once()
+
+	res, err := s.JSONRPCCall("ots_hasCode", "0x1", "0x1")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, "true", string(res.Result))
+
+	res, err = s.JSONRPCCall("ots_hasCode", "0x2", "0x1")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, "false", string(res.Result))
+}
+
+// TestOtsInternalTxs covers all the methods built on the internal txs in a single server, since the
+// endpoint used to trace the internal txs is created once with the state of the first caller
+func TestOtsInternalTxs(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	okHash := common.HexToHash("0x1")
+	okTrace := `{"type":"CALL","from":"0x0000000000000000000000000000000000000001","to":"0x0000000000000000000000000000000000000002","value":"0x0","gas":"0x10000","gasUsed":"0x8000","input":"0x1234","output":"0x",
+		"calls":[
+			{"type":"CALL","from":"0x0000000000000000000000000000000000000002","to":"0x0000000000000000000000000000000000000003","value":"0x5","gas":"0x100","gasUsed":"0x0","input":"0x"},
+			{"type":"CREATE2","from":"0x0000000000000000000000000000000000000002","to":"0x0000000000000000000000000000000000000004","value":"0x0","gas":"0x100","gasUsed":"0x50","input":"0x6000","output":"0x"},
+			{"type":"STATICCALL","from":"0x0000000000000000000000000000000000000002","to":"0x0000000000000000000000000000000000000005","gas":"0x100","gasUsed":"0x10","input":"0xabcd","output":"0x01"}
+		]}`
+	revertedHash := common.HexToHash("0x2")
+	revertedTrace := `{"type":"CALL","from":"0x0000000000000000000000000000000000000001","to":"0x0000000000000000000000000000000000000002","value":"0x0","gas":"0x10000","gasUsed":"0x8000","input":"0x","output":"0x08c379a0","error":"execution reverted"}`
+
+	m.State.On("DebugTransaction", context.Background(), okHash, mock.Anything, nil).
+		Return(&runtime.ExecutionResult{TraceResult: json.RawMessage(okTrace)}, nil)
+	m.State.On("DebugTransaction", context.Background(), revertedHash, mock.Anything, nil).
+		Return(&runtime.ExecutionResult{TraceResult: json.RawMessage(revertedTrace)}, nil)
+
+	res, err := s.JSONRPCCall("ots_traceTransaction", okHash.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.JSONEq(t, `[
+		{"type":"CALL","depth":0,"from":"0x0000000000000000000000000000000000000001","to":"0x0000000000000000000000000000000000000002","value":"0x0","input":"0x1234","output":"0x"},
+		{"type":"CALL","depth":1,"from":"0x0000000000000000000000000000000000000002","to":"0x0000000000000000000000000000000000000003","value":"0x5","input":"0x","output":"0x"},
+		{"type":"CREATE2","depth":1,"from":"0x0000000000000000000000000000000000000002","to":"0x0000000000000000000000000000000000000004","value":"0x0","input":"0x6000","output":"0x"},
+		{"type":"STATICCALL","depth":1,"from":"0x0000000000000000000000000000000000000002","to":"0x0000000000000000000000000000000000000005","value":null,"input":"0xabcd","output":"0x01"}
+	]`, string(res.Result))
+
+	res, err = s.JSONRPCCall("ots_getInternalOperations", okHash.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.JSONEq(t, `[
+		{"type":0,"from":"0x0000000000000000000000000000000000000002","to":"0x0000000000000000000000000000000000000003","value":"0x5"},
+		{"type":3,"from":"0x0000000000000000000000000000000000000002","to":"0x0000000000000000000000000000000000000004","value":"0x0"}
+	]`, string(res.Result))
+
+	res, err = s.JSONRPCCall("ots_getTransactionError", okHash.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, `"0x"`, string(res.Result))
+
+	res, err = s.JSONRPCCall("ots_getTransactionError", revertedHash.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, `"0x08c379a0"`, string(res.Result))
+}
+
+func TestOtsSearchTransactionsBefore(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	address := common.HexToAddress("0x111")
+	receipts := newTestTransactionReceipts(t, 5)
+	for i := range receipts {
+		receipts[i].BlockTime = 1000
+	}
+	m.State.On("GetFirstAddressIndexedL2BlockNumber", context.Background(), nil).Return(uint64(3), nil).Times(3)
+	m.State.On("GetLastL2BlockNumber", context.Background(), nil).Return(uint64(10), nil).Once()
+	m.State.On("GetReceiptsByAddressBefore", context.Background(), address, uint64(11), uint64(2), nil).Return(receipts, nil).Once()
+
+	res, err := s.JSONRPCCall("ots_searchTransactionsBefore", address.String(), 0, 2)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result struct {
+		Txs       []types.Transaction `json:"txs"`
+		Receipts  []types.OtsReceipt  `json:"receipts"`
+		FirstPage bool                `json:"firstPage"`
+		LastPage  bool                `json:"lastPage"`
+	}
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	require.Len(t, result.Txs, 2)
+	require.Len(t, result.Receipts, 2)
+	// The txs are returned from the newest to the oldest
+	assert.Equal(t, receipts[1].Tx.Hash(), result.Txs[0].Hash)
+	assert.Equal(t, receipts[0].Tx.Hash(), result.Txs[1].Hash)
+	assert.Equal(t, receipts[1].Tx.Hash(), result.Receipts[0].TxHash)
+	assert.Equal(t, types.ArgUint64(1000), result.Receipts[0].Timestamp)
+	assert.True(t, result.FirstPage)
+	assert.False(t, result.LastPage)
+
+	// the page crossing the first block indexed is not the last one, the older blocks are not indexed
+	m.State.On("GetReceiptsByAddressBefore", context.Background(), address, uint64(5), uint64(2), nil).Return(receipts[4:], nil).Once()
+
+	res, err = s.JSONRPCCall("ots_searchTransactionsBefore", address.String(), 5, 2)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	require.Len(t, result.Txs, 1)
+	assert.False(t, result.FirstPage)
+	assert.False(t, result.LastPage)
+
+	// the blocks stored before the address index was created can't be searched
+	res, err = s.JSONRPCCall("ots_searchTransactionsBefore", address.String(), 3, 2)
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, "the txs are indexed by address from block 3", res.Error.Message)
+
+	// when all the blocks are indexed a short page is the last one
+	m.State.On("GetFirstAddressIndexedL2BlockNumber", context.Background(), nil).Return(uint64(0), nil).Once()
+	m.State.On("GetReceiptsByAddressBefore", context.Background(), address, uint64(3), uint64(2), nil).Return([]state.TransactionReceipt{}, nil).Once()
+
+	res, err = s.JSONRPCCall("ots_searchTransactionsBefore", address.String(), 3, 2)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	assert.Empty(t, result.Txs)
+	assert.True(t, result.LastPage)
+}
+
+func TestOtsSearchTransactionsAfter(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	address := common.HexToAddress("0x111")
+	receipts := newTestTransactionReceipts(t, 5)
+	m.State.On("GetFirstAddressIndexedL2BlockNumber", context.Background(), nil).Return(uint64(0), nil).Once()
+	m.State.On("GetReceiptsByAddressAfter", context.Background(), address, uint64(0), uint64(5), nil).Return(receipts, nil).Once()
+
+	res, err := s.JSONRPCCall("ots_searchTransactionsAfter", address.String(), 0, 5)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result types.OtsSearchResult
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	require.Len(t, result.Txs, 2)
+	assert.Equal(t, receipts[1].Tx.Hash(), result.Txs[0].Hash)
+	assert.True(t, result.FirstPage)
+	assert.True(t, result.LastPage)
+
+	res, err = s.JSONRPCCall("ots_searchTransactionsAfter", address.String(), 0, 1000)
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidParamsErrorCode, res.Error.Code)
+}
+
+func TestOtsGetBlockDetails(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	receipts := newTestTransactionReceipts(t, 1)
+	txs := []*ethTypes.Transaction{&receipts[0].Tx, &receipts[1].Tx}
+	block := state.NewL2Block(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}), txs, nil, nil, trie.NewStackTrie(nil))
+
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, nil).Return(block, nil).Once()
+	m.State.On("GetReceiptsByL2BlockNumber", context.Background(), blockNumOneUint64, nil).Return(receipts, nil).Once()
+
+	res, err := s.JSONRPCCall("ots_getBlockDetails", 1)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	// 2 txs using 21000 gas each with a gas price of 4
+	assert.Equal(t, `"0x29040"`, string(result["totalFees"]))
+	assert.JSONEq(t, `{"blockReward":"0x0","uncleReward":"0x0","issuance":"0x0"}`, string(result["issuance"]))
+
+	var rpcBlock map[string]json.RawMessage
+	require.NoError(t, json.Unmarshal(result["block"], &rpcBlock))
+	assert.Equal(t, `"0x2"`, string(rpcBlock["transactionCount"]))
+	assert.Equal(t, `"0x1"`, string(rpcBlock["number"]))
+	assert.Equal(t, "null", string(rpcBlock["transactions"]))
+}
+
+func TestOtsGetBlockTransactions(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	receipts := newTestTransactionReceipts(t, 1)
+	txs := []*ethTypes.Transaction{&receipts[0].Tx, &receipts[1].Tx}
+	block := state.NewL2Block(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}), txs, nil, nil, trie.NewStackTrie(nil))
+
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, nil).Return(block, nil).Twice()
+	m.State.On("GetReceiptsByL2BlockNumber", context.Background(), blockNumOneUint64, nil).Return(receipts, nil).Twice()
+
+	var result struct {
+		FullBlock struct {
+			TransactionCount types.ArgUint64     `json:"transactionCount"`
+			Transactions     []types.Transaction `json:"transactions"`
+		} `json:"fullblock"`
+		Receipts []map[string]json.RawMessage `json:"receipts"`
+	}
+
+	// the first page holds the last txs of the block
+	res, err := s.JSONRPCCall("ots_getBlockTransactions", 1, 0, 1)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	assert.Equal(t, types.ArgUint64(2), result.FullBlock.TransactionCount)
+	require.Len(t, result.FullBlock.Transactions, 1)
+	assert.Equal(t, receipts[1].Tx.Hash(), result.FullBlock.Transactions[0].Hash)
+	require.Len(t, result.Receipts, 1)
+	assert.Equal(t, "null", string(result.Receipts[0]["logs"]))
+
+	res, err = s.JSONRPCCall("ots_getBlockTransactions", 1, 1, 1)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	require.Len(t, result.FullBlock.Transactions, 1)
+	assert.Equal(t, receipts[0].Tx.Hash(), result.FullBlock.Transactions[0].Hash)
+}
+
+func TestOtsGetTransactionBySenderAndNonce(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	receipts := newTestTransactionReceipts(t, 1)
+	sender, err := state.GetSender(receipts[1].Tx)
+	require.NoError(t, err)
+	m.State.On("GetTransactionBySenderAndNonce", context.Background(), sender, uint64(1), nil).Return(&receipts[1].Tx, nil).Once()
+	m.State.On("GetTransactionBySenderAndNonce", context.Background(), sender, uint64(2), nil).Return(nil, state.ErrNotFound).Twice()
+	m.State.On("GetFirstAddressIndexedL2BlockNumber", context.Background(), nil).Return(uint64(0), nil).Once()
+
+	res, err := s.JSONRPCCall("ots_getTransactionBySenderAndNonce", sender.String(), "0x1")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, `"`+receipts[1].Tx.Hash().String()+`"`, string(res.Result))
+
+	res, err = s.JSONRPCCall("ots_getTransactionBySenderAndNonce", sender.String(), "0x2")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, "null", string(res.Result))
+
+	// a tx that isn't found may have been sent before the address index was created
+	m.State.On("GetFirstAddressIndexedL2BlockNumber", context.Background(), nil).Return(uint64(3), nil).Once()
+	res, err = s.JSONRPCCall("ots_getTransactionBySenderAndNonce", sender.String(), "0x2")
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, "the txs are indexed by address from block 3", res.Error.Message)
+}
+
+func TestOtsGetContractCreator(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	receipts := newTestTransactionReceipts(t, 1)
+	creator, err := state.GetSender(receipts[0].Tx)
+	require.NoError(t, err)
+	contract := common.HexToAddress("0xc0de")
+	m.State.On("GetContractCreationReceipt", context.Background(), contract, nil).Return(&receipts[0], nil).Once()
+	m.State.On("GetContractCreationReceipt", context.Background(), common.HexToAddress("0x1"), nil).Return(nil, state.ErrNotFound).Once()
+
+	res, err := s.JSONRPCCall("ots_getContractCreator", contract.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	var result types.OtsContractCreator
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	assert.Equal(t, receipts[0].Tx.Hash(), result.Hash)
+	assert.Equal(t, creator, result.Creator)
+
+	res, err = s.JSONRPCCall("ots_getContractCreator", "0x1")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, "null", string(res.Result))
+}
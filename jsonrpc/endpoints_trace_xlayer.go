@@ -0,0 +1,273 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// APITrace represents the trace API prefix.
+	APITrace = "trace"
+
+	// maxTraceFilterBlockRange is the max number of blocks that can be traced by a trace_filter request
+	maxTraceFilterBlockRange = 100
+
+	flatCallTracer = "flatCallTracer"
+	prestateTracer = "prestateTracer"
+	muxTracer      = "muxTracer"
+)
+
+var (
+	errMaxTraceFilterBlockRangeLimitExceeded = errors.New("traces are limited to a %v block range")
+
+	flatCallTracerConfig = json.RawMessage(`{"convertParityErrors":true}`)
+	prestateTracerConfig = json.RawMessage(`{"diffMode":true}`)
+)
+
+// TraceEndpoints contains implementations for the "trace" RPC endpoints, the
+// traces are built by the flat call tracer in the parity format
+type TraceEndpoints struct {
+	cfg      Config
+	state    types.StateInterface
+	etherman types.EthermanInterface
+}
+
+// NewTraceEndpoints returns TraceEndpoints
+func NewTraceEndpoints(cfg Config, state types.StateInterface, etherman types.EthermanInterface) *TraceEndpoints {
+	return &TraceEndpoints{
+		cfg:      cfg,
+		state:    state,
+		etherman: etherman,
+	}
+}
+
+// flatCallFrameFilter are the fields of a flat call frame used to filter the traces and to get the output of a tx
+type flatCallFrameFilter struct {
+	Action struct {
+		From *common.Address `json:"from"`
+		To   *common.Address `json:"to"`
+	} `json:"action"`
+	Result *struct {
+		Address *common.Address `json:"address"`
+		Output  hexutil.Bytes   `json:"output"`
+	} `json:"result"`
+}
+
+// Transaction returns the traces of a tx
+func (t *TraceEndpoints) Transaction(hash types.ArgHash) (interface{}, types.Error) {
+	ctx := context.Background()
+	traces, err := t.traceTransaction(ctx, hash.Hash(), flatCallTracer, flatCallTracerConfig)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("failed to get trace for transaction %v", hash.Hash().String()), err, true)
+	}
+	return traces, nil
+}
+
+// Block returns the traces of all the txs of a block
+func (t *TraceEndpoints) Block(number types.BlockNumber) (interface{}, types.Error) {
+	ctx := context.Background()
+	blockNumber, rpcErr := number.GetNumericBlockNumber(ctx, t.state, t.etherman, nil)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	block, err := t.state.GetL2BlockByNumber(ctx, blockNumber, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load block from state by number %v", blockNumber), err, true)
+	}
+
+	traces, rpcErr := t.traceBlock(ctx, block)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	return traces, nil
+}
+
+// Filter returns the traces of a block range whose sender and receiver match the filter
+func (t *TraceEndpoints) Filter(filter types.TraceFilter) (interface{}, types.Error) {
+	ctx := context.Background()
+	l := types.LatestBlockNumber
+	if filter.FromBlock == nil {
+		filter.FromBlock = &l
+	}
+	if filter.ToBlock == nil {
+		filter.ToBlock = &l
+	}
+	fromBlock, toBlock, rpcErr := getNumericBlockNumbers(ctx, t.state, t.etherman, filter.FromBlock, filter.ToBlock, maxTraceFilterBlockRange, errMaxTraceFilterBlockRangeLimitExceeded, nil)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	var after, count uint64
+	if filter.After != nil {
+		after = uint64(*filter.After)
+	}
+	if filter.Count != nil {
+		count = uint64(*filter.Count)
+	}
+
+	traces := []json.RawMessage{}
+	matched := uint64(0)
+	for blockNumber := fromBlock; blockNumber <= toBlock; blockNumber++ {
+		block, err := t.state.GetL2BlockByNumber(ctx, blockNumber, nil)
+		if errors.Is(err, state.ErrNotFound) {
+			break
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load block from state by number %v", blockNumber), err, true)
+		}
+
+		blockTraces, rpcErr := t.traceBlock(ctx, block)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		for _, trace := range blockTraces {
+			var frame flatCallFrameFilter
+			if err := json.Unmarshal(trace, &frame); err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to decode trace", err, true)
+			}
+			if !filter.Matches(frame.Action.From, frame.receiver()) {
+				continue
+			}
+			matched++
+			if matched <= after {
+				continue
+			}
+			traces = append(traces, trace)
+			if count > 0 && uint64(len(traces)) >= count {
+				return traces, nil
+			}
+		}
+	}
+
+	return traces, nil
+}
+
+// ReplayBlockTransactions replays all the txs of a block returning the traces and
+// state changes of each tx, vmTrace is not supported
+func (t *TraceEndpoints) ReplayBlockTransactions(number types.BlockNumber, traceTypes []string) (interface{}, types.Error) {
+	ctx := context.Background()
+	withTrace, withStateDiff := false, false
+	for _, traceType := range traceTypes {
+		switch traceType {
+		case types.TraceTypeTrace:
+			withTrace = true
+		case types.TraceTypeStateDiff:
+			withStateDiff = true
+		case types.TraceTypeVMTrace:
+			return RPCErrorResponse(types.InvalidParamsErrorCode, "vmTrace is not supported", nil, false)
+		default:
+			return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("trace type %s not supported", traceType), nil, false)
+		}
+	}
+
+	blockNumber, rpcErr := number.GetNumericBlockNumber(ctx, t.state, t.etherman, nil)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	block, err := t.state.GetL2BlockByNumber(ctx, blockNumber, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load block from state by number %v", blockNumber), err, true)
+	}
+
+	// Both tracers are run in the same execution, the call traces are always needed to get the output of the tx
+	tracerConfig, err := json.Marshal(map[string]json.RawMessage{
+		flatCallTracer: flatCallTracerConfig,
+		prestateTracer: prestateTracerConfig,
+	})
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to build tracer config", err, true)
+	}
+
+	results := make([]types.TraceReplayResult, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		traceResult, err := t.traceTransaction(ctx, tx.Hash(), muxTracer, tracerConfig)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("failed to get trace for transaction %v", tx.Hash().String()), err, true)
+		}
+		var muxResult map[string]json.RawMessage
+		if err := json.Unmarshal(traceResult, &muxResult); err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to decode trace", err, true)
+		}
+
+		result := types.TraceReplayResult{
+			Output:          types.ArgBytes{},
+			Trace:           json.RawMessage("[]"),
+			TransactionHash: tx.Hash(),
+		}
+		var frames []flatCallFrameFilter
+		if err := json.Unmarshal(muxResult[flatCallTracer], &frames); err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, "failed to decode trace", err, true)
+		}
+		if len(frames) > 0 && frames[0].Result != nil {
+			result.Output = types.ArgBytes(frames[0].Result.Output)
+		}
+		if withTrace {
+			result.Trace = muxResult[flatCallTracer]
+		}
+		if withStateDiff {
+			result.StateDiff, err = types.NewStateDiff(muxResult[prestateTracer])
+			if err != nil {
+				return RPCErrorResponse(types.DefaultErrorCode, "failed to decode state diff", err, true)
+			}
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// traceBlock returns the flat call traces of all the txs of a block
+func (t *TraceEndpoints) traceBlock(ctx context.Context, block *state.L2Block) ([]json.RawMessage, types.Error) {
+	traces := []json.RawMessage{}
+	for _, tx := range block.Transactions() {
+		traceResult, err := t.traceTransaction(ctx, tx.Hash(), flatCallTracer, flatCallTracerConfig)
+		if err != nil {
+			_, rpcErr := RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("failed to get trace for transaction %v", tx.Hash().String()), err, true)
+			return nil, rpcErr
+		}
+		var txTraces []json.RawMessage
+		if err := json.Unmarshal(traceResult, &txTraces); err != nil {
+			_, rpcErr := RPCErrorResponse(types.DefaultErrorCode, "failed to decode trace", err, true)
+			return nil, rpcErr
+		}
+		traces = append(traces, txTraces...)
+	}
+	return traces, nil
+}
+
+func (t *TraceEndpoints) traceTransaction(ctx context.Context, hash common.Hash, tracer string, tracerConfig json.RawMessage) (json.RawMessage, error) {
+	traceConfig := state.TraceConfig{
+		Tracer:       &tracer,
+		TracerConfig: tracerConfig,
+	}
+	result, err := t.state.DebugTransaction(ctx, hash, traceConfig, nil)
+	if err != nil {
+		return nil, err
+	}
+	return result.TraceResult, nil
+}
+
+// receiver returns the receiver of a call or the address of a created contract
+func (f *flatCallFrameFilter) receiver() *common.Address {
+	if f.Action.To != nil {
+		return f.Action.To
+	}
+	if f.Result != nil {
+		return f.Result.Address
+	}
+	return nil
+}
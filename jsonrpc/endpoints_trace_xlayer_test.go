@@ -0,0 +1,147 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTraceBlock(t *testing.T, txCount int) *state.L2Block {
+	txs := make([]*ethTypes.Transaction, 0, txCount)
+	for i := 0; i < txCount; i++ {
+		txs = append(txs, ethTypes.NewTransaction(uint64(i), common.HexToAddress("0x2"), big.NewInt(1), 21000, big.NewInt(1), nil))
+	}
+	header := state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot})
+	return state.NewL2Block(header, txs, nil, nil, trie.NewStackTrie(nil))
+}
+
+func flatCallTrace(from, to string, output string) string {
+	return `[{"action":{"callType":"call","from":"` + from + `","to":"` + to + `","gas":"0x5208","input":"0x","value":"0x1"},"result":{"gasUsed":"0x0","output":"` + output + `"},"subtraces":0,"traceAddress":[],"type":"call"}]`
+}
+
+func tracerIs(tracer string) interface{} {
+	return mock.MatchedBy(func(cfg state.TraceConfig) bool {
+		return cfg.Tracer != nil && *cfg.Tracer == tracer
+	})
+}
+
+func TestTraceTransaction(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	hash := common.HexToHash("0x123")
+	trace := flatCallTrace("0x0000000000000000000000000000000000000001", "0x0000000000000000000000000000000000000002", "0x")
+	m.State.On("DebugTransaction", context.Background(), hash, tracerIs(flatCallTracer), nil).
+		Return(&runtime.ExecutionResult{TraceResult: json.RawMessage(trace)}, nil).Once()
+
+	res, err := s.JSONRPCCall("trace_transaction", hash.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.JSONEq(t, trace, string(res.Result))
+
+	m.State.On("DebugTransaction", context.Background(), hash, tracerIs(flatCallTracer), nil).
+		Return(nil, state.ErrNotFound).Once()
+
+	res, err = s.JSONRPCCall("trace_transaction", hash.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, "null", string(res.Result))
+}
+
+func TestTraceFilter(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	block := newTestTraceBlock(t, 3)
+	senders := []string{
+		"0x0000000000000000000000000000000000000001",
+		"0x0000000000000000000000000000000000000003",
+		"0x0000000000000000000000000000000000000001",
+	}
+	for i, tx := range block.Transactions() {
+		trace := flatCallTrace(senders[i], "0x0000000000000000000000000000000000000002", "0x")
+		m.State.On("DebugTransaction", context.Background(), tx.Hash(), tracerIs(flatCallTracer), nil).
+			Return(&runtime.ExecutionResult{TraceResult: json.RawMessage(trace)}, nil)
+	}
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, nil).Return(block, nil)
+
+	filter := map[string]interface{}{
+		"fromBlock":   "0x1",
+		"toBlock":     "0x1",
+		"fromAddress": []string{senders[0]},
+	}
+	res, err := s.JSONRPCCall("trace_filter", filter)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	var traces []flatCallFrameFilter
+	require.NoError(t, json.Unmarshal(res.Result, &traces))
+	require.Len(t, traces, 2)
+	for _, trace := range traces {
+		assert.Equal(t, common.HexToAddress(senders[0]), *trace.Action.From)
+	}
+
+	filter["after"] = "0x1"
+	filter["count"] = "0x1"
+	res, err = s.JSONRPCCall("trace_filter", filter)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	require.NoError(t, json.Unmarshal(res.Result, &traces))
+	require.Len(t, traces, 1)
+
+	res, err = s.JSONRPCCall("trace_filter", map[string]interface{}{"fromBlock": "0x1", "toBlock": "0x200"})
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidParamsErrorCode, res.Error.Code)
+	assert.Equal(t, "traces are limited to a 100 block range", res.Error.Message)
+}
+
+func TestTraceReplayBlockTransactions(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	block := newTestTraceBlock(t, 1)
+	tx := block.Transactions()[0]
+	trace := flatCallTrace("0x0000000000000000000000000000000000000001", "0x0000000000000000000000000000000000000002", "0x0102")
+	prestate := `{"pre":{"0x0000000000000000000000000000000000000001":{"balance":"0x10","nonce":1}},"post":{"0x0000000000000000000000000000000000000001":{"balance":"0xf","nonce":2}}}`
+	muxResult := `{"flatCallTracer":` + trace + `,"prestateTracer":` + prestate + `}`
+
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, nil).Return(block, nil).Once()
+	m.State.On("DebugTransaction", context.Background(), tx.Hash(), tracerIs(muxTracer), nil).
+		Return(&runtime.ExecutionResult{TraceResult: json.RawMessage(muxResult)}, nil).Once()
+
+	res, err := s.JSONRPCCall("trace_replayBlockTransactions", "0x1", []string{"stateDiff"})
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	expected := `[{
+		"output": "0x0102",
+		"stateDiff": {
+			"0x0000000000000000000000000000000000000001": {
+				"balance": {"*": {"from": "0x10", "to": "0xf"}},
+				"code": "=",
+				"nonce": {"*": {"from": "0x1", "to": "0x2"}},
+				"storage": {}
+			}
+		},
+		"trace": [],
+		"vmTrace": null,
+		"transactionHash": "` + tx.Hash().String() + `"
+	}]`
+	assert.JSONEq(t, expected, string(res.Result))
+
+	res, err = s.JSONRPCCall("trace_replayBlockTransactions", "0x1", []string{"trace", "vmTrace"})
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidParamsErrorCode, res.Error.Code)
+}
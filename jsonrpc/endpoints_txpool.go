@@ -1,12 +1,22 @@
 package jsonrpc
 
 import (
+	"context"
+
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
 	"github.com/ethereum/go-ethereum/common"
 )
 
 // TxPoolEndpoints is the txpool jsonrpc endpoint
-type TxPoolEndpoints struct{}
+type TxPoolEndpoints struct {
+	cfg  Config
+	pool types.PoolInterface
+}
+
+// NewTxPoolEndpoints returns TxPoolEndpoints
+func NewTxPoolEndpoints(cfg Config, pool types.PoolInterface) *TxPoolEndpoints {
+	return &TxPoolEndpoints{cfg: cfg, pool: pool}
+}
 
 type contentResponse struct {
 	Pending map[common.Address]map[uint64]*txPoolTransaction `json:"pending"`
@@ -30,10 +40,10 @@ type txPoolTransaction struct {
 // Content creates a response for txpool_content request.
 // See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_content.
 func (e *TxPoolEndpoints) Content() (interface{}, types.Error) {
-	resp := contentResponse{
-		Pending: make(map[common.Address]map[uint64]*txPoolTransaction),
-		Queued:  make(map[common.Address]map[uint64]*txPoolTransaction),
+	content, err := e.pool.GetContent(context.Background(), txPoolContentLimit)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get pool content", err, true)
 	}
 
-	return resp, nil
+	return newContentResponse(content), nil
 }
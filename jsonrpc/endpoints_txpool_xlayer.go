@@ -0,0 +1,125 @@
+package jsonrpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// txPoolContentLimit is the max number of pending txs, sorted by gas price,
+	// loaded from the pool to build the txpool responses
+	txPoolContentLimit = 10000
+)
+
+type statusResponse struct {
+	Pending types.ArgUint64 `json:"pending"`
+	Queued  types.ArgUint64 `json:"queued"`
+}
+
+type inspectResponse struct {
+	Pending map[common.Address]map[uint64]string `json:"pending"`
+	Queued  map[common.Address]map[uint64]string `json:"queued"`
+}
+
+// Inspect creates a response for txpool_inspect request.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_inspect.
+func (e *TxPoolEndpoints) Inspect() (interface{}, types.Error) {
+	content, err := e.pool.GetContent(context.Background(), txPoolContentLimit)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get pool content", err, true)
+	}
+
+	return inspectResponse{
+		Pending: inspectTxs(content.Pending),
+		Queued:  inspectTxs(content.Queued),
+	}, nil
+}
+
+// Status creates a response for txpool_status request.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_status.
+func (e *TxPoolEndpoints) Status() (interface{}, types.Error) {
+	pending, queued, err := e.pool.CountContent(context.Background())
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to count pool content", err, true)
+	}
+
+	return statusResponse{
+		Pending: types.ArgUint64(pending),
+		Queued:  types.ArgUint64(queued),
+	}, nil
+}
+
+// ContentFrom creates a response for txpool_contentFrom request.
+// See https://geth.ethereum.org/docs/rpc/ns-txpool#txpool_contentfrom.
+func (e *TxPoolEndpoints) ContentFrom(from common.Address) (interface{}, types.Error) {
+	content, err := e.pool.GetContentFrom(context.Background(), from)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get pool content", err, true)
+	}
+
+	resp := newContentResponse(content)
+	return struct {
+		Pending map[uint64]*txPoolTransaction `json:"pending"`
+		Queued  map[uint64]*txPoolTransaction `json:"queued"`
+	}{
+		Pending: nonEmptyTxs(resp.Pending[from]),
+		Queued:  nonEmptyTxs(resp.Queued[from]),
+	}, nil
+}
+
+func newContentResponse(content *pool.Content) contentResponse {
+	return contentResponse{
+		Pending: newTxPoolTransactions(content.Pending),
+		Queued:  newTxPoolTransactions(content.Queued),
+	}
+}
+
+func newTxPoolTransactions(txs map[common.Address]map[uint64]pool.Transaction) map[common.Address]map[uint64]*txPoolTransaction {
+	result := make(map[common.Address]map[uint64]*txPoolTransaction, len(txs))
+	for from, senderTxs := range txs {
+		result[from] = make(map[uint64]*txPoolTransaction, len(senderTxs))
+		for nonce, tx := range senderTxs {
+			result[from][nonce] = newTxPoolTransaction(from, tx)
+		}
+	}
+	return result
+}
+
+func newTxPoolTransaction(from common.Address, tx pool.Transaction) *txPoolTransaction {
+	return &txPoolTransaction{
+		Nonce:    types.ArgUint64(tx.Nonce()),
+		GasPrice: types.ArgBig(*tx.GasPrice()),
+		Gas:      types.ArgUint64(tx.Gas()),
+		To:       tx.To(),
+		Value:    types.ArgBig(*tx.Value()),
+		Input:    tx.Data(),
+		Hash:     tx.Hash(),
+		From:     from,
+	}
+}
+
+func nonEmptyTxs(txs map[uint64]*txPoolTransaction) map[uint64]*txPoolTransaction {
+	if txs == nil {
+		return make(map[uint64]*txPoolTransaction)
+	}
+	return txs
+}
+
+func inspectTxs(txs map[common.Address]map[uint64]pool.Transaction) map[common.Address]map[uint64]string {
+	result := make(map[common.Address]map[uint64]string, len(txs))
+	for from, senderTxs := range txs {
+		result[from] = make(map[uint64]string, len(senderTxs))
+		for nonce, tx := range senderTxs {
+			to := "contract creation"
+			if tx.To() != nil {
+				to = tx.To().Hex()
+			}
+			result[from][nonce] = fmt.Sprintf("%s: %v wei + %v gas × %v wei", to, tx.Value(), tx.Gas(), tx.GasPrice())
+		}
+	}
+	return result
+}
@@ -0,0 +1,84 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTxPoolContent(from common.Address) *pool.Content {
+	to := common.HexToAddress("0x2")
+	newTx := func(nonce uint64) pool.Transaction {
+		return pool.Transaction{
+			Transaction: *ethTypes.NewTransaction(nonce, to, big.NewInt(1), 21000, big.NewInt(10), nil),
+			Status:      pool.TxStatusPending,
+		}
+	}
+	return &pool.Content{
+		Pending: map[common.Address]map[uint64]pool.Transaction{from: {0: newTx(0), 1: newTx(1)}},
+		Queued:  map[common.Address]map[uint64]pool.Transaction{from: {3: newTx(3)}},
+	}
+}
+
+func TestTxPoolStatus(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	m.Pool.On("CountContent", context.Background()).Return(uint64(2), uint64(1), nil).Once()
+
+	res, err := s.JSONRPCCall("txpool_status")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result statusResponse
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	assert.Equal(t, uint64(2), uint64(result.Pending))
+	assert.Equal(t, uint64(1), uint64(result.Queued))
+}
+
+func TestTxPoolInspect(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	from := common.HexToAddress("0x1")
+	m.Pool.On("GetContent", context.Background(), uint64(txPoolContentLimit)).Return(newTestTxPoolContent(from), nil).Once()
+
+	res, err := s.JSONRPCCall("txpool_inspect")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result inspectResponse
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	require.Len(t, result.Pending[from], 2)
+	assert.Equal(t, common.HexToAddress("0x2").Hex()+": 1 wei + 21000 gas × 10 wei", result.Pending[from][0])
+	require.Len(t, result.Queued[from], 1)
+}
+
+func TestTxPoolContentFrom(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	from := common.HexToAddress("0x1")
+	m.Pool.On("GetContentFrom", context.Background(), from).Return(newTestTxPoolContent(from), nil).Once()
+
+	res, err := s.JSONRPCCall("txpool_contentFrom", from)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result struct {
+		Pending map[uint64]*txPoolTransaction `json:"pending"`
+		Queued  map[uint64]*txPoolTransaction `json:"queued"`
+	}
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	require.Len(t, result.Pending, 2)
+	assert.Equal(t, from, result.Pending[1].From)
+	require.Len(t, result.Queued, 1)
+	assert.Equal(t, uint64(3), uint64(result.Queued[3].Nonce))
+}
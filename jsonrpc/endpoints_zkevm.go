@@ -27,6 +27,10 @@ type ZKEVMEndpoints struct {
 	pool     types.PoolInterface
 	state    types.StateInterface
 	etherman types.EthermanInterface
+
+	// XLayer preconfirmations and dynamic gas price shared with the eth endpoints
+	preconfirmations *preconfirmations
+	dgpMan           *DynamicGPManager
 }
 
 // NewZKEVMEndpoints returns ZKEVMEndpoints
@@ -582,17 +586,7 @@ func (z *ZKEVMEndpoints) EstimateCounters(arg *types.TxArgs, blockArg *types.Blo
 		}
 	}
 
-	limits := types.ZKCountersLimits{
-		MaxGasUsed:          types.ArgUint64(state.MaxTxGasLimit),
-		MaxKeccakHashes:     types.ArgUint64(z.cfg.ZKCountersLimits.MaxKeccakHashes),
-		MaxPoseidonHashes:   types.ArgUint64(z.cfg.ZKCountersLimits.MaxPoseidonHashes),
-		MaxPoseidonPaddings: types.ArgUint64(z.cfg.ZKCountersLimits.MaxPoseidonPaddings),
-		MaxMemAligns:        types.ArgUint64(z.cfg.ZKCountersLimits.MaxMemAligns),
-		MaxArithmetics:      types.ArgUint64(z.cfg.ZKCountersLimits.MaxArithmetics),
-		MaxBinaries:         types.ArgUint64(z.cfg.ZKCountersLimits.MaxBinaries),
-		MaxSteps:            types.ArgUint64(z.cfg.ZKCountersLimits.MaxSteps),
-		MaxSHA256Hashes:     types.ArgUint64(z.cfg.ZKCountersLimits.MaxSHA256Hashes),
-	}
+	limits := z.zkCountersLimits()
 	return types.NewZKCountersResponse(processBatchResponse.UsedZkCounters, limits, revert, oocErr), nil
 }
 
@@ -2,12 +2,31 @@ package jsonrpc
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/client"
 	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// maxSimulateBundleCalls is the max number of calls a simulated bundle can contain
+	maxSimulateBundleCalls = 100
+	// maxProofStorageKeys is the max number of storage keys that can be proven in a single request
+	maxProofStorageKeys = 100
 )
 
 // GetBatchSealTime returns the seal time
@@ -62,3 +81,437 @@ func (z *ZKEVMEndpoints) GetBatchDataByNumbers(filter types.BatchFilter) (interf
 
 	return types.BatchDataResult{Data: ret}, nil
 }
+
+// zkCountersLimits returns the zk counter limits reported to the user
+func (z *ZKEVMEndpoints) zkCountersLimits() types.ZKCountersLimits {
+	return types.ZKCountersLimits{
+		MaxGasUsed:          types.ArgUint64(state.MaxTxGasLimit),
+		MaxKeccakHashes:     types.ArgUint64(z.cfg.ZKCountersLimits.MaxKeccakHashes),
+		MaxPoseidonHashes:   types.ArgUint64(z.cfg.ZKCountersLimits.MaxPoseidonHashes),
+		MaxPoseidonPaddings: types.ArgUint64(z.cfg.ZKCountersLimits.MaxPoseidonPaddings),
+		MaxMemAligns:        types.ArgUint64(z.cfg.ZKCountersLimits.MaxMemAligns),
+		MaxArithmetics:      types.ArgUint64(z.cfg.ZKCountersLimits.MaxArithmetics),
+		MaxBinaries:         types.ArgUint64(z.cfg.ZKCountersLimits.MaxBinaries),
+		MaxSteps:            types.ArgUint64(z.cfg.ZKCountersLimits.MaxSteps),
+		MaxSHA256Hashes:     types.ArgUint64(z.cfg.ZKCountersLimits.MaxSHA256Hashes),
+	}
+}
+
+// SimulateBundle executes an ordered list of calls in a single virtual l2 block on
+// top of the given block, every call sees the effects of the previous ones. All the
+// calls must be sent by the same sender since the executor processes unsigned txs
+// on behalf of a single address
+func (z *ZKEVMEndpoints) SimulateBundle(calls []types.TxArgs, blockArg *types.BlockNumberOrHash, stateOverride *types.StateOverride) (interface{}, types.Error) {
+	ctx := context.Background()
+	if len(calls) == 0 {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, "missing value for required argument 0", nil, false)
+	}
+	if len(calls) > maxSimulateBundleCalls {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("bundle exceeds the max number of calls %d", maxSimulateBundleCalls), nil, false)
+	}
+
+	block, respErr := z.getBlockByArg(ctx, blockArg, nil)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	var blockToProcess *uint64
+	if blockArg != nil {
+		blockNumArg := blockArg.Number()
+		if blockNumArg != nil && (*blockArg.Number() == types.LatestBlockNumber || *blockArg.Number() == types.PendingBlockNumber) {
+			blockToProcess = nil
+		} else {
+			n := block.NumberU64()
+			blockToProcess = &n
+		}
+	}
+
+	defaultSenderAddress := common.HexToAddress(state.DefaultSenderAddress)
+	var sender common.Address
+	txs := make([]*ethTypes.Transaction, 0, len(calls))
+	for i := range calls {
+		callSender, tx, err := calls[i].ToTransaction(ctx, z.state, z.cfg.MaxCumulativeGasUsed, block.Root(), defaultSenderAddress, nil)
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("failed to convert call %d into an unsigned transaction", i), err, false)
+		}
+		if i == 0 {
+			sender = callSender
+		} else if callSender != sender {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, "all the calls of a bundle must have the same sender", nil, false)
+		}
+		txs = append(txs, tx)
+	}
+
+	var oocErr error
+	response, err := z.state.SimulateBundle(ctx, txs, sender, blockToProcess, stateOverride.ToStateOverride(), nil)
+	if errors.Is(err, state.ErrStateAndStateDiffOverride) || errors.Is(err, state.ErrBundleForkIDNotSupported) {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+	} else if err != nil {
+		if !executor.IsROMOutOfCountersError(executor.RomErrorCode(err)) {
+			errMsg := fmt.Sprintf("failed to simulate bundle: %v", err.Error())
+			return nil, types.NewRPCError(types.DefaultErrorCode, errMsg)
+		}
+		oocErr = err
+	}
+
+	result := types.SimulateBundleResponse{
+		Calls:      []types.SimulateBundleCallResult{},
+		ZKCounters: types.NewZKCountersResponse(response.UsedZkCounters, z.zkCountersLimits(), nil, oocErr),
+	}
+	if len(response.BlockResponses) > 0 {
+		for _, txResponse := range response.BlockResponses[0].TransactionResponses {
+			result.Calls = append(result.Calls, newSimulateBundleCallResult(txResponse))
+		}
+	}
+
+	return result, nil
+}
+
+// newSimulateBundleCallResult builds the result of a simulated call from its executor response
+func newSimulateBundleCallResult(txResponse *state.ProcessTransactionResponse) types.SimulateBundleCallResult {
+	callResult := types.SimulateBundleCallResult{
+		TxHash:     txResponse.TxHash,
+		Status:     types.ArgUint64(ethTypes.ReceiptStatusSuccessful),
+		GasUsed:    types.ArgUint64(txResponse.GasUsed),
+		ReturnData: txResponse.ReturnValue,
+		Logs:       make([]types.Log, 0, len(txResponse.Logs)),
+	}
+	for _, l := range txResponse.Logs {
+		callResult.Logs = append(callResult.Logs, types.NewLog(*l))
+	}
+	if txResponse.RomError == nil {
+		return callResult
+	}
+
+	callResult.Status = types.ArgUint64(ethTypes.ReceiptStatusFailed)
+	if errors.Is(txResponse.RomError, runtime.ErrExecutionReverted) {
+		returnValue := make([]byte, len(txResponse.ReturnValue))
+		copy(returnValue, txResponse.ReturnValue)
+		err := state.ConstructErrorFromRevert(txResponse.RomError, returnValue)
+		callResult.Revert = &types.RevertInfo{
+			Message: err.Error(),
+			Data:    state.Ptr(types.ArgBytes(returnValue)),
+		}
+	} else {
+		errMsg := txResponse.RomError.Error()
+		callResult.Error = &errMsg
+	}
+	return callResult
+}
+
+// GetProof returns the sparse merkle tree proofs of the balance, nonce, code hash and the given storage keys of an
+// account against the state root of the given block. The proofs can be checked offline with merkletree.AccountProof
+func (z *ZKEVMEndpoints) GetProof(address types.ArgAddress, storageKeys []types.ArgHash, blockArg *types.BlockNumberOrHash) (interface{}, types.Error) {
+	ctx := context.Background()
+	if len(storageKeys) > maxProofStorageKeys {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, fmt.Sprintf("too many storage keys, max %d", maxProofStorageKeys), nil, false)
+	}
+
+	block, respErr := z.getBlockByArg(ctx, blockArg, nil)
+	if respErr != nil {
+		return nil, respErr
+	}
+
+	positions := make([]*big.Int, 0, len(storageKeys))
+	for _, storageKey := range storageKeys {
+		positions = append(positions, storageKey.Hash().Big())
+	}
+
+	proof, err := z.state.GetAccountProof(ctx, address.Address(), positions, block.Root())
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get account proof from state", err, true)
+	}
+
+	return proof, nil
+}
+
+// GetBatchReceipts returns the receipts of all the txs of all the l2 blocks of a batch, all
+// the receipts and their logs are loaded from the state at once
+func (z *ZKEVMEndpoints) GetBatchReceipts(batchNumber types.BatchNumber) (interface{}, types.Error) {
+	ctx := context.Background()
+	batchNumberResult, rpcErr := batchNumber.GetNumericBatchNumber(ctx, z.state, z.etherman, nil)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	receipts, err := z.state.GetReceiptsByBatchNumber(ctx, batchNumberResult, nil)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load receipts for batch %v", batchNumberResult), err, true)
+	}
+
+	// A batch without receipts may not exist yet
+	if len(receipts) == 0 {
+		_, err = z.state.GetBatchByNumber(ctx, batchNumberResult, nil)
+		if errors.Is(err, state.ErrNotFound) {
+			return nil, nil
+		} else if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load batch from state by number %v", batchNumberResult), err, true)
+		}
+	}
+
+	return newReceipts(receipts, true)
+}
+
+// GetTransactionStatus returns the lifecycle of a tx, from the pool up to the L1 finality of its
+// batch, with the batch, the L1 txs and the time each stage was reached
+func (z *ZKEVMEndpoints) GetTransactionStatus(hash types.ArgHash) (interface{}, types.Error) {
+	ctx := context.Background()
+	receipt, err := z.state.GetTransactionReceipt(ctx, hash.Hash(), nil)
+	if errors.Is(err, state.ErrNotFound) {
+		if z.cfg.SequencerNodeURI != "" {
+			return z.getTransactionStatusFromSequencerNode(hash.Hash())
+		}
+		return z.getPoolTransactionStatus(ctx, hash.Hash())
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get tx receipt from state", err, true)
+	}
+
+	status := &types.TransactionStatus{Hash: hash.Hash(), Stages: []types.TransactionStatusStage{}}
+
+	// The txs synced from the trusted sequencer aren't in the pool of this node
+	poolTx, err := z.pool.GetTransactionByHash(ctx, hash.Hash())
+	if err != nil && !errors.Is(err, pool.ErrNotFound) {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load transaction by hash from pool", err, true)
+	}
+
+	blockNumber := receipt.BlockNumber.Uint64()
+	header, err := z.state.GetL2BlockHeaderByNumber(ctx, blockNumber, nil)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load block header from state by number %v", blockNumber), err, true)
+	}
+	if poolTx != nil {
+		// The WIP L2 block of the tx is the stored L2 block, so it has the same timestamp
+		status.AddStage(types.TransactionStatusPending, timestampOf(poolTx.ReceivedAt))
+		status.AddStage(types.TransactionStatusSelected, selectedTimestampOf(poolTx))
+		status.AddStage(types.TransactionStatusWIPL2Block, state.Ptr(types.ArgUint64(header.Time)))
+	}
+
+	batchNumber, err := z.state.BatchNumberByL2BlockNumber(ctx, blockNumber, nil)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load batch number from state by block number %v", blockNumber), err, true)
+	}
+	status.BlockNumber = state.Ptr(types.ArgUint64(blockNumber))
+	status.BlockHash = state.Ptr(receipt.BlockHash)
+	status.BatchNumber = state.Ptr(types.ArgUint64(batchNumber))
+	status.AddStage(types.TransactionStatusTrusted, state.Ptr(types.ArgUint64(header.Time)))
+
+	virtualBatch, err := z.state.GetVirtualBatch(ctx, batchNumber, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return status, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load virtual batch from state by number %v", batchNumber), err, true)
+	}
+	timestamp, rpcErr := z.getL1BlockTimestamp(ctx, virtualBatch.BlockNumber)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	status.SendSequencesTxHash = state.Ptr(virtualBatch.TxHash)
+	status.AddStage(types.TransactionStatusVirtualized, timestamp)
+
+	verifiedBatch, err := z.state.GetVerifiedBatch(ctx, batchNumber, nil)
+	if errors.Is(err, state.ErrNotFound) {
+		return status, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load verified batch from state by number %v", batchNumber), err, true)
+	}
+	timestamp, rpcErr = z.getL1BlockTimestamp(ctx, verifiedBatch.BlockNumber)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+	status.VerifyBatchTxHash = state.Ptr(verifiedBatch.TxHash)
+	status.AddStage(types.TransactionStatusVerified, timestamp)
+
+	finalizedBlockNumber, err := z.etherman.GetFinalizedBlockNumber(ctx)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get the finalized L1 block number", err, true)
+	}
+	if verifiedBatch.BlockNumber <= finalizedBlockNumber {
+		finalizedHeader, err := z.etherman.HeaderByNumber(ctx, new(big.Int).SetUint64(finalizedBlockNumber))
+		if err != nil {
+			return RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("failed to get the finalized L1 block header by number %v", finalizedBlockNumber), err, true)
+		}
+		status.AddStage(types.TransactionStatusFinalized, state.Ptr(types.ArgUint64(finalizedHeader.Time)))
+	}
+
+	return status, nil
+}
+
+// getPoolTransactionStatus returns the lifecycle of a tx that isn't in a stored L2 block yet
+func (z *ZKEVMEndpoints) getPoolTransactionStatus(ctx context.Context, hash common.Hash) (interface{}, types.Error) {
+	poolTx, err := z.pool.GetTransactionByHash(ctx, hash)
+	if errors.Is(err, pool.ErrNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to load transaction by hash from pool", err, true)
+	}
+
+	status := &types.TransactionStatus{Hash: hash, Stages: []types.TransactionStatusStage{}}
+	status.AddStage(types.TransactionStatusPending, timestampOf(poolTx.ReceivedAt))
+	switch poolTx.Status {
+	case pool.TxStatusPending:
+		if poolTx.IsWIP {
+			status.AddStage(types.TransactionStatusSelected, selectedTimestampOf(poolTx))
+		}
+		if preconfirmation, ok := z.getPreconfirmation(hash); ok {
+			status.AddStage(types.TransactionStatusWIPL2Block, state.Ptr(types.ArgUint64(preconfirmation.BlockTimestamp)))
+		}
+	case pool.TxStatusSelected:
+		// The L2 block of the tx is being stored, its timestamp is only known from the preconfirmation
+		status.AddStage(types.TransactionStatusSelected, selectedTimestampOf(poolTx))
+		var wipL2BlockTimestamp *types.ArgUint64
+		if preconfirmation, ok := z.getPreconfirmation(hash); ok {
+			wipL2BlockTimestamp = state.Ptr(types.ArgUint64(preconfirmation.BlockTimestamp))
+		}
+		status.AddStage(types.TransactionStatusWIPL2Block, wipL2BlockTimestamp)
+	case pool.TxStatusFailed:
+		// The pool doesn't keep the time a tx fails
+		status.AddStage(types.TransactionStatusFailed, nil)
+		status.FailedReason = poolTx.FailedReason
+	case pool.TxStatusInvalid:
+		status.AddStage(types.TransactionStatusInvalid, nil)
+		status.FailedReason = poolTx.FailedReason
+	}
+	return status, nil
+}
+
+// getPreconfirmation returns the preconfirmation of a tx in the WIP L2 block, if the preconfirmations are enabled
+func (z *ZKEVMEndpoints) getPreconfirmation(hash common.Hash) (state.Preconfirmation, bool) {
+	if z.preconfirmations == nil {
+		return state.Preconfirmation{}, false
+	}
+	return z.preconfirmations.get(hash)
+}
+
+func (z *ZKEVMEndpoints) getTransactionStatusFromSequencerNode(hash common.Hash) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(z.cfg.SequencerNodeURI, "zkevm_getTransactionStatus", hash.String())
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get tx status from sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	var status *types.TransactionStatus
+	err = json.Unmarshal(res.Result, &status)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to read tx status from sequencer node", err, true)
+	}
+	return status, nil
+}
+
+// getL1BlockTimestamp returns the timestamp of a synced L1 block
+func (z *ZKEVMEndpoints) getL1BlockTimestamp(ctx context.Context, blockNumber uint64) (*types.ArgUint64, types.Error) {
+	block, err := z.state.GetBlockByNumber(ctx, blockNumber, nil)
+	if err != nil {
+		_, rpcErr := RPCErrorResponse(types.DefaultErrorCode, fmt.Sprintf("couldn't load L1 block from state by number %v", blockNumber), err, true)
+		return nil, rpcErr
+	}
+	return timestampOf(block.ReceivedAt), nil
+}
+
+func timestampOf(t time.Time) *types.ArgUint64 {
+	return state.Ptr(types.ArgUint64(t.Unix()))
+}
+
+// selectedTimestampOf returns the time a pool tx was selected by the sequencer, the txs selected
+// before the time was kept in the pool have no timestamp
+func selectedTimestampOf(poolTx *pool.Transaction) *types.ArgUint64 {
+	if poolTx.SelectedAt == nil {
+		return nil
+	}
+	return timestampOf(*poolTx.SelectedAt)
+}
+
+// CheckTransaction dry-runs the admission of a raw tx in the pool without storing it,
+// returning every check of the pool as passed or failed with its details
+func (z *ZKEVMEndpoints) CheckTransaction(httpRequest *http.Request, input string) (interface{}, types.Error) {
+	if z.cfg.SequencerNodeURI != "" {
+		return z.checkTransactionInSequencerNode(input)
+	}
+
+	ip := ""
+	if ips := httpRequest.Header.Get("X-Forwarded-For"); ips != "" {
+		ip = strings.Split(ips, ",")[0]
+	}
+
+	tx, err := hexToTx(input)
+	if err != nil {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, "invalid tx input", err, false)
+	}
+
+	result, err := z.pool.CheckTx(context.Background(), *tx, ip)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to check tx", err, true)
+	}
+	return types.NewTxCheckResponse(result, z.zkCountersLimits()), nil
+}
+
+func (z *ZKEVMEndpoints) checkTransactionInSequencerNode(input string) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(z.cfg.SequencerNodeURI, "zkevm_checkTransaction", input)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to check tx in the sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	var response *types.TxCheckResponse
+	err = json.Unmarshal(res.Result, &response)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to read tx check from sequencer node", err, true)
+	}
+	return response, nil
+}
+
+// SendRawTransactionWithOptions adds a tx to the pool with a validity window, the sequencer keeps the
+// tx until the window opens and discards it once the window closes
+func (z *ZKEVMEndpoints) SendRawTransactionWithOptions(httpRequest *http.Request, input string, options types.TxOptions) (interface{}, types.Error) {
+	window := options.ValidityWindow()
+	if err := window.Validate(); err != nil {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+	}
+
+	if z.cfg.SequencerNodeURI != "" {
+		return z.relayTxWithOptionsToSequencerNode(input, options)
+	}
+
+	ip := ""
+	if ips := httpRequest.Header.Get("X-Forwarded-For"); ips != "" {
+		ip = strings.Split(ips, ",")[0]
+	}
+
+	tx, err := hexToTx(input)
+	if err != nil {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, "invalid tx input", err, false)
+	}
+	log.Infof("adding scheduled TX to the pool: %v", tx.Hash().Hex())
+
+	if z.dgpMan != nil {
+		dgp := getDynamicGp(z.cfg.DynamicGP.Enabled, z.dgpMan.lastPrice)
+		z.pool.AddDynamicGp(dgp)
+	}
+	if err := z.pool.AddTxWithValidityWindow(context.Background(), *tx, ip, window); err != nil {
+		if errors.Is(err, pool.ErrInvalidTxValidityWindow) {
+			return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+		}
+		return RPCErrorResponse(types.DefaultErrorCode, err.Error(), nil, false)
+	}
+	log.Infof("scheduled TX added to the pool: %v", tx.Hash().Hex())
+
+	return tx.Hash().Hex(), nil
+}
+
+func (z *ZKEVMEndpoints) relayTxWithOptionsToSequencerNode(input string, options types.TxOptions) (interface{}, types.Error) {
+	res, err := client.JSONRPCCall(z.cfg.SequencerNodeURI, "zkevm_sendRawTransactionWithOptions", input, options)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to relay tx to the sequencer node", err, true)
+	}
+
+	if res.Error != nil {
+		return RPCErrorResponse(res.Error.Code, res.Error.Message, nil, false)
+	}
+
+	return res.Result, nil
+}
@@ -0,0 +1,387 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/mocks"
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/merkletree"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateBundle(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	from := common.HexToAddress("0x1")
+	calls := []types.TxArgs{
+		{From: &from, To: state.HexToAddressPtr("0x2"), Data: types.ArgBytesPtr([]byte("approve"))},
+		{From: &from, To: state.HexToAddressPtr("0x3"), Data: types.ArgBytesPtr([]byte("swap"))},
+	}
+
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
+	m.State.On("GetLastL2BlockNumber", context.Background(), nil).Return(blockNumOne.Uint64(), nil).Once()
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, nil).Return(block, nil).Once()
+	m.State.On("GetNonce", context.Background(), from, blockRoot).Return(uint64(0), nil).Twice()
+
+	approveLog := &ethTypes.Log{Address: common.HexToAddress("0x2"), Topics: []common.Hash{common.HexToHash("0x1")}}
+	response := &state.ProcessBatchResponse{
+		UsedZkCounters: state.ZKCounters{GasUsed: 50000, Steps: 1000},
+		BlockResponses: []*state.ProcessBlockResponse{
+			{
+				TransactionResponses: []*state.ProcessTransactionResponse{
+					{TxHash: common.HexToHash("0xa"), GasUsed: 30000, Logs: []*ethTypes.Log{approveLog}},
+					{TxHash: common.HexToHash("0xb"), GasUsed: 20000, RomError: runtime.ErrExecutionReverted},
+				},
+			},
+		},
+	}
+	m.State.
+		On("SimulateBundle", context.Background(), mock.AnythingOfType("[]*types.Transaction"), from, nilUint64, state.StateOverride(nil), nil).
+		Return(response, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("zkevm_simulateBundle", calls, latest)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result types.SimulateBundleResponse
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	require.Len(t, result.Calls, 2)
+
+	assert.Equal(t, types.ArgUint64(ethTypes.ReceiptStatusSuccessful), result.Calls[0].Status)
+	assert.Equal(t, types.ArgUint64(30000), result.Calls[0].GasUsed)
+	require.Len(t, result.Calls[0].Logs, 1)
+	assert.Equal(t, approveLog.Address, result.Calls[0].Logs[0].Address)
+
+	assert.Equal(t, types.ArgUint64(ethTypes.ReceiptStatusFailed), result.Calls[1].Status)
+	require.NotNil(t, result.Calls[1].Revert)
+
+	assert.Equal(t, types.ArgUint64(50000), result.ZKCounters.CountersUsed.GasUsed)
+	assert.Equal(t, types.ArgUint64(1000), result.ZKCounters.CountersUsed.UsedSteps)
+	assert.Nil(t, result.ZKCounters.OOCError)
+}
+
+func TestSimulateBundleDifferentSenders(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	calls := []types.TxArgs{
+		{From: state.HexToAddressPtr("0x1"), To: state.HexToAddressPtr("0x2"), Data: types.ArgBytesPtr([]byte("approve"))},
+		{From: state.HexToAddressPtr("0x4"), To: state.HexToAddressPtr("0x3"), Data: types.ArgBytesPtr([]byte("swap"))},
+	}
+
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
+	m.State.On("GetLastL2BlockNumber", context.Background(), nil).Return(blockNumOne.Uint64(), nil).Once()
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, nil).Return(block, nil).Once()
+	m.State.On("GetNonce", context.Background(), mock.Anything, blockRoot).Return(uint64(0), nil).Twice()
+
+	res, err := s.JSONRPCCall("zkevm_simulateBundle", calls, latest)
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidParamsErrorCode, res.Error.Code)
+}
+
+func TestGetProof(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	address := common.HexToAddress("0x1")
+	storageKey := common.HexToHash("0x2")
+	newProof := func(value uint64) *merkletree.StateProof {
+		return &merkletree.StateProof{
+			Root:     []uint64{1, 2, 3, 4},
+			Key:      []uint64{5, 6, 7, 8},
+			Value:    []uint64{value, 0, 0, 0, 0, 0, 0, 0},
+			Siblings: [][]uint64{{1, 2, 3, 4, 5, 6, 7, 8}},
+			IsOld0:   true,
+		}
+	}
+	proof := &merkletree.AccountProof{
+		Address:      address,
+		StateRoot:    blockRoot,
+		Balance:      newProof(100),
+		Nonce:        newProof(1),
+		CodeHash:     newProof(0),
+		StorageProof: []merkletree.StorageProof{{Position: storageKey, Proof: newProof(3)}},
+	}
+
+	block := state.NewL2BlockWithHeader(state.NewL2Header(&ethTypes.Header{Number: blockNumOne, Root: blockRoot}))
+	m.State.On("GetLastL2BlockNumber", context.Background(), nil).Return(blockNumOne.Uint64(), nil).Once()
+	m.State.On("GetL2BlockByNumber", context.Background(), blockNumOneUint64, nil).Return(block, nil).Once()
+	m.State.On("GetAccountProof", context.Background(), address, []*big.Int{storageKey.Big()}, blockRoot).Return(proof, nil).Once()
+
+	res, err := s.JSONRPCCall("zkevm_getProof", address.String(), []string{storageKey.String()}, latest)
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result merkletree.AccountProof
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	assert.Equal(t, address, result.Address)
+	assert.Equal(t, blockRoot, result.StateRoot)
+	assert.Equal(t, big.NewInt(100), result.Balance.GetValue())
+	assert.Equal(t, big.NewInt(1), result.Nonce.GetValue())
+	require.Len(t, result.StorageProof, 1)
+	assert.Equal(t, storageKey, result.StorageProof[0].Position)
+	assert.Equal(t, proof.StorageProof[0].Proof.Siblings, result.StorageProof[0].Proof.Siblings)
+}
+
+func TestGetProofTooManyStorageKeys(t *testing.T) {
+	s, _, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	storageKeys := make([]string, maxProofStorageKeys+1)
+	for i := range storageKeys {
+		storageKeys[i] = common.BigToHash(big.NewInt(int64(i))).String()
+	}
+
+	res, err := s.JSONRPCCall("zkevm_getProof", common.HexToAddress("0x1").String(), storageKeys, latest)
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidParamsErrorCode, res.Error.Code)
+}
+
+func TestGetBatchReceipts(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	receipts := newTestTransactionReceipts(t, blockNumOne.Uint64())
+	m.State.On("GetReceiptsByBatchNumber", context.Background(), uint64(1), nil).Return(receipts, nil).Once()
+
+	res, err := s.JSONRPCCall("zkevm_getBatchReceipts", "0x1")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var result []types.Receipt
+	require.NoError(t, json.Unmarshal(res.Result, &result))
+	require.Len(t, result, len(receipts))
+	for i, r := range result {
+		assert.Equal(t, receipts[i].Tx.Hash(), r.TxHash)
+		assert.Equal(t, receipts[i].L2Hash, r.TxL2Hash)
+	}
+}
+
+func TestGetBatchReceiptsBatchNotFound(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	m.State.On("GetReceiptsByBatchNumber", context.Background(), uint64(9), nil).Return([]state.TransactionReceipt{}, nil).Once()
+	m.State.On("GetBatchByNumber", context.Background(), uint64(9), nil).Return(nil, state.ErrNotFound).Once()
+
+	res, err := s.JSONRPCCall("zkevm_getBatchReceipts", "0x9")
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, "null", string(res.Result))
+}
+
+func TestGetTransactionStatus(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	txHash := common.HexToHash("0x1")
+	blockHash := common.HexToHash("0x2")
+	sequenceTxHash := common.HexToHash("0x3")
+	verifyTxHash := common.HexToHash("0x4")
+	receivedAt := time.Unix(100, 0)
+	selectedAt := time.Unix(101, 0)
+
+	m.State.On("GetTransactionReceipt", context.Background(), txHash, nil).Return(&ethTypes.Receipt{BlockNumber: big.NewInt(5), BlockHash: blockHash}, nil).Once()
+	m.Pool.On("GetTransactionByHash", context.Background(), txHash).Return(&pool.Transaction{Status: pool.TxStatusSelected, ReceivedAt: receivedAt, SelectedAt: &selectedAt}, nil).Once()
+	m.State.On("GetL2BlockHeaderByNumber", context.Background(), uint64(5), nil).Return(state.NewL2Header(&ethTypes.Header{Number: big.NewInt(5), Time: 101}), nil).Once()
+	m.State.On("BatchNumberByL2BlockNumber", context.Background(), uint64(5), nil).Return(uint64(2), nil).Once()
+	m.State.On("GetVirtualBatch", context.Background(), uint64(2), nil).Return(&state.VirtualBatch{BatchNumber: 2, TxHash: sequenceTxHash, BlockNumber: 10}, nil).Once()
+	m.State.On("GetBlockByNumber", context.Background(), uint64(10), nil).Return(&state.Block{BlockNumber: 10, ReceivedAt: time.Unix(102, 0)}, nil).Once()
+	m.State.On("GetVerifiedBatch", context.Background(), uint64(2), nil).Return(&state.VerifiedBatch{BatchNumber: 2, TxHash: verifyTxHash, BlockNumber: 11}, nil).Once()
+	m.State.On("GetBlockByNumber", context.Background(), uint64(11), nil).Return(&state.Block{BlockNumber: 11, ReceivedAt: time.Unix(103, 0)}, nil).Once()
+	m.Etherman.On("GetFinalizedBlockNumber", context.Background()).Return(uint64(12), nil).Once()
+	m.Etherman.On("HeaderByNumber", context.Background(), big.NewInt(12)).Return(&ethTypes.Header{Number: big.NewInt(12), Time: 104}, nil).Once()
+
+	res, err := s.JSONRPCCall("zkevm_getTransactionStatus", txHash.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var status types.TransactionStatus
+	require.NoError(t, json.Unmarshal(res.Result, &status))
+	assert.Equal(t, types.TransactionStatusFinalized, status.Status)
+	assert.Equal(t, uint64(5), uint64(*status.BlockNumber))
+	assert.Equal(t, blockHash, *status.BlockHash)
+	assert.Equal(t, uint64(2), uint64(*status.BatchNumber))
+	assert.Equal(t, sequenceTxHash, *status.SendSequencesTxHash)
+	assert.Equal(t, verifyTxHash, *status.VerifyBatchTxHash)
+
+	expectedStages := []string{
+		types.TransactionStatusPending, types.TransactionStatusSelected, types.TransactionStatusWIPL2Block, types.TransactionStatusTrusted,
+		types.TransactionStatusVirtualized, types.TransactionStatusVerified, types.TransactionStatusFinalized,
+	}
+	expectedTimestamps := []*types.ArgUint64{
+		state.Ptr(types.ArgUint64(100)), state.Ptr(types.ArgUint64(101)), state.Ptr(types.ArgUint64(101)), state.Ptr(types.ArgUint64(101)),
+		state.Ptr(types.ArgUint64(102)), state.Ptr(types.ArgUint64(103)), state.Ptr(types.ArgUint64(104)),
+	}
+	require.Len(t, status.Stages, len(expectedStages))
+	for i, stage := range status.Stages {
+		assert.Equal(t, expectedStages[i], stage.Status)
+		assert.Equal(t, expectedTimestamps[i], stage.Timestamp)
+	}
+}
+
+func TestGetTransactionStatusPool(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	txHash := common.HexToHash("0x1")
+	failedReason := "nonce too low"
+	m.State.On("GetTransactionReceipt", context.Background(), txHash, nil).Return(nil, state.ErrNotFound).Twice()
+	selectedAt := time.Unix(101, 0)
+	m.Pool.On("GetTransactionByHash", context.Background(), txHash).Return(&pool.Transaction{Status: pool.TxStatusPending, IsWIP: true, ReceivedAt: time.Unix(100, 0), SelectedAt: &selectedAt}, nil).Once()
+	m.Pool.On("GetTransactionByHash", context.Background(), txHash).Return(&pool.Transaction{Status: pool.TxStatusFailed, FailedReason: &failedReason}, nil).Once()
+
+	res, err := s.JSONRPCCall("zkevm_getTransactionStatus", txHash.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var status types.TransactionStatus
+	require.NoError(t, json.Unmarshal(res.Result, &status))
+	assert.Equal(t, types.TransactionStatusSelected, status.Status)
+	assert.Nil(t, status.BatchNumber)
+	require.Len(t, status.Stages, 2)
+	assert.Equal(t, types.TransactionStatusPending, status.Stages[0].Status)
+	assert.Equal(t, types.ArgUint64(100), *status.Stages[0].Timestamp)
+	assert.Equal(t, types.ArgUint64(101), *status.Stages[1].Timestamp)
+
+	res, err = s.JSONRPCCall("zkevm_getTransactionStatus", txHash.String())
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	status = types.TransactionStatus{}
+	require.NoError(t, json.Unmarshal(res.Result, &status))
+	assert.Equal(t, types.TransactionStatusFailed, status.Status)
+	require.NotNil(t, status.FailedReason)
+	assert.Equal(t, failedReason, *status.FailedReason)
+	// The pool doesn't keep the time a tx fails
+	assert.Nil(t, status.Stages[len(status.Stages)-1].Timestamp)
+}
+
+func TestGetTransactionStatusPreconfirmed(t *testing.T) {
+	txHash := common.HexToHash("0x1")
+	st := mocks.NewStateMock(t)
+	p := mocks.NewPoolMock(t)
+	z := &ZKEVMEndpoints{state: st, pool: p, preconfirmations: newPreconfirmations(func(state.Preconfirmation) {})}
+	preconfirmation := newTestPreconfirmation(txHash, 10)
+	preconfirmation.BlockTimestamp = 102
+	z.preconfirmations.add(preconfirmation)
+
+	st.On("GetTransactionReceipt", context.Background(), txHash, nil).Return(nil, state.ErrNotFound).Twice()
+	p.On("GetTransactionByHash", context.Background(), txHash).Return(&pool.Transaction{Status: pool.TxStatusPending, IsWIP: true, ReceivedAt: time.Unix(100, 0)}, nil).Once()
+	p.On("GetTransactionByHash", context.Background(), txHash).Return(&pool.Transaction{Status: pool.TxStatusSelected, ReceivedAt: time.Unix(100, 0)}, nil).Once()
+
+	// The tx selected before the selection time was kept in the pool has no selection timestamp
+	expectedStages := []string{types.TransactionStatusPending, types.TransactionStatusSelected, types.TransactionStatusWIPL2Block}
+	expectedTimestamps := []*types.ArgUint64{state.Ptr(types.ArgUint64(100)), nil, state.Ptr(types.ArgUint64(102))}
+	for i := 0; i < 2; i++ {
+		res, rpcErr := z.GetTransactionStatus(types.ArgHash(txHash))
+		require.Nil(t, rpcErr)
+		status := res.(*types.TransactionStatus)
+		assert.Equal(t, types.TransactionStatusWIPL2Block, status.Status)
+		require.Len(t, status.Stages, len(expectedStages))
+		for j, stage := range status.Stages {
+			assert.Equal(t, expectedStages[j], stage.Status)
+			assert.Equal(t, expectedTimestamps[j], stage.Timestamp)
+		}
+	}
+}
+
+func TestCheckTransaction(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	tx := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(21000), big.NewInt(10), []byte{})
+	txBinary, err := tx.MarshalBinary()
+	require.NoError(t, err)
+
+	m.Pool.
+		On("CheckTx", context.Background(), mock.IsType(ethTypes.Transaction{}), "").
+		Return(&pool.TxCheckResult{
+			Checks: []pool.TxCheck{
+				{Name: pool.TxCheckSignature, Passed: true, Details: "sender 0x1"},
+				{Name: pool.TxCheckBalance, Passed: false, Details: pool.ErrInsufficientFunds.Error()},
+			},
+			UsedZKCounters:     &state.ZKCounters{GasUsed: 21000, Steps: 100},
+			ReservedZKCounters: &state.ZKCounters{GasUsed: 21000, Steps: 200},
+			BreakEvenGasPrice:  big.NewInt(5),
+			OfferedGasPrice:    big.NewInt(10),
+		}, nil).
+		Once()
+
+	res, err := s.JSONRPCCall("zkevm_checkTransaction", hex.EncodeToHex(txBinary))
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+
+	var response types.TxCheckResponse
+	require.NoError(t, json.Unmarshal(res.Result, &response))
+	assert.False(t, response.Accepted)
+	require.Len(t, response.Checks, 2)
+	assert.True(t, response.Checks[0].Passed)
+	assert.Equal(t, pool.TxCheckBalance, response.Checks[1].Name)
+	assert.False(t, response.Checks[1].Passed)
+	require.NotNil(t, response.UsedZKCounters)
+	assert.Equal(t, types.ArgUint64(100), response.UsedZKCounters.UsedSteps)
+	require.NotNil(t, response.ReservedZKCounters)
+	assert.Equal(t, types.ArgUint64(200), response.ReservedZKCounters.UsedSteps)
+	assert.NotNil(t, response.CountersLimits)
+	assert.Equal(t, big.NewInt(5), (*big.Int)(response.BreakEvenGasPrice))
+	assert.Equal(t, big.NewInt(10), (*big.Int)(response.OfferedGasPrice))
+
+	res, err = s.JSONRPCCall("zkevm_checkTransaction", "0x1234")
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidParamsErrorCode, res.Error.Code)
+	assert.Equal(t, "invalid tx input", res.Error.Message)
+}
+
+func TestSendRawTransactionWithOptions(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	tx := ethTypes.NewTransaction(1, common.HexToAddress("0x1"), big.NewInt(1), uint64(21000), big.NewInt(10), []byte{})
+	txBinary, err := tx.MarshalBinary()
+	require.NoError(t, err)
+	rawTx := hex.EncodeToHex(txBinary)
+
+	notBefore, notAfterBlock := uint64(1700000000), uint64(100)
+	txMatchByHash := mock.MatchedBy(func(t ethTypes.Transaction) bool {
+		return t.Hash() == tx.Hash()
+	})
+	m.Pool.
+		On("AddTxWithValidityWindow", context.Background(), txMatchByHash, "", pool.TxValidityWindow{NotBefore: &notBefore, NotAfterBlock: &notAfterBlock}).
+		Return(nil).
+		Once()
+
+	res, err := s.JSONRPCCall("zkevm_sendRawTransactionWithOptions", rawTx, map[string]interface{}{
+		"notBefore":     types.ArgUint64(notBefore),
+		"notAfterBlock": types.ArgUint64(notAfterBlock),
+	})
+	require.NoError(t, err)
+	require.Nil(t, res.Error)
+	assert.Equal(t, `"`+tx.Hash().Hex()+`"`, string(res.Result))
+
+	// An empty window is rejected
+	res, err = s.JSONRPCCall("zkevm_sendRawTransactionWithOptions", rawTx, map[string]interface{}{
+		"notBefore": types.ArgUint64(20),
+		"notAfter":  types.ArgUint64(10),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, res.Error)
+	assert.Equal(t, types.InvalidParamsErrorCode, res.Error.Code)
+}
@@ -164,6 +164,9 @@ func (h *Handler) HandleWs(reqBody []byte, wsConn *concurrentWsConn, httpReq *ht
 	if !methodRateLimitAllow(req.Method) {
 		return types.NewResponse(req, nil, types.NewRPCError(types.InvalidParamsErrorCode, "server is too busy")).Bytes()
 	}
+	if rpcErr := apiKeyAllow(httpReq, req.Method); rpcErr != nil {
+		return types.NewResponse(req, nil, rpcErr).Bytes()
+	}
 
 	response, relayed := tryRelay(h.cfg.ApiRelay, req)
 	if !relayed {
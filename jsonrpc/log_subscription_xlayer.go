@@ -0,0 +1,174 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+)
+
+// logReplay switches a resumable log subscription from the replay of the stored logs to the
+// live logs, the live logs notified while replaying are buffered and only the logs after the
+// last notified one are sent, so the client gets every log once and in order
+type logReplay struct {
+	// last is the cursor of the last notified log, or the cursor the subscription resumes from
+	last *types.LogCursor
+	// replayedToBlock is the last block of the replay, the live logs up to it were already replayed
+	replayedToBlock uint64
+	done            bool
+	buffered        []types.Log
+	sync.Mutex
+}
+
+func newLogReplay(cursor *types.LogCursor) *logReplay {
+	return &logReplay{last: cursor}
+}
+
+// isResumable checks if the log subscription must replay the stored logs before the live ones
+func (f *LogFilter) isResumable() bool {
+	return f.BlockHash == nil && (f.FromBlock != nil || f.Cursor != nil)
+}
+
+// finish sends the replayed logs and the live logs buffered while replaying
+func (r *logReplay) finish(filter *Filter, replayed []types.Log, toBlock uint64) {
+	r.Lock()
+	defer r.Unlock()
+	r.send(filter, replayed)
+	r.replayedToBlock = toBlock
+	r.done = true
+	r.send(filter, r.buffered)
+	r.buffered = nil
+}
+
+// live sends the live logs, or buffers them until the replay finishes
+func (r *logReplay) live(filter *Filter, logs []types.Log) {
+	r.Lock()
+	defer r.Unlock()
+	if !r.done {
+		r.buffered = append(r.buffered, logs...)
+		return
+	}
+	r.send(filter, logs)
+}
+
+func (r *logReplay) send(filter *Filter, logs []types.Log) {
+	for _, l := range logs {
+		cursor := types.NewLogCursor(l)
+		if r.done && cursor.BlockNumber <= r.replayedToBlock {
+			continue
+		}
+		if r.last != nil && cursor.Cmp(*r.last) <= 0 {
+			continue
+		}
+		data, err := json.Marshal(types.LogWithCursor{Log: l, Cursor: cursor})
+		if err != nil {
+			log.Errorf("failed to marshal ethLog response to subscription: %v", err)
+			continue
+		}
+		filter.EnqueueSubscriptionDataToBeSent(data)
+		r.last = &cursor
+	}
+}
+
+// newResumableLogFilter creates a log subscription that replays the stored logs from the
+// from block, or after the cursor, and then continues with the live logs
+func (e *EthEndpoints) newResumableLogFilter(ctx context.Context, wsConn *concurrentWsConn, filter LogFilter) (interface{}, types.Error) {
+	if e.isDisabled("eth_newFilter") {
+		return RPCErrorResponse(types.DefaultErrorCode, "not supported yet", nil, true)
+	}
+
+	if filter.ShouldFilterByBlockRange() {
+		_, _, rpcErr := filter.GetNumericBlockNumbers(ctx, e.cfg, e.state, e.etherman, nil)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+	}
+
+	var fromBlock uint64
+	if filter.Cursor != nil {
+		fromBlock = filter.Cursor.BlockNumber
+	} else {
+		bn, rpcErr := filter.FromBlock.GetNumericBlockNumber(ctx, e.state, e.etherman, nil)
+		if rpcErr != nil {
+			return nil, rpcErr
+		}
+		fromBlock = bn
+	}
+
+	// The filter is created before reading the stored logs, so the logs of the blocks
+	// stored meanwhile are buffered as live logs and none is missed
+	replay := newLogReplay(filter.Cursor)
+	filter.replay = replay
+	id, err := e.storage.NewLogFilter(wsConn, filter)
+	if errors.Is(err, ErrFilterInvalidPayload) {
+		return RPCErrorResponse(types.InvalidParamsErrorCode, err.Error(), nil, false)
+	} else if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to create new log filter", err, true)
+	}
+
+	replayed, toBlock, rpcErr := e.getLogsToReplay(ctx, filter, fromBlock)
+	if rpcErr != nil {
+		if err := e.storage.UninstallFilter(id); err != nil {
+			log.Errorf("failed to uninstall log filter %v: %v", id, err)
+		}
+		return nil, rpcErr
+	}
+	f, err := e.storage.GetFilter(id)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to get log filter", err, true)
+	}
+
+	// The replayed logs are sent after the subscription id
+	wsConn.afterResponse(func() {
+		replay.finish(f, replayed, toBlock)
+	})
+	return id, nil
+}
+
+// getLogsToReplay gets the stored logs of the subscription from the from block up to the
+// last stored block, respecting the limits of eth_getLogs
+func (e *EthEndpoints) getLogsToReplay(ctx context.Context, filter LogFilter, fromBlock uint64) ([]types.Log, uint64, types.Error) {
+	toBlock, err := e.state.GetLastL2BlockNumber(ctx, nil)
+	if err != nil {
+		_, rpcErr := RPCErrorResponse(types.DefaultErrorCode, "failed to get the last block number from state", err, true)
+		return nil, 0, rpcErr
+	}
+	if filter.ToBlock != nil {
+		bn, rpcErr := filter.ToBlock.GetNumericBlockNumber(ctx, e.state, e.etherman, nil)
+		if rpcErr != nil {
+			return nil, 0, rpcErr
+		}
+		if bn < toBlock {
+			toBlock = bn
+		}
+	}
+	if fromBlock > toBlock {
+		return []types.Log{}, toBlock, nil
+	}
+	if e.cfg.MaxLogsBlockRange > 0 && toBlock-fromBlock > e.cfg.MaxLogsBlockRange {
+		errMsg := fmt.Sprintf(state.ErrMaxLogsBlockRangeLimitExceeded.Error(), e.cfg.MaxLogsBlockRange)
+		_, rpcErr := RPCErrorResponse(types.InvalidParamsErrorCode, errMsg, nil, false)
+		return nil, 0, rpcErr
+	}
+
+	logs, err := e.state.GetLogs(ctx, fromBlock, toBlock, filter.Addresses, filter.Topics, nil, nil, nil)
+	if errors.Is(err, state.ErrMaxLogsCountLimitExceeded) {
+		errMsg := fmt.Sprintf(state.ErrMaxLogsCountLimitExceeded.Error(), e.cfg.MaxLogsCount)
+		_, rpcErr := RPCErrorResponse(types.InvalidParamsErrorCode, errMsg, nil, false)
+		return nil, 0, rpcErr
+	} else if err != nil {
+		_, rpcErr := RPCErrorResponse(types.DefaultErrorCode, "failed to get logs from state", err, true)
+		return nil, 0, rpcErr
+	}
+
+	result := make([]types.Log, 0, len(logs))
+	for _, l := range logs {
+		result = append(result, types.NewLog(*l))
+	}
+	return result, toBlock, nil
+}
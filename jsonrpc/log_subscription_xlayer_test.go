@@ -0,0 +1,165 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReplayLog(blockNumber, txIndex, logIndex uint64) types.Log {
+	return types.Log{
+		Topics:      []common.Hash{},
+		Data:        []byte{},
+		BlockNumber: types.ArgUint64(blockNumber),
+		TxIndex:     types.ArgUint64(txIndex),
+		LogIndex:    types.ArgUint64(logIndex),
+	}
+}
+
+func popNotifiedCursors(t *testing.T, filter *Filter) []types.LogCursor {
+	cursors := []types.LogCursor{}
+	for {
+		data, err := filter.wsQueue.Pop()
+		if err == state.ErrQueueEmpty {
+			return cursors
+		}
+		require.NoError(t, err)
+		var l types.LogWithCursor
+		require.NoError(t, json.Unmarshal(data, &l))
+		assert.Equal(t, types.NewLogCursor(l.Log), l.Cursor)
+		cursors = append(cursors, l.Cursor)
+	}
+}
+
+func TestLogReplay(t *testing.T) {
+	filter := &Filter{
+		ID:            "0x1",
+		Type:          FilterTypeLog,
+		wsQueue:       state.NewQueue[[]byte](),
+		wsQueueSignal: sync.NewCond(&sync.Mutex{}),
+	}
+	r := newLogReplay(&types.LogCursor{BlockNumber: 10, LogIndex: 1})
+
+	// The live logs are buffered while replaying
+	r.live(filter, []types.Log{newTestReplayLog(11, 0, 0), newTestReplayLog(12, 0, 0)})
+	assert.Empty(t, popNotifiedCursors(t, filter))
+
+	// The replay resumes after the cursor and the buffered logs already replayed are skipped
+	replayed := []types.Log{newTestReplayLog(10, 0, 0), newTestReplayLog(10, 0, 1), newTestReplayLog(10, 1, 0), newTestReplayLog(11, 0, 0)}
+	r.finish(filter, replayed, 11)
+	assert.Equal(t, []types.LogCursor{
+		{BlockNumber: 10, TxIndex: 1, LogIndex: 0},
+		{BlockNumber: 11, TxIndex: 0, LogIndex: 0},
+		{BlockNumber: 12, TxIndex: 0, LogIndex: 0},
+	}, popNotifiedCursors(t, filter))
+
+	// The live logs are sent once the replay finished
+	r.live(filter, []types.Log{newTestReplayLog(12, 0, 0), newTestReplayLog(13, 2, 1)})
+	assert.Equal(t, []types.LogCursor{{BlockNumber: 13, TxIndex: 2, LogIndex: 1}}, popNotifiedCursors(t, filter))
+}
+
+func TestLogCursor(t *testing.T) {
+	cursor := types.LogCursor{BlockNumber: 1000, TxIndex: 2, LogIndex: 3}
+	text, err := cursor.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "0x00000000000003e80000000200000003", string(text))
+
+	var decoded types.LogCursor
+	require.NoError(t, decoded.UnmarshalText(text))
+	assert.Equal(t, cursor, decoded)
+	assert.Error(t, decoded.UnmarshalText([]byte("0x1234")))
+
+	assert.Equal(t, -1, cursor.Cmp(types.LogCursor{BlockNumber: 1000, TxIndex: 3}))
+	assert.Equal(t, 1, cursor.Cmp(types.LogCursor{BlockNumber: 999, TxIndex: 5, LogIndex: 5}))
+	assert.Equal(t, 0, cursor.Cmp(decoded))
+}
+
+func TestSubscribeResumableLogs(t *testing.T) {
+	s, m, _ := newSequencerMockedServer(t)
+	defer s.Stop()
+
+	address := common.HexToAddress("0x1")
+	filter := &Filter{
+		ID:            "0x1",
+		Type:          FilterTypeLog,
+		wsQueue:       state.NewQueue[[]byte](),
+		wsQueueSignal: sync.NewCond(&sync.Mutex{}),
+	}
+	m.Storage.
+		On("NewLogFilter", mock.IsType(&concurrentWsConn{}), mock.IsType(LogFilter{})).
+		Run(func(args mock.Arguments) {
+			filter.WsConn = args.Get(0).(*concurrentWsConn)
+			filter.Parameters = args.Get(1)
+			go filter.SendEnqueuedSubscriptionData()
+		}).
+		Return("0x1", nil).
+		Once()
+	m.Storage.
+		On("GetFilter", "0x1").
+		Return(filter, nil).
+		Once()
+	m.State.
+		On("GetLastL2BlockNumber", context.Background(), nil).
+		Return(uint64(5), nil).
+		Twice()
+	m.State.
+		On("GetLogs", context.Background(), uint64(3), uint64(5), []common.Address{address}, mock.Anything, (*common.Hash)(nil), (*time.Time)(nil), nil).
+		Return([]*ethTypes.Log{
+			{Address: address, Topics: []common.Hash{}, Data: []byte{}, BlockNumber: 3, TxIndex: 1},
+			{Address: address, Topics: []common.Hash{}, Data: []byte{}, BlockNumber: 5, Index: 2},
+		}, nil).
+		Once()
+
+	c := s.GetWSClient()
+	ch := make(chan types.LogWithCursor, 10)
+	sub, err := c.Client().EthSubscribe(context.Background(), ch, "logs", map[string]interface{}{
+		"fromBlock": "0x3",
+		"address":   address,
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, sub)
+
+	expected := []types.LogCursor{{BlockNumber: 3, TxIndex: 1}, {BlockNumber: 5, LogIndex: 2}}
+	for _, cursor := range expected {
+		select {
+		case l := <-ch:
+			assert.Equal(t, cursor, l.Cursor)
+			assert.Equal(t, address, l.Address)
+		case <-time.After(5 * time.Second):
+			t.Fatal("replayed log not received")
+		}
+	}
+
+	// A cursor too far behind the last block fails like eth_getLogs
+	m.Storage.
+		On("NewLogFilter", mock.IsType(&concurrentWsConn{}), mock.IsType(LogFilter{})).
+		Return("0x2", nil).
+		Once()
+	m.Storage.
+		On("UninstallFilter", "0x2").
+		Return(nil).
+		Once()
+	m.State.
+		On("GetLastL2BlockNumber", context.Background(), nil).
+		Return(uint64(20000), nil).
+		Once()
+
+	_, err = c.Client().EthSubscribe(context.Background(), ch, "logs", map[string]interface{}{
+		"cursor": types.LogCursor{BlockNumber: 1},
+	})
+	require.Error(t, err)
+	rpcErr := err.(rpc.Error)
+	assert.Equal(t, types.InvalidParamsErrorCode, rpcErr.ErrorCode())
+	assert.Equal(t, "logs are limited to a 10000 block range", rpcErr.Error())
+}
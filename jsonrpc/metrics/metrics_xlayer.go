@@ -17,6 +17,8 @@ var (
 	requestAuthErrorCountName   = requestPrefix + "auth_error_count"
 	requestRelayFailCountName   = requestPrefix + "relay_fail_count"
 	requestBatchSizeName        = requestPrefix + "batch_size"
+	requestKeyComputeUnitsName  = requestPrefix + "key_compute_units"
+	requestKeyRejectedCountName = requestPrefix + "key_rejected_count"
 
 	wsRequestPrefix             = prefix + "ws_request_"
 	requestWsMethodName         = wsRequestPrefix + "method"
@@ -121,6 +123,20 @@ var (
 			},
 			Labels: []string{"type"},
 		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: requestKeyComputeUnitsName,
+				Help: "[JSONRPC] number of compute units used by the api keys",
+			},
+			Labels: []string{"project"},
+		},
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: requestKeyRejectedCountName,
+				Help: "[JSONRPC] number of requests rejected by the policies of the api keys",
+			},
+			Labels: []string{"project", "type"},
+		},
 	}
 )
 
@@ -134,6 +150,18 @@ const (
 	RequestAuthErrorTypeNoAuth RequestAuthErrorType = "no_auth"
 )
 
+// RequestKeyRejectedType request rejected by an api key policy type
+type RequestKeyRejectedType string
+
+const (
+	// RequestKeyRejectedTypeMethodNotAllowed represents a request to a method the api key can't call.
+	RequestKeyRejectedTypeMethodNotAllowed RequestKeyRejectedType = "method_not_allowed"
+	// RequestKeyRejectedTypeRateLimit represents a request over the requests per second of the api key.
+	RequestKeyRejectedTypeRateLimit RequestKeyRejectedType = "rate_limit"
+	// RequestKeyRejectedTypeComputeUnits represents a request over the daily compute units of the api key.
+	RequestKeyRejectedTypeComputeUnits RequestKeyRejectedType = "compute_units"
+)
+
 // WsRequestMethodDuration observes (histogram) the duration of a ws request from the
 // provided starting time.
 func WsRequestMethodDuration(method string, start time.Time) {
@@ -202,3 +230,13 @@ func RequestRelayFailCount(method string) {
 func RequestBatchSize(batchSize int) {
 	metrics.GaugeSet(requestBatchSizeName, float64(batchSize))
 }
+
+// RequestKeyComputeUnits increments the compute units counter vector by the given units for the given project.
+func RequestKeyComputeUnits(project string, units uint64) {
+	metrics.CounterVecAdd(requestKeyComputeUnitsName, project, float64(units))
+}
+
+// RequestKeyRejectedCount increments the rejected requests counter vector by one for the given project and type.
+func RequestKeyRejectedCount(project string, tp RequestKeyRejectedType) {
+	metrics.CounterVecIncWithLabels(requestKeyRejectedCountName, project, string(tp))
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 // AddInnerTx provides a mock function with given fields: ctx, txHash, innerTx
@@ -119,3 +121,230 @@ func (_m *PoolMock) GetReadyTxCount(ctx context.Context) (uint64, error) {
 func (_m *PoolMock) IsFreeGasAddr(ctx context.Context, addr common.Address) (bool, error) {
 	return false, nil
 }
+
+// GetContent provides a mock function with given fields: ctx, limit
+func (_m *PoolMock) GetContent(ctx context.Context, limit uint64) (*pool.Content, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContent")
+	}
+
+	var r0 *pool.Content
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) (*pool.Content, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) *pool.Content); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pool.Content)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetContentFrom provides a mock function with given fields: ctx, from
+func (_m *PoolMock) GetContentFrom(ctx context.Context, from common.Address) (*pool.Content, error) {
+	ret := _m.Called(ctx, from)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContentFrom")
+	}
+
+	var r0 *pool.Content
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) (*pool.Content, error)); ok {
+		return rf(ctx, from)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address) *pool.Content); ok {
+		r0 = rf(ctx, from)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pool.Content)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address) error); ok {
+		r1 = rf(ctx, from)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountContent provides a mock function with given fields: ctx
+func (_m *PoolMock) CountContent(ctx context.Context) (uint64, uint64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountContent")
+	}
+
+	var r0 uint64
+	var r1 uint64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context) (uint64, uint64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) uint64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) uint64); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(uint64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// EvictTx provides a mock function with given fields: ctx, hash
+func (_m *PoolMock) EvictTx(ctx context.Context, hash common.Hash) error {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvictTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) error); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EvictTxsByFromAndNonce provides a mock function with given fields: ctx, from, nonce
+func (_m *PoolMock) EvictTxsByFromAndNonce(ctx context.Context, from common.Address, nonce uint64) ([]common.Hash, error) {
+	ret := _m.Called(ctx, from, nonce)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvictTxsByFromAndNonce")
+	}
+
+	var r0 []common.Hash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64) ([]common.Hash, error)); ok {
+		return rf(ctx, from, nonce)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64) []common.Hash); ok {
+		r0 = rf(ctx, from, nonce)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]common.Hash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, uint64) error); ok {
+		r1 = rf(ctx, from, nonce)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddPrivateTx provides a mock function with given fields: ctx, tx, ip
+func (_m *PoolMock) AddPrivateTx(ctx context.Context, tx types.Transaction, ip string) error {
+	ret := _m.Called(ctx, tx, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddPrivateTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Transaction, string) error); ok {
+		r0 = rf(ctx, tx, ip)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// AddConditionalTx provides a mock function with given fields: ctx, tx, ip, conditional
+func (_m *PoolMock) AddConditionalTx(ctx context.Context, tx types.Transaction, ip string, conditional pool.TxConditional) error {
+	ret := _m.Called(ctx, tx, ip, conditional)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddConditionalTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Transaction, string, pool.TxConditional) error); ok {
+		r0 = rf(ctx, tx, ip, conditional)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CheckTx provides a mock function with given fields: ctx, tx, ip
+func (_m *PoolMock) CheckTx(ctx context.Context, tx types.Transaction, ip string) (*pool.TxCheckResult, error) {
+	ret := _m.Called(ctx, tx, ip)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckTx")
+	}
+
+	var r0 *pool.TxCheckResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Transaction, string) (*pool.TxCheckResult, error)); ok {
+		return rf(ctx, tx, ip)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.Transaction, string) *pool.TxCheckResult); ok {
+		r0 = rf(ctx, tx, ip)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*pool.TxCheckResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.Transaction, string) error); ok {
+		r1 = rf(ctx, tx, ip)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddTxWithValidityWindow provides a mock function with given fields: ctx, tx, ip, window
+func (_m *PoolMock) AddTxWithValidityWindow(ctx context.Context, tx types.Transaction, ip string, window pool.TxValidityWindow) error {
+	ret := _m.Called(ctx, tx, ip, window)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTxWithValidityWindow")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Transaction, string, pool.TxValidityWindow) error); ok {
+		r0 = rf(ctx, tx, ip, window)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -5,6 +5,18 @@ package mocks
 import (
 	context "context"
 
+	big "math/big"
+
+	merkletree "github.com/0xPolygonHermez/zkevm-node/merkletree"
+
+	runtime "github.com/0xPolygonHermez/zkevm-node/state/runtime"
+
+	state "github.com/0xPolygonHermez/zkevm-node/state"
+
+	common "github.com/ethereum/go-ethereum/common"
+
+	coretypes "github.com/ethereum/go-ethereum/core/types"
+
 	pgx "github.com/jackc/pgx/v4"
 )
 
@@ -109,3 +121,398 @@ func (_m *StateMock)GetLastL2BlockTimeByBatchNumber(ctx context.Context, batchNu
 
 	return r0, r1
 }
+
+// DebugCall provides a mock function with given fields: ctx, tx, senderAddress, l2BlockNumber, stateOverride, blockOverrides, traceConfig, dbTx
+func (_m *StateMock) DebugCall(ctx context.Context, tx *coretypes.Transaction, senderAddress common.Address, l2BlockNumber *uint64, stateOverride state.StateOverride, blockOverrides *state.BlockOverrides, traceConfig state.TraceConfig, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+	ret := _m.Called(ctx, tx, senderAddress, l2BlockNumber, stateOverride, blockOverrides, traceConfig, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DebugCall")
+	}
+
+	var r0 *runtime.ExecutionResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, state.StateOverride, *state.BlockOverrides, state.TraceConfig, pgx.Tx) (*runtime.ExecutionResult, error)); ok {
+		return rf(ctx, tx, senderAddress, l2BlockNumber, stateOverride, blockOverrides, traceConfig, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, state.StateOverride, *state.BlockOverrides, state.TraceConfig, pgx.Tx) *runtime.ExecutionResult); ok {
+		r0 = rf(ctx, tx, senderAddress, l2BlockNumber, stateOverride, blockOverrides, traceConfig, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*runtime.ExecutionResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, state.StateOverride, *state.BlockOverrides, state.TraceConfig, pgx.Tx) error); ok {
+		r1 = rf(ctx, tx, senderAddress, l2BlockNumber, stateOverride, blockOverrides, traceConfig, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ProcessUnsignedTransactionWithStateOverride provides a mock function with given fields: ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, stateOverride, dbTx
+func (_m *StateMock) ProcessUnsignedTransactionWithStateOverride(ctx context.Context, tx *coretypes.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, stateOverride state.StateOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+	ret := _m.Called(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, stateOverride, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProcessUnsignedTransactionWithStateOverride")
+	}
+
+	var r0 *runtime.ExecutionResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, bool, state.StateOverride, pgx.Tx) (*runtime.ExecutionResult, error)); ok {
+		return rf(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, stateOverride, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, bool, state.StateOverride, pgx.Tx) *runtime.ExecutionResult); ok {
+		r0 = rf(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, stateOverride, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*runtime.ExecutionResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *coretypes.Transaction, common.Address, *uint64, bool, state.StateOverride, pgx.Tx) error); ok {
+		r1 = rf(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, stateOverride, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EstimateGasWithStateOverride provides a mock function with given fields: transaction, senderAddress, isGasFreeSender, l2BlockNumber, stateOverride, dbTx
+func (_m *StateMock) EstimateGasWithStateOverride(transaction *coretypes.Transaction, senderAddress common.Address, isGasFreeSender bool, l2BlockNumber *uint64, stateOverride state.StateOverride, dbTx pgx.Tx) (uint64, []byte, error) {
+	ret := _m.Called(transaction, senderAddress, isGasFreeSender, l2BlockNumber, stateOverride, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EstimateGasWithStateOverride")
+	}
+
+	var r0 uint64
+	var r1 []byte
+	var r2 error
+	if rf, ok := ret.Get(0).(func(*coretypes.Transaction, common.Address, bool, *uint64, state.StateOverride, pgx.Tx) (uint64, []byte, error)); ok {
+		return rf(transaction, senderAddress, isGasFreeSender, l2BlockNumber, stateOverride, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(*coretypes.Transaction, common.Address, bool, *uint64, state.StateOverride, pgx.Tx) uint64); ok {
+		r0 = rf(transaction, senderAddress, isGasFreeSender, l2BlockNumber, stateOverride, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(*coretypes.Transaction, common.Address, bool, *uint64, state.StateOverride, pgx.Tx) []byte); ok {
+		r1 = rf(transaction, senderAddress, isGasFreeSender, l2BlockNumber, stateOverride, dbTx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(*coretypes.Transaction, common.Address, bool, *uint64, state.StateOverride, pgx.Tx) error); ok {
+		r2 = rf(transaction, senderAddress, isGasFreeSender, l2BlockNumber, stateOverride, dbTx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// SimulateBundle provides a mock function with given fields: ctx, txs, senderAddress, l2BlockNumber, stateOverride, dbTx
+func (_m *StateMock) SimulateBundle(ctx context.Context, txs []*coretypes.Transaction, senderAddress common.Address, l2BlockNumber *uint64, stateOverride state.StateOverride, dbTx pgx.Tx) (*state.ProcessBatchResponse, error) {
+	ret := _m.Called(ctx, txs, senderAddress, l2BlockNumber, stateOverride, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SimulateBundle")
+	}
+
+	var r0 *state.ProcessBatchResponse
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*coretypes.Transaction, common.Address, *uint64, state.StateOverride, pgx.Tx) (*state.ProcessBatchResponse, error)); ok {
+		return rf(ctx, txs, senderAddress, l2BlockNumber, stateOverride, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*coretypes.Transaction, common.Address, *uint64, state.StateOverride, pgx.Tx) *state.ProcessBatchResponse); ok {
+		r0 = rf(ctx, txs, senderAddress, l2BlockNumber, stateOverride, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.ProcessBatchResponse)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*coretypes.Transaction, common.Address, *uint64, state.StateOverride, pgx.Tx) error); ok {
+		r1 = rf(ctx, txs, senderAddress, l2BlockNumber, stateOverride, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAccountProof provides a mock function with given fields: ctx, address, positions, root
+func (_m *StateMock) GetAccountProof(ctx context.Context, address common.Address, positions []*big.Int, root common.Hash) (*merkletree.AccountProof, error) {
+	ret := _m.Called(ctx, address, positions, root)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAccountProof")
+	}
+
+	var r0 *merkletree.AccountProof
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, []*big.Int, common.Hash) (*merkletree.AccountProof, error)); ok {
+		return rf(ctx, address, positions, root)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, []*big.Int, common.Hash) *merkletree.AccountProof); ok {
+		r0 = rf(ctx, address, positions, root)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*merkletree.AccountProof)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, []*big.Int, common.Hash) error); ok {
+		r1 = rf(ctx, address, positions, root)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceiptsByL2BlockNumber provides a mock function with given fields: ctx, blockNumber, dbTx
+func (_m *StateMock) GetReceiptsByL2BlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]state.TransactionReceipt, error) {
+	ret := _m.Called(ctx, blockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceiptsByL2BlockNumber")
+	}
+
+	var r0 []state.TransactionReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) ([]state.TransactionReceipt, error)); ok {
+		return rf(ctx, blockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) []state.TransactionReceipt); ok {
+		r0 = rf(ctx, blockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.TransactionReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, blockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceiptsByBatchNumber provides a mock function with given fields: ctx, batchNumber, dbTx
+func (_m *StateMock) GetReceiptsByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]state.TransactionReceipt, error) {
+	ret := _m.Called(ctx, batchNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceiptsByBatchNumber")
+	}
+
+	var r0 []state.TransactionReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) ([]state.TransactionReceipt, error)); ok {
+		return rf(ctx, batchNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) []state.TransactionReceipt); ok {
+		r0 = rf(ctx, batchNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.TransactionReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, batchNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceiptsByAddressBefore provides a mock function with given fields: ctx, address, blockNumber, pageSize, dbTx
+func (_m *StateMock) GetReceiptsByAddressBefore(ctx context.Context, address common.Address, blockNumber uint64, pageSize uint64, dbTx pgx.Tx) ([]state.TransactionReceipt, error) {
+	ret := _m.Called(ctx, address, blockNumber, pageSize, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceiptsByAddressBefore")
+	}
+
+	var r0 []state.TransactionReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64, uint64, pgx.Tx) ([]state.TransactionReceipt, error)); ok {
+		return rf(ctx, address, blockNumber, pageSize, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64, uint64, pgx.Tx) []state.TransactionReceipt); ok {
+		r0 = rf(ctx, address, blockNumber, pageSize, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.TransactionReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, uint64, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, address, blockNumber, pageSize, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetReceiptsByAddressAfter provides a mock function with given fields: ctx, address, blockNumber, pageSize, dbTx
+func (_m *StateMock) GetReceiptsByAddressAfter(ctx context.Context, address common.Address, blockNumber uint64, pageSize uint64, dbTx pgx.Tx) ([]state.TransactionReceipt, error) {
+	ret := _m.Called(ctx, address, blockNumber, pageSize, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetReceiptsByAddressAfter")
+	}
+
+	var r0 []state.TransactionReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64, uint64, pgx.Tx) ([]state.TransactionReceipt, error)); ok {
+		return rf(ctx, address, blockNumber, pageSize, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64, uint64, pgx.Tx) []state.TransactionReceipt); ok {
+		r0 = rf(ctx, address, blockNumber, pageSize, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]state.TransactionReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, uint64, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, address, blockNumber, pageSize, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetContractCreationReceipt provides a mock function with given fields: ctx, address, dbTx
+func (_m *StateMock) GetContractCreationReceipt(ctx context.Context, address common.Address, dbTx pgx.Tx) (*state.TransactionReceipt, error) {
+	ret := _m.Called(ctx, address, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetContractCreationReceipt")
+	}
+
+	var r0 *state.TransactionReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, pgx.Tx) (*state.TransactionReceipt, error)); ok {
+		return rf(ctx, address, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, pgx.Tx) *state.TransactionReceipt); ok {
+		r0 = rf(ctx, address, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.TransactionReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, pgx.Tx) error); ok {
+		r1 = rf(ctx, address, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTransactionBySenderAndNonce provides a mock function with given fields: ctx, sender, nonce, dbTx
+func (_m *StateMock) GetTransactionBySenderAndNonce(ctx context.Context, sender common.Address, nonce uint64, dbTx pgx.Tx) (*coretypes.Transaction, error) {
+	ret := _m.Called(ctx, sender, nonce, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTransactionBySenderAndNonce")
+	}
+
+	var r0 *coretypes.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64, pgx.Tx) (*coretypes.Transaction, error)); ok {
+		return rf(ctx, sender, nonce, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Address, uint64, pgx.Tx) *coretypes.Transaction); ok {
+		r0 = rf(ctx, sender, nonce, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*coretypes.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Address, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, sender, nonce, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFirstAddressIndexedL2BlockNumber provides a mock function with given fields: ctx, dbTx
+func (_m *StateMock) GetFirstAddressIndexedL2BlockNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	ret := _m.Called(ctx, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetFirstAddressIndexedL2BlockNumber")
+	}
+
+	var r0 uint64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) (uint64, error)); ok {
+		return rf(ctx, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, pgx.Tx) uint64); ok {
+		r0 = rf(ctx, dbTx)
+	} else {
+		r0 = ret.Get(0).(uint64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, pgx.Tx) error); ok {
+		r1 = rf(ctx, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetBlockByNumber provides a mock function with given fields: ctx, blockNumber, dbTx
+func (_m *StateMock) GetBlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.Block, error) {
+	ret := _m.Called(ctx, blockNumber, dbTx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockByNumber")
+	}
+
+	var r0 *state.Block
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) (*state.Block, error)); ok {
+		return rf(ctx, blockNumber, dbTx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64, pgx.Tx) *state.Block); ok {
+		r0 = rf(ctx, blockNumber, dbTx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*state.Block)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64, pgx.Tx) error); ok {
+		r1 = rf(ctx, blockNumber, dbTx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
@@ -0,0 +1,197 @@
+package jsonrpc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// preconfirmedTransactionsSubscription is the eth_subscribe topic of the preconfirmations
+	preconfirmedTransactionsSubscription = "zkevm_preconfirmedTransactions"
+
+	preconfirmationRelayRetryInterval = 5 * time.Second
+)
+
+// preconfirmations tracks the txs executed by the sequencer in the WIP L2 block until their
+// L2 block is stored in this node, each tx is notified as unconfirmed and then as confirmed
+// when it's in the stored L2 block or as reorged when it's not
+type preconfirmations struct {
+	pending         map[common.Hash]state.Preconfirmation
+	lastBlockNumber uint64
+	// notify is called with the lock held to keep the order of the notifications of a tx
+	notify func(state.Preconfirmation)
+	sync.Mutex
+}
+
+func newPreconfirmations(notify func(state.Preconfirmation)) *preconfirmations {
+	return &preconfirmations{
+		pending: make(map[common.Hash]state.Preconfirmation),
+		notify:  notify,
+	}
+}
+
+// add tracks and notifies an unconfirmed tx, the txs of the L2 blocks already stored are ignored
+func (p *preconfirmations) add(preconfirmation state.Preconfirmation) {
+	p.Lock()
+	defer p.Unlock()
+	if uint64(preconfirmation.BlockNumber) <= p.lastBlockNumber {
+		return
+	}
+	p.pending[preconfirmation.TxHash] = preconfirmation
+	p.notify(preconfirmation)
+}
+
+// get returns the preconfirmation of a tx executed in the WIP L2 block whose L2 block isn't stored yet
+func (p *preconfirmations) get(txHash common.Hash) (state.Preconfirmation, bool) {
+	p.Lock()
+	defer p.Unlock()
+	preconfirmation, ok := p.pending[txHash]
+	return preconfirmation, ok
+}
+
+// resolve notifies the pending txs of the stored L2 block as confirmed if they are in the block
+// or as reorged otherwise, the L2 blocks must be resolved in order
+func (p *preconfirmations) resolve(block *state.L2Block, logs []*ethTypes.Log) {
+	p.Lock()
+	defer p.Unlock()
+	number := block.NumberU64()
+	if number > p.lastBlockNumber {
+		p.lastBlockNumber = number
+	}
+	if len(p.pending) == 0 {
+		return
+	}
+
+	included := make(map[common.Hash]struct{}, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		included[tx.Hash()] = struct{}{}
+	}
+	blockHash := block.Hash()
+	for txHash, preconfirmation := range p.pending {
+		if uint64(preconfirmation.BlockNumber) > number {
+			continue
+		}
+		delete(p.pending, txHash)
+
+		if _, ok := included[txHash]; ok {
+			preconfirmation.Status = state.PreconfirmationStatusConfirmed
+			preconfirmation.BlockNumber = hexutil.Uint64(number)
+			preconfirmation.BlockHash = &blockHash
+			preconfirmation.Logs = []*ethTypes.Log{}
+			for _, l := range logs {
+				if l.TxHash == txHash {
+					preconfirmation.Logs = append(preconfirmation.Logs, l)
+				}
+			}
+		} else {
+			// The logs of a reorged tx are flagged as removed like the logs of a reorged block
+			preconfirmation.Status = state.PreconfirmationStatusReorged
+			removedLogs := make([]*ethTypes.Log, 0, len(preconfirmation.Logs))
+			for _, l := range preconfirmation.Logs {
+				removedLog := *l
+				removedLog.Removed = true
+				removedLogs = append(removedLogs, &removedLog)
+			}
+			preconfirmation.Logs = removedLogs
+		}
+		p.notify(preconfirmation)
+	}
+}
+
+// newPreconfirmationFilter creates a subscription to the preconfirmations
+func (e *EthEndpoints) newPreconfirmationFilter(wsConn *concurrentWsConn) (interface{}, types.Error) {
+	if e.preconfirmations == nil {
+		return nil, types.NewRPCError(types.DefaultErrorCode, "not supported yet")
+	}
+	id, err := e.storage.NewPreconfirmationFilter(wsConn)
+	if err != nil {
+		return RPCErrorResponse(types.DefaultErrorCode, "failed to create new preconfirmation filter", err, true)
+	}
+	return id, nil
+}
+
+// notifyPreconfirmation sends a preconfirmation to the preconfirmation subscriptions
+func (e *EthEndpoints) notifyPreconfirmation(preconfirmation state.Preconfirmation) {
+	data, err := json.Marshal(preconfirmation)
+	if err != nil {
+		log.Errorf("failed to marshal preconfirmation response to subscription: %v", err)
+		return
+	}
+	for _, filter := range e.storage.GetAllPreconfirmationFiltersWithWSConn() {
+		filter.EnqueueSubscriptionDataToBeSent(data)
+	}
+}
+
+// relayPreconfirmations keeps a subscription to the preconfirmations of the sequencer, or of the trusted
+// RPC node they are relayed from, reconnecting when the connection is lost
+func (e *EthEndpoints) relayPreconfirmations() {
+	for {
+		err := e.subscribePreconfirmations()
+		log.Warnf("preconfirmations relay from %s stopped, retrying in %v, error: %v", e.cfg.PreconfirmationURI, preconfirmationRelayRetryInterval, err)
+		time.Sleep(preconfirmationRelayRetryInterval)
+	}
+}
+
+// subscribePreconfirmations subscribes to the preconfirmations and tracks the unconfirmed txs until the
+// connection is lost, the confirmed and reorged notifications are built by this node from its own L2 blocks
+func (e *EthEndpoints) subscribePreconfirmations() error {
+	var header http.Header
+	if e.cfg.PreconfirmationAuthKey != "" {
+		header = http.Header{"Authorization": []string{"Bearer " + e.cfg.PreconfirmationAuthKey}}
+	}
+	conn, _, err := websocket.DefaultDialer.Dial(e.cfg.PreconfirmationURI, header)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	params, err := json.Marshal([]string{preconfirmedTransactionsSubscription})
+	if err != nil {
+		return err
+	}
+	err = conn.WriteJSON(types.Request{JSONRPC: "2.0", ID: 1, Method: "eth_subscribe", Params: params})
+	if err != nil {
+		return err
+	}
+	log.Infof("subscribed to the preconfirmations of %s", e.cfg.PreconfirmationURI)
+
+	for {
+		_, message, err := conn.ReadMessage()
+		if err != nil {
+			return err
+		}
+
+		var notification types.SubscriptionResponse
+		if err := json.Unmarshal(message, &notification); err != nil {
+			return err
+		}
+		if notification.Method != "eth_subscription" {
+			// Response to the subscription request
+			var res types.Response
+			if err := json.Unmarshal(message, &res); err == nil && res.Error != nil {
+				return fmt.Errorf("failed to subscribe to the preconfirmations: %s", res.Error.Message)
+			}
+			continue
+		}
+
+		var preconfirmation state.Preconfirmation
+		if err := json.Unmarshal(notification.Params.Result, &preconfirmation); err != nil {
+			log.Errorf("failed to decode preconfirmation: %v", err)
+			continue
+		}
+		if preconfirmation.Status == state.PreconfirmationStatusUnconfirmed {
+			e.preconfirmations.add(preconfirmation)
+		}
+	}
+}
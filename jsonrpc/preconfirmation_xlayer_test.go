@@ -0,0 +1,169 @@
+package jsonrpc
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPreconfirmation(txHash common.Hash, blockNumber uint64) state.Preconfirmation {
+	return state.Preconfirmation{
+		Status:        state.PreconfirmationStatusUnconfirmed,
+		TxHash:        txHash,
+		BlockNumber:   hexutil.Uint64(blockNumber),
+		ReceiptStatus: 1,
+		GasUsed:       21000,
+		Logs:          []*ethTypes.Log{{Address: common.HexToAddress("0x1"), Topics: []common.Hash{}, Data: []byte{1}, TxHash: txHash, BlockNumber: blockNumber}},
+	}
+}
+
+func TestPreconfirmations(t *testing.T) {
+	notifications := []state.Preconfirmation{}
+	p := newPreconfirmations(func(preconfirmation state.Preconfirmation) {
+		notifications = append(notifications, preconfirmation)
+	})
+
+	tx := ethTypes.NewTransaction(1, common.HexToAddress("0x2"), big.NewInt(1), 21000, big.NewInt(1), nil)
+	reorgedTxHash := common.HexToHash("0x3")
+	nextBlockTxHash := common.HexToHash("0x4")
+
+	p.add(newTestPreconfirmation(tx.Hash(), 10))
+	p.add(newTestPreconfirmation(reorgedTxHash, 10))
+	p.add(newTestPreconfirmation(nextBlockTxHash, 11))
+	require.Len(t, notifications, 3)
+	for _, n := range notifications {
+		assert.Equal(t, state.PreconfirmationStatusUnconfirmed, n.Status)
+	}
+
+	header := state.NewL2Header(&ethTypes.Header{Number: big.NewInt(10)})
+	block := state.NewL2BlockWithHeader(header).WithBody([]*ethTypes.Transaction{tx}, nil)
+	blockLog := &ethTypes.Log{Address: common.HexToAddress("0x5"), TxHash: tx.Hash(), BlockHash: block.Hash(), BlockNumber: 10}
+	p.resolve(block, []*ethTypes.Log{blockLog, {TxHash: common.HexToHash("0x6")}})
+
+	require.Len(t, notifications, 5)
+	resolved := map[common.Hash]state.Preconfirmation{}
+	for _, n := range notifications[3:] {
+		resolved[n.TxHash] = n
+	}
+
+	confirmed := resolved[tx.Hash()]
+	assert.Equal(t, state.PreconfirmationStatusConfirmed, confirmed.Status)
+	require.NotNil(t, confirmed.BlockHash)
+	assert.Equal(t, block.Hash(), *confirmed.BlockHash)
+	assert.Equal(t, []*ethTypes.Log{blockLog}, confirmed.Logs)
+
+	reorged := resolved[reorgedTxHash]
+	assert.Equal(t, state.PreconfirmationStatusReorged, reorged.Status)
+	assert.Nil(t, reorged.BlockHash)
+	require.Len(t, reorged.Logs, 1)
+	assert.True(t, reorged.Logs[0].Removed)
+
+	// The tx of the next block is still pending and the txs of the stored blocks are ignored
+	assert.Len(t, p.pending, 1)
+	assert.Contains(t, p.pending, nextBlockTxHash)
+	p.add(newTestPreconfirmation(common.HexToHash("0x7"), 10))
+	assert.Len(t, notifications, 5)
+}
+
+func TestRelayPreconfirmations(t *testing.T) {
+	preconfirmation := newTestPreconfirmation(common.HexToHash("0x1"), 10)
+	confirmed := newTestPreconfirmation(common.HexToHash("0x2"), 9)
+	confirmed.Status = state.PreconfirmationStatusConfirmed
+
+	// The upstream node sends a confirmed tx, that is not relayed, and an unconfirmed tx after the subscription
+	upgrader := websocket.Upgrader{}
+	subscribed := make(chan types.Request, 1)
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		var req types.Request
+		require.NoError(t, conn.ReadJSON(&req))
+		subscribed <- req
+		require.NoError(t, conn.WriteJSON(map[string]interface{}{"jsonrpc": "2.0", "id": req.ID, "result": "0x1"}))
+		for _, p := range []state.Preconfirmation{confirmed, preconfirmation} {
+			result, err := json.Marshal(p)
+			require.NoError(t, err)
+			require.NoError(t, conn.WriteJSON(types.SubscriptionResponse{
+				JSONRPC: "2.0",
+				Method:  "eth_subscription",
+				Params:  types.SubscriptionResponseParams{Subscription: "0x1", Result: result},
+			}))
+		}
+		// Wait until the client closes the connection
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer upstream.Close()
+
+	storage := newStorageMock(t)
+	filter := &Filter{
+		ID:            "0x1",
+		Type:          FilterTypePreconfirmation,
+		wsQueue:       state.NewQueue[[]byte](),
+		wsQueueSignal: sync.NewCond(&sync.Mutex{}),
+	}
+	storage.On("GetAllPreconfirmationFiltersWithWSConn").Return([]*Filter{filter})
+
+	cfg := getSequencerDefaultConfig()
+	cfg.PreconfirmationURI = "ws" + strings.TrimPrefix(upstream.URL, "http")
+	e := &EthEndpoints{cfg: cfg, storage: storage}
+	e.preconfirmations = newPreconfirmations(e.notifyPreconfirmation)
+	go func() { _ = e.subscribePreconfirmations() }()
+
+	select {
+	case req := <-subscribed:
+		assert.Equal(t, "eth_subscribe", req.Method)
+		assert.JSONEq(t, `["zkevm_preconfirmedTransactions"]`, string(req.Params))
+	case <-time.After(5 * time.Second):
+		t.Fatal("subscription not received")
+	}
+
+	var data []byte
+	require.Eventually(t, func() bool {
+		d, err := filter.wsQueue.Pop()
+		data = d
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+
+	var relayed state.Preconfirmation
+	require.NoError(t, json.Unmarshal(data, &relayed))
+	assert.Equal(t, preconfirmation, relayed)
+	_, err := filter.wsQueue.Pop()
+	assert.ErrorIs(t, err, state.ErrQueueEmpty)
+}
+
+func TestSubscribePreconfirmedTransactions(t *testing.T) {
+	cfg := getSequencerDefaultConfig()
+	// The relay keeps retrying in background, the subscriptions only need it to be configured
+	cfg.PreconfirmationURI = "ws://127.0.0.1:1"
+	s, m, _ := newMockedServerWithCustomConfig(t, cfg)
+	defer s.Stop()
+
+	m.Storage.
+		On("NewPreconfirmationFilter", mock.IsType(&concurrentWsConn{})).
+		Return("0x1", nil).
+		Once()
+
+	c := s.GetWSClient()
+	ch := make(chan state.Preconfirmation)
+	sub, err := c.Client().EthSubscribe(context.Background(), ch, preconfirmedTransactionsSubscription)
+	require.NoError(t, err)
+	assert.NotNil(t, sub)
+}
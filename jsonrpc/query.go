@@ -108,6 +108,10 @@ type LogFilter struct {
 	Addresses []common.Address
 	Topics    [][]common.Hash
 	Since     *time.Time
+
+	// XLayer resumable log subscriptions
+	Cursor *types.LogCursor
+	replay *logReplay
 }
 
 // addTopic adds specific topics to the log filter topics
@@ -190,6 +194,9 @@ func (f *LogFilter) MarshalJSON() ([]byte, error) {
 		}
 	}
 
+	// XLayer resumable log subscriptions
+	obj.Cursor = f.Cursor
+
 	return json.Marshal(obj)
 }
 
@@ -204,6 +211,8 @@ func (f *LogFilter) UnmarshalJSON(data []byte) error {
 	}
 
 	f.BlockHash = obj.BlockHash
+	// XLayer resumable log subscriptions
+	f.Cursor = obj.Cursor
 	lbb := types.LatestBlockNumber
 
 	if obj.FromBlock != nil && *obj.FromBlock == "" {
@@ -81,6 +81,9 @@ func NewServer(
 	handler := newJSONRpcHandler()
 	handler.setCfg(cfg)
 
+	// XLayer
+	shareServices(services)
+
 	for _, service := range services {
 		handler.registerService(service)
 	}
@@ -320,6 +323,19 @@ func (s *Server) handleSingleRequest(httpRequest *http.Request, w http.ResponseW
 		}
 		return len(respbytes)
 	}
+	if rpcErr := apiKeyAllow(httpRequest, request.Method); rpcErr != nil {
+		respbytes, er := types.NewResponse(request, nil, rpcErr).Bytes()
+		if er != nil {
+			handleError(w, er)
+			return 0
+		}
+		_, er = w.Write(respbytes)
+		if er != nil {
+			handleError(w, er)
+			return 0
+		}
+		return len(respbytes)
+	}
 	defer metrics.RequestMethodCount(request.Method)
 	defer metrics.RequestMethodDuration(request.Method, st)
 
@@ -375,6 +391,10 @@ func (s *Server) handleBatchRequest(httpRequest *http.Request, w http.ResponseWr
 			responses = append(responses, types.NewResponse(request, nil, types.NewRPCError(types.InvalidParamsErrorCode, "server is too busy")))
 			continue
 		}
+		if rpcErr := apiKeyAllow(httpRequest, request.Method); rpcErr != nil {
+			responses = append(responses, types.NewResponse(request, nil, rpcErr))
+			continue
+		}
 		st := time.Now()
 		metrics.RequestMethodCount(request.Method)
 		response, relayed := tryRelay(s.config.ApiRelay, request)
@@ -476,6 +496,8 @@ func (s *Server) handleWs(w http.ResponseWriter, req *http.Request) {
 			} else {
 				_ = wsConn.WriteMessage(msgType, resp)
 			}
+			// XLayer send the notifications that must follow the response
+			wsConn.runAfterResponse()
 		}
 	}
 }
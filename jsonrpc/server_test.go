@@ -56,17 +56,20 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 		APIZKEVM:  true,
 		APITxPool: true,
 		APIWeb3:   true,
+		APITrace:  true,
+		APIOts:    true,
 	}
 
 	var newL2BlockEventHandler state.NewL2BlockEventHandler = func(e state.NewL2BlockEvent) {}
 	st.On("RegisterNewL2BlockEventHandler", mock.IsType(newL2BlockEventHandler)).Once()
 	st.On("StartToMonitorNewL2Blocks").Once()
 
+	ethEndpoints := NewEthEndpoints(cfg, chainID, pool, st, etherman, storage)
 	services := []Service{}
 	if _, ok := apis[APIEth]; ok {
 		services = append(services, Service{
 			Name:    APIEth,
-			Service: NewEthEndpoints(cfg, chainID, pool, st, etherman, storage),
+			Service: ethEndpoints,
 		})
 	}
 
@@ -87,7 +90,7 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 	if _, ok := apis[APITxPool]; ok {
 		services = append(services, Service{
 			Name:    APITxPool,
-			Service: &TxPoolEndpoints{},
+			Service: NewTxPoolEndpoints(cfg, pool),
 		})
 	}
 
@@ -98,6 +101,20 @@ func newMockedServer(t *testing.T, cfg Config) (*mockedServer, *mocksWrapper, *e
 		})
 	}
 
+	if _, ok := apis[APITrace]; ok {
+		services = append(services, Service{
+			Name:    APITrace,
+			Service: NewTraceEndpoints(cfg, st, etherman),
+		})
+	}
+
+	if _, ok := apis[APIOts]; ok {
+		services = append(services, Service{
+			Name:    APIOts,
+			Service: NewOtsEndpoints(cfg, st, etherman, ethEndpoints),
+		})
+	}
+
 	if _, ok := apis[APIWeb3]; ok {
 		services = append(services, Service{
 			Name:    APIWeb3,
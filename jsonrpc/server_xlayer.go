@@ -81,6 +81,10 @@ func (s *Server) handleWsBatch(httpRequest *http.Request, wsConn *concurrentWsCo
 			responses = append(responses, types.NewResponse(request, nil, types.NewRPCError(types.InvalidParamsErrorCode, "server is too busy")))
 			continue
 		}
+		if rpcErr := apiKeyAllow(httpRequest, request.Method); rpcErr != nil {
+			responses = append(responses, types.NewResponse(request, nil, rpcErr))
+			continue
+		}
 		st := time.Now()
 		metrics.RequestMethodCount(request.Method)
 		req := handleRequest{Request: request, wsConn: wsConn, HttpRequest: httpRequest}
@@ -118,3 +122,21 @@ func validateWsRequest(data []byte) error {
 
 	return nil
 }
+
+// shareServices shares the state kept by a service with the services that read it
+func shareServices(services []Service) {
+	var eth *EthEndpoints
+	var zkevm *ZKEVMEndpoints
+	for _, service := range services {
+		switch s := service.Service.(type) {
+		case *EthEndpoints:
+			eth = s
+		case *ZKEVMEndpoints:
+			zkevm = s
+		}
+	}
+	if eth != nil && zkevm != nil {
+		zkevm.preconfirmations = eth.preconfirmations
+		zkevm.dgpMan = &eth.dgpMan
+	}
+}
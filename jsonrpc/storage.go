@@ -25,6 +25,8 @@ type Storage struct {
 	blockFiltersWithWSConn     map[string]*Filter
 	logFiltersWithWSConn       map[string]*Filter
 	pendingTxFiltersWithWSConn map[string]*Filter
+	// XLayer preconfirmation filters, guarded by the pending tx mutex
+	preconfirmationFiltersWithWSConn map[string]*Filter
 
 	blockMutex     *sync.Mutex
 	logMutex       *sync.Mutex
@@ -42,6 +44,9 @@ func NewStorage() *Storage {
 		blockMutex:                 &sync.Mutex{},
 		logMutex:                   &sync.Mutex{},
 		pendingTxMutex:             &sync.Mutex{},
+
+		// XLayer preconfirmation filters
+		preconfirmationFiltersWithWSConn: make(map[string]*Filter),
 	}
 }
 
@@ -104,6 +109,8 @@ func (s *Storage) createFilter(t FilterType, parameters interface{}, wsConn *con
 			s.logFiltersWithWSConn[id] = f
 		} else if t == FilterTypePendingTx {
 			s.pendingTxFiltersWithWSConn[id] = f
+		} else if t == FilterTypePreconfirmation {
+			s.preconfirmationFiltersWithWSConn[id] = f
 		}
 	}
 	return id, nil
@@ -234,6 +241,8 @@ func (s *Storage) deleteFilter(filter *Filter) {
 		delete(s.logFiltersWithWSConn, filter.ID)
 	} else if filter.Type == FilterTypePendingTx {
 		delete(s.pendingTxFiltersWithWSConn, filter.ID)
+	} else if filter.Type == FilterTypePreconfirmation {
+		delete(s.preconfirmationFiltersWithWSConn, filter.ID)
 	}
 
 	if filter.WsConn != nil {
@@ -0,0 +1,23 @@
+package jsonrpc
+
+// FilterTypePreconfirmation represents a filter of type preconfirmation.
+const FilterTypePreconfirmation = "preconfirmation"
+
+// NewPreconfirmationFilter persists a new preconfirmation filter
+func (s *Storage) NewPreconfirmationFilter(wsConn *concurrentWsConn) (string, error) {
+	return s.createFilter(FilterTypePreconfirmation, nil, wsConn)
+}
+
+// GetAllPreconfirmationFiltersWithWSConn returns an array with all filter that have
+// a web socket connection and are subscribed to the preconfirmations
+func (s *Storage) GetAllPreconfirmationFiltersWithWSConn() []*Filter {
+	s.pendingTxMutex.Lock()
+	defer s.pendingTxMutex.Unlock()
+
+	filters := []*Filter{}
+	for _, filter := range s.preconfirmationFiltersWithWSConn {
+		f := filter
+		filters = append(filters, f)
+	}
+	return filters
+}
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// QuotaExceededErrorCode error code for requests over a limit of the api key
+	QuotaExceededErrorCode = -32005
+	// TxWIPErrorCode error code for evictions of txs being processed by the sequencer
+	TxWIPErrorCode = -32006
+
+	// QuotaRequestsPerSecond is the limit of requests per second of an api key
+	QuotaRequestsPerSecond = "requests_per_second"
+	// QuotaDailyComputeUnits is the limit of compute units per day of an api key
+	QuotaDailyComputeUnits = "daily_compute_units"
+)
+
+// QuotaExceededData is the data of the error of a request over a limit of the api key, encoded as json
+type QuotaExceededData struct {
+	Quota      string `json:"quota"`
+	Limit      uint64 `json:"limit"`
+	RetryAfter uint64 `json:"retryAfter"`
+}
+
+// NewQuotaExceededError creates the error of a request over a limit of the api key. The limit, its
+// value and the seconds to wait before retrying are returned as data so clients don't parse the message
+func NewQuotaExceededError(quota string, limit uint64, retryAfter time.Duration) *RPCError {
+	seconds := uint64(retryAfter.Round(time.Second) / time.Second)
+	if seconds == 0 {
+		seconds = 1
+	}
+	data, err := json.Marshal(QuotaExceededData{Quota: quota, Limit: limit, RetryAfter: seconds})
+	if err != nil {
+		return NewRPCError(QuotaExceededErrorCode, "quota exceeded: %s", quota)
+	}
+	return NewRPCErrorWithData(QuotaExceededErrorCode, "quota exceeded: %s", data, quota)
+}
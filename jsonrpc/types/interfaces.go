@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/merkletree"
 	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
@@ -31,6 +32,15 @@ type PoolInterface interface {
 	GetMinSuggestedGasPriceWithDelta(ctx context.Context, delta time.Duration) (uint64, error)
 	GetReadyTxCount(ctx context.Context) (uint64, error)
 	IsFreeGasAddr(ctx context.Context, addr common.Address) (bool, error)
+	GetContent(ctx context.Context, limit uint64) (*pool.Content, error)
+	GetContentFrom(ctx context.Context, from common.Address) (*pool.Content, error)
+	CountContent(ctx context.Context) (uint64, uint64, error)
+	EvictTx(ctx context.Context, hash common.Hash) error
+	EvictTxsByFromAndNonce(ctx context.Context, from common.Address, nonce uint64) ([]common.Hash, error)
+	AddPrivateTx(ctx context.Context, tx types.Transaction, ip string) error
+	AddConditionalTx(ctx context.Context, tx types.Transaction, ip string, conditional pool.TxConditional) error
+	CheckTx(ctx context.Context, tx types.Transaction, ip string) (*pool.TxCheckResult, error)
+	AddTxWithValidityWindow(ctx context.Context, tx types.Transaction, ip string, window pool.TxValidityWindow) error
 }
 
 // StateInterface gathers the methods required to interact with the state.
@@ -87,10 +97,38 @@ type StateInterface interface {
 
 	// GetLastL2BlockTimeByBatchNumber gets the last l2 block time in a batch by batch number X Layer handler
 	GetLastL2BlockTimeByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (uint64, error)
+	// ProcessUnsignedTransactionWithStateOverride processes an unsigned tx applying the state override set X Layer handler
+	ProcessUnsignedTransactionWithStateOverride(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, stateOverride state.StateOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error)
+	// EstimateGasWithStateOverride estimates the gas of a tx applying the state override set X Layer handler
+	EstimateGasWithStateOverride(transaction *types.Transaction, senderAddress common.Address, isGasFreeSender bool, l2BlockNumber *uint64, stateOverride state.StateOverride, dbTx pgx.Tx) (uint64, []byte, error)
+	// SimulateBundle executes an ordered list of unsigned txs in a single virtual l2 block X Layer handler
+	SimulateBundle(ctx context.Context, txs []*types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, stateOverride state.StateOverride, dbTx pgx.Tx) (*state.ProcessBatchResponse, error)
+	// GetAccountProof returns the merkle proofs of an account and some of its storage positions X Layer handler
+	GetAccountProof(ctx context.Context, address common.Address, positions []*big.Int, root common.Hash) (*merkletree.AccountProof, error)
+	// GetReceiptsByL2BlockNumber returns the txs of a l2 block along with their receipts X Layer handler
+	GetReceiptsByL2BlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]state.TransactionReceipt, error)
+	// GetReceiptsByBatchNumber returns the txs of all the l2 blocks of a batch along with their receipts X Layer handler
+	GetReceiptsByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]state.TransactionReceipt, error)
+	// GetReceiptsByAddressBefore returns a page of txs of an address before a l2 block along with their receipts X Layer handler
+	GetReceiptsByAddressBefore(ctx context.Context, address common.Address, blockNumber uint64, pageSize uint64, dbTx pgx.Tx) ([]state.TransactionReceipt, error)
+	// GetReceiptsByAddressAfter returns a page of txs of an address after a l2 block along with their receipts X Layer handler
+	GetReceiptsByAddressAfter(ctx context.Context, address common.Address, blockNumber uint64, pageSize uint64, dbTx pgx.Tx) ([]state.TransactionReceipt, error)
+	// GetContractCreationReceipt returns the tx that deployed a contract along with its receipt X Layer handler
+	GetContractCreationReceipt(ctx context.Context, address common.Address, dbTx pgx.Tx) (*state.TransactionReceipt, error)
+	// GetTransactionBySenderAndNonce returns the tx sent by an address with a given nonce X Layer handler
+	GetTransactionBySenderAndNonce(ctx context.Context, sender common.Address, nonce uint64, dbTx pgx.Tx) (*types.Transaction, error)
+	// GetFirstAddressIndexedL2BlockNumber returns the first l2 block whose txs are indexed by address X Layer handler
+	GetFirstAddressIndexedL2BlockNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
+	// DebugCall executes and traces an unsigned tx applying the state and block overrides X Layer handler
+	DebugCall(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, stateOverride state.StateOverride, blockOverrides *state.BlockOverrides, traceConfig state.TraceConfig, dbTx pgx.Tx) (*runtime.ExecutionResult, error)
+	// GetBlockByNumber returns a L1 block by number X Layer handler
+	GetBlockByNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) (*state.Block, error)
 }
 
 // EthermanInterface provides integration with L1
 type EthermanInterface interface {
 	GetSafeBlockNumber(ctx context.Context) (uint64, error)
 	GetFinalizedBlockNumber(ctx context.Context) (uint64, error)
+	// HeaderByNumber returns a L1 block header by number X Layer handler
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
 }
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+const logCursorLength = 16
+
+// LogCursor is the position of a log in the chain, logs are ordered by block number,
+// tx index and log index. It's encoded as an opaque hex string returned with each
+// notification of a resumable log subscription, to resume the subscription after it
+type LogCursor struct {
+	BlockNumber uint64
+	TxIndex     uint32
+	LogIndex    uint32
+}
+
+// NewLogCursor creates the cursor of a log
+func NewLogCursor(l Log) LogCursor {
+	return LogCursor{
+		BlockNumber: uint64(l.BlockNumber),
+		TxIndex:     uint32(l.TxIndex),
+		LogIndex:    uint32(l.LogIndex),
+	}
+}
+
+// Cmp compares two cursors, returns -1 if c is before other, 1 if c is after other and 0 if equal
+func (c LogCursor) Cmp(other LogCursor) int {
+	switch {
+	case c.BlockNumber != other.BlockNumber:
+		return cmpUint64(c.BlockNumber, other.BlockNumber)
+	case c.TxIndex != other.TxIndex:
+		return cmpUint64(uint64(c.TxIndex), uint64(other.TxIndex))
+	default:
+		return cmpUint64(uint64(c.LogIndex), uint64(other.LogIndex))
+	}
+}
+
+func cmpUint64(a, b uint64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// MarshalText marshals the cursor as an hex string
+func (c LogCursor) MarshalText() ([]byte, error) {
+	b := make([]byte, logCursorLength)
+	binary.BigEndian.PutUint64(b[0:8], c.BlockNumber)
+	binary.BigEndian.PutUint32(b[8:12], c.TxIndex)
+	binary.BigEndian.PutUint32(b[12:16], c.LogIndex)
+	return []byte("0x" + hex.EncodeToString(b)), nil
+}
+
+// UnmarshalText unmarshals the cursor from an hex string
+func (c *LogCursor) UnmarshalText(input []byte) error {
+	b, err := hex.DecodeString(strings.TrimPrefix(string(input), "0x"))
+	if err != nil || len(b) != logCursorLength {
+		return fmt.Errorf("invalid log cursor %q", string(input))
+	}
+	c.BlockNumber = binary.BigEndian.Uint64(b[0:8])
+	c.TxIndex = binary.BigEndian.Uint32(b[8:12])
+	c.LogIndex = binary.BigEndian.Uint32(b[12:16])
+	return nil
+}
+
+// LogWithCursor is a log notified to a resumable log subscription
+type LogWithCursor struct {
+	Log
+	Cursor LogCursor `json:"cursor"`
+}
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// OtsOperationTransfer is the type of an internal operation that transfers value
+	OtsOperationTransfer = 0
+	// OtsOperationSelfDestruct is the type of an internal operation that self destructs a contract
+	OtsOperationSelfDestruct = 1
+	// OtsOperationCreate is the type of an internal operation that deploys a contract with CREATE
+	OtsOperationCreate = 2
+	// OtsOperationCreate2 is the type of an internal operation that deploys a contract with CREATE2
+	OtsOperationCreate2 = 3
+)
+
+// OtsInternalOperation is an internal operation of a tx that moves value, returned by ots_getInternalOperations
+type OtsInternalOperation struct {
+	Type  int            `json:"type"`
+	From  common.Address `json:"from"`
+	To    common.Address `json:"to"`
+	Value ArgBig         `json:"value"`
+}
+
+// OtsTraceEntry is a call frame of a tx, returned by ots_traceTransaction
+type OtsTraceEntry struct {
+	Type   string         `json:"type"`
+	Depth  int            `json:"depth"`
+	From   common.Address `json:"from"`
+	To     common.Address `json:"to"`
+	Value  *ArgBig        `json:"value"`
+	Input  ArgBytes       `json:"input"`
+	Output ArgBytes       `json:"output"`
+}
+
+// OtsReceipt is a receipt along with the timestamp of its block
+type OtsReceipt struct {
+	Receipt
+	Timestamp ArgUint64 `json:"timestamp"`
+}
+
+// OtsSearchResult is a page of txs of an address, returned by ots_searchTransactionsBefore
+// and ots_searchTransactionsAfter. The first page holds the newest txs and the last page the oldest
+type OtsSearchResult struct {
+	Txs       []*Transaction `json:"txs"`
+	Receipts  []OtsReceipt   `json:"receipts"`
+	FirstPage bool           `json:"firstPage"`
+	LastPage  bool           `json:"lastPage"`
+}
+
+// OtsBlock is a block without its txs along with the number of txs
+type OtsBlock struct {
+	*Block
+	TransactionCount ArgUint64 `json:"transactionCount"`
+}
+
+// OtsIssuance are the rewards of a block, there are no block rewards in L2
+type OtsIssuance struct {
+	BlockReward ArgBig `json:"blockReward"`
+	UncleReward ArgBig `json:"uncleReward"`
+	Issuance    ArgBig `json:"issuance"`
+}
+
+// OtsBlockDetails is the result of ots_getBlockDetails
+type OtsBlockDetails struct {
+	Block     OtsBlock    `json:"block"`
+	Issuance  OtsIssuance `json:"issuance"`
+	TotalFees ArgBig      `json:"totalFees"`
+}
+
+// OtsContractCreator is the tx that deployed a contract and its sender, returned by ots_getContractCreator
+type OtsContractCreator struct {
+	Hash    common.Hash    `json:"hash"`
+	Creator common.Address `json:"creator"`
+}
+
+// OtsBlockTransactions is a page of the txs of a block along with their receipts, returned by ots_getBlockTransactions
+type OtsBlockTransactions struct {
+	FullBlock OtsBlock  `json:"fullblock"`
+	Receipts  []Receipt `json:"receipts"`
+}
@@ -11,4 +11,6 @@ type LogFilterRequest struct {
 	ToBlock   *string       `json:"toBlock,omitempty"`
 	Address   interface{}   `json:"address,omitempty"`
 	Topics    []interface{} `json:"topics,omitempty"`
+	// XLayer resumable log subscriptions
+	Cursor *LogCursor `json:"cursor,omitempty"`
 }
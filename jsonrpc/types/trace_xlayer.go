@@ -0,0 +1,192 @@
+package types
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// TraceTypeTrace is the trace type of trace_replay* that returns the flat call traces
+	TraceTypeTrace = "trace"
+	// TraceTypeStateDiff is the trace type of trace_replay* that returns the state changes
+	TraceTypeStateDiff = "stateDiff"
+	// TraceTypeVMTrace is the trace type of trace_replay* that returns the vm traces, not supported
+	TraceTypeVMTrace = "vmTrace"
+
+	diffUnchanged = "="
+	diffBorn      = "+"
+	diffDied      = "-"
+	diffChanged   = "*"
+)
+
+// TraceFilter is the filter of trace_filter, the traces match when the sender of the
+// call is in FromAddress and the receiver is in ToAddress, an empty list matches any address
+type TraceFilter struct {
+	FromBlock   *BlockNumber     `json:"fromBlock"`
+	ToBlock     *BlockNumber     `json:"toBlock"`
+	FromAddress []common.Address `json:"fromAddress"`
+	ToAddress   []common.Address `json:"toAddress"`
+	After       *ArgUint64       `json:"after"`
+	Count       *ArgUint64       `json:"count"`
+}
+
+// Matches checks if a call from the given sender to the given receiver matches the filter
+func (f *TraceFilter) Matches(from, to *common.Address) bool {
+	return matchesAddress(f.FromAddress, from) && matchesAddress(f.ToAddress, to)
+}
+
+func matchesAddress(addresses []common.Address, address *common.Address) bool {
+	if len(addresses) == 0 {
+		return true
+	}
+	if address == nil {
+		return false
+	}
+	for _, a := range addresses {
+		if a == *address {
+			return true
+		}
+	}
+	return false
+}
+
+// TraceReplayResult is the result of replaying a tx with trace_replayBlockTransactions
+type TraceReplayResult struct {
+	Output          ArgBytes                       `json:"output"`
+	StateDiff       map[common.Address]AccountDiff `json:"stateDiff"`
+	Trace           json.RawMessage                `json:"trace"`
+	VMTrace         interface{}                    `json:"vmTrace"`
+	TransactionHash common.Hash                    `json:"transactionHash"`
+}
+
+// DiffValue is the change of a value in the parity state diff format
+type DiffValue struct {
+	kind     string
+	from, to interface{}
+}
+
+// MarshalJSON encodes "=" for unchanged values, {"+": to} for created values, {"-": from}
+// for deleted values and {"*": {"from": from, "to": to}} for changed values
+func (d DiffValue) MarshalJSON() ([]byte, error) {
+	switch d.kind {
+	case diffBorn:
+		return json.Marshal(map[string]interface{}{diffBorn: d.to})
+	case diffDied:
+		return json.Marshal(map[string]interface{}{diffDied: d.from})
+	case diffChanged:
+		return json.Marshal(map[string]interface{}{diffChanged: map[string]interface{}{"from": d.from, "to": d.to}})
+	default:
+		return json.Marshal(diffUnchanged)
+	}
+}
+
+// AccountDiff are the changes of an account in the parity state diff format
+type AccountDiff struct {
+	Balance DiffValue                 `json:"balance"`
+	Code    DiffValue                 `json:"code"`
+	Nonce   DiffValue                 `json:"nonce"`
+	Storage map[common.Hash]DiffValue `json:"storage"`
+}
+
+// prestateAccount is an account of the prestate tracer in diff mode, the post state only
+// contains the fields that changed
+type prestateAccount struct {
+	Balance *hexutil.Big                `json:"balance"`
+	Code    *hexutil.Bytes              `json:"code"`
+	Nonce   *uint64                     `json:"nonce"`
+	Storage map[common.Hash]common.Hash `json:"storage"`
+}
+
+func (a *prestateAccount) balance() *hexutil.Big {
+	if a == nil || a.Balance == nil {
+		return (*hexutil.Big)(big.NewInt(0))
+	}
+	return a.Balance
+}
+
+func (a *prestateAccount) code() hexutil.Bytes {
+	if a == nil || a.Code == nil {
+		return hexutil.Bytes{}
+	}
+	return *a.Code
+}
+
+func (a *prestateAccount) nonce() hexutil.Uint64 {
+	if a == nil || a.Nonce == nil {
+		return 0
+	}
+	return hexutil.Uint64(*a.Nonce)
+}
+
+// NewStateDiff converts the result of the prestate tracer in diff mode to the parity state diff format
+func NewStateDiff(prestateDiff json.RawMessage) (map[common.Address]AccountDiff, error) {
+	var diff struct {
+		Pre  map[common.Address]*prestateAccount `json:"pre"`
+		Post map[common.Address]*prestateAccount `json:"post"`
+	}
+	if err := json.Unmarshal(prestateDiff, &diff); err != nil {
+		return nil, err
+	}
+
+	res := make(map[common.Address]AccountDiff, len(diff.Pre)+len(diff.Post))
+	for addr, post := range diff.Post {
+		pre, ok := diff.Pre[addr]
+		if !ok {
+			// The account didn't exist before the tx
+			accountDiff := AccountDiff{
+				Balance: DiffValue{kind: diffBorn, to: post.balance()},
+				Code:    DiffValue{kind: diffBorn, to: post.code()},
+				Nonce:   DiffValue{kind: diffBorn, to: post.nonce()},
+				Storage: make(map[common.Hash]DiffValue, len(post.Storage)),
+			}
+			for slot, value := range post.Storage {
+				accountDiff.Storage[slot] = DiffValue{kind: diffBorn, to: value}
+			}
+			res[addr] = accountDiff
+			continue
+		}
+
+		accountDiff := AccountDiff{Storage: make(map[common.Hash]DiffValue)}
+		if post.Balance != nil {
+			accountDiff.Balance = DiffValue{kind: diffChanged, from: pre.balance(), to: post.balance()}
+		}
+		if post.Code != nil {
+			accountDiff.Code = DiffValue{kind: diffChanged, from: pre.code(), to: post.code()}
+		}
+		if post.Nonce != nil {
+			accountDiff.Nonce = DiffValue{kind: diffChanged, from: pre.nonce(), to: post.nonce()}
+		}
+		// The slots set to zero are only in the pre state and the slots set from zero are only in the post state
+		for slot, from := range pre.Storage {
+			accountDiff.Storage[slot] = DiffValue{kind: diffChanged, from: from, to: post.Storage[slot]}
+		}
+		for slot, to := range post.Storage {
+			if _, ok := pre.Storage[slot]; !ok {
+				accountDiff.Storage[slot] = DiffValue{kind: diffChanged, from: common.Hash{}, to: to}
+			}
+		}
+		res[addr] = accountDiff
+	}
+
+	// The accounts that are only in the pre state were self destructed
+	for addr, pre := range diff.Pre {
+		if _, ok := diff.Post[addr]; ok {
+			continue
+		}
+		accountDiff := AccountDiff{
+			Balance: DiffValue{kind: diffDied, from: pre.balance()},
+			Code:    DiffValue{kind: diffDied, from: pre.code()},
+			Nonce:   DiffValue{kind: diffDied, from: pre.nonce()},
+			Storage: make(map[common.Hash]DiffValue, len(pre.Storage)),
+		}
+		for slot, value := range pre.Storage {
+			accountDiff.Storage[slot] = DiffValue{kind: diffDied, from: value}
+		}
+		res[addr] = accountDiff
+	}
+
+	return res, nil
+}
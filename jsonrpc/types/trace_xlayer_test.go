@@ -0,0 +1,65 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStateDiff(t *testing.T) {
+	prestateDiff := json.RawMessage(`{
+		"pre": {
+			"0x0000000000000000000000000000000000000001": {"balance": "0x10", "nonce": 1, "storage": {"0x0000000000000000000000000000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000005"}},
+			"0x0000000000000000000000000000000000000003": {"balance": "0x1", "code": "0x6000"}
+		},
+		"post": {
+			"0x0000000000000000000000000000000000000001": {"balance": "0x8", "nonce": 2, "storage": {"0x0000000000000000000000000000000000000000000000000000000000000002": "0x0000000000000000000000000000000000000000000000000000000000000007"}},
+			"0x0000000000000000000000000000000000000002": {"balance": "0x8", "code": "0x6001", "nonce": 1}
+		}
+	}`)
+
+	stateDiff, err := NewStateDiff(prestateDiff)
+	require.NoError(t, err)
+
+	b, err := json.Marshal(stateDiff)
+	require.NoError(t, err)
+
+	expected := `{
+		"0x0000000000000000000000000000000000000001": {
+			"balance": {"*": {"from": "0x10", "to": "0x8"}},
+			"code": "=",
+			"nonce": {"*": {"from": "0x1", "to": "0x2"}},
+			"storage": {
+				"0x0000000000000000000000000000000000000000000000000000000000000001": {"*": {"from": "0x0000000000000000000000000000000000000000000000000000000000000005", "to": "0x0000000000000000000000000000000000000000000000000000000000000000"}},
+				"0x0000000000000000000000000000000000000000000000000000000000000002": {"*": {"from": "0x0000000000000000000000000000000000000000000000000000000000000000", "to": "0x0000000000000000000000000000000000000000000000000000000000000007"}}
+			}
+		},
+		"0x0000000000000000000000000000000000000002": {
+			"balance": {"+": "0x8"},
+			"code": {"+": "0x6001"},
+			"nonce": {"+": "0x1"},
+			"storage": {}
+		},
+		"0x0000000000000000000000000000000000000003": {
+			"balance": {"-": "0x1"},
+			"code": {"-": "0x6000"},
+			"nonce": {"-": "0x0"},
+			"storage": {}
+		}
+	}`
+	assert.JSONEq(t, expected, string(b))
+}
+
+func TestTraceFilterMatches(t *testing.T) {
+	from := common.HexToAddress("0x1")
+	to := common.HexToAddress("0x2")
+
+	assert.True(t, (&TraceFilter{}).Matches(&from, &to))
+	assert.True(t, (&TraceFilter{FromAddress: []common.Address{from}}).Matches(&from, &to))
+	assert.False(t, (&TraceFilter{FromAddress: []common.Address{to}}).Matches(&from, &to))
+	assert.True(t, (&TraceFilter{FromAddress: []common.Address{from}, ToAddress: []common.Address{to}}).Matches(&from, &to))
+	assert.False(t, (&TraceFilter{ToAddress: []common.Address{to}}).Matches(&from, nil))
+}
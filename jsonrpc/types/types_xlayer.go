@@ -1,5 +1,14 @@
 package types
 
+import (
+	"errors"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
 // Contains checks if a string is contained in a slice of strings
 func Contains(s []string, str string) bool {
 	for _, v := range s {
@@ -10,3 +19,220 @@ func Contains(s []string, str string) bool {
 
 	return false
 }
+
+// FeeHistory is the response of eth_feeHistory
+type FeeHistory struct {
+	OldestBlock   ArgUint64  `json:"oldestBlock"`
+	Reward        [][]ArgBig `json:"reward,omitempty"`
+	BaseFeePerGas []ArgBig   `json:"baseFeePerGas,omitempty"`
+	GasUsedRatio  []float64  `json:"gasUsedRatio"`
+}
+
+// OverrideAccount indicates the overriding fields of an account during the
+// execution of a message call
+type OverrideAccount struct {
+	Nonce     *ArgUint64                   `json:"nonce"`
+	Code      *ArgBytes                    `json:"code"`
+	Balance   *ArgBig                      `json:"balance"`
+	State     *map[common.Hash]common.Hash `json:"state"`
+	StateDiff *map[common.Hash]common.Hash `json:"stateDiff"`
+}
+
+// StateOverride is the collection of overridden accounts
+type StateOverride map[common.Address]OverrideAccount
+
+// ToStateOverride converts the rpc state override set into the state format
+func (so *StateOverride) ToStateOverride() state.StateOverride {
+	if so == nil {
+		return nil
+	}
+	result := make(state.StateOverride, len(*so))
+	for addr, account := range *so {
+		overrideAccount := state.OverrideAccount{}
+		if account.Nonce != nil {
+			nonce := uint64(*account.Nonce)
+			overrideAccount.Nonce = &nonce
+		}
+		if account.Code != nil {
+			overrideAccount.Code = *account.Code
+		}
+		if account.Balance != nil {
+			overrideAccount.Balance = (*big.Int)(account.Balance)
+		}
+		if account.State != nil {
+			overrideAccount.State = *account.State
+		}
+		if account.StateDiff != nil {
+			overrideAccount.StateDiff = *account.StateDiff
+		}
+		result[addr] = overrideAccount
+	}
+	return result
+}
+
+// BlockOverrides is a set of header fields to override when executing a message call
+type BlockOverrides struct {
+	Number     *ArgBig         `json:"number"`
+	Difficulty *ArgBig         `json:"difficulty"`
+	Time       *ArgUint64      `json:"time"`
+	GasLimit   *ArgUint64      `json:"gasLimit"`
+	Coinbase   *common.Address `json:"coinbase"`
+	Random     *common.Hash    `json:"random"`
+	BaseFee    *ArgBig         `json:"baseFee"`
+}
+
+// ToBlockOverrides converts the rpc block overrides into the state format,
+// only the fields the executor is able to override are accepted
+func (bo *BlockOverrides) ToBlockOverrides() (*state.BlockOverrides, error) {
+	if bo == nil {
+		return nil, nil
+	}
+	if bo.Number != nil || bo.Difficulty != nil || bo.GasLimit != nil || bo.Random != nil || bo.BaseFee != nil {
+		return nil, errors.New("only time and coinbase block overrides are supported")
+	}
+	result := &state.BlockOverrides{
+		Coinbase: bo.Coinbase,
+	}
+	if bo.Time != nil {
+		t := uint64(*bo.Time)
+		result.Time = &t
+	}
+	return result, nil
+}
+
+// SimulateBundleCallResult is the execution result of a single call of a simulated bundle
+type SimulateBundleCallResult struct {
+	TxHash     common.Hash `json:"transactionHash"`
+	Status     ArgUint64   `json:"status"`
+	GasUsed    ArgUint64   `json:"gasUsed"`
+	ReturnData ArgBytes    `json:"returnData"`
+	Logs       []Log       `json:"logs"`
+	Revert     *RevertInfo `json:"revert,omitempty"`
+	Error      *string     `json:"error,omitempty"`
+}
+
+// SimulateBundleResponse is the response of zkevm_simulateBundle, the zk counters
+// are the ones used by the whole virtual l2 block containing the bundle
+type SimulateBundleResponse struct {
+	Calls      []SimulateBundleCallResult `json:"calls"`
+	ZKCounters ZKCountersResponse         `json:"zkCounters"`
+}
+
+// Stages of the lifecycle of a tx returned by zkevm_getTransactionStatus
+const (
+	// TransactionStatusPending is a tx waiting in the pool
+	TransactionStatusPending = "pending"
+	// TransactionStatusSelected is a tx taken from the pool by the sequencer
+	TransactionStatusSelected = "selected"
+	// TransactionStatusWIPL2Block is a tx executed in the WIP L2 block of the sequencer
+	TransactionStatusWIPL2Block = "wipL2Block"
+	// TransactionStatusTrusted is a tx in a L2 block stored by the trusted sequencer
+	TransactionStatusTrusted = "trusted"
+	// TransactionStatusVirtualized is a tx whose batch was sequenced on L1
+	TransactionStatusVirtualized = "virtualized"
+	// TransactionStatusVerified is a tx whose batch was verified on L1
+	TransactionStatusVerified = "verified"
+	// TransactionStatusFinalized is a tx whose batch verification is in a finalized L1 block
+	TransactionStatusFinalized = "finalized"
+	// TransactionStatusFailed is a tx discarded by the sequencer
+	TransactionStatusFailed = "failed"
+	// TransactionStatusInvalid is a tx rejected by the pool
+	TransactionStatusInvalid = "invalid"
+)
+
+// TransactionStatusStage is a stage reached by a tx, the timestamp is null
+// for the stages whose time isn't tracked
+type TransactionStatusStage struct {
+	Status    string     `json:"status"`
+	Timestamp *ArgUint64 `json:"timestamp"`
+}
+
+// TransactionStatus is the lifecycle of a tx, from the pool to the L1 finality of its batch
+type TransactionStatus struct {
+	Hash                common.Hash              `json:"hash"`
+	Status              string                   `json:"status"`
+	FailedReason        *string                  `json:"failedReason,omitempty"`
+	BlockNumber         *ArgUint64               `json:"blockNumber"`
+	BlockHash           *common.Hash             `json:"blockHash"`
+	BatchNumber         *ArgUint64               `json:"batchNumber"`
+	SendSequencesTxHash *common.Hash             `json:"sendSequencesTxHash"`
+	VerifyBatchTxHash   *common.Hash             `json:"verifyBatchTxHash"`
+	Stages              []TransactionStatusStage `json:"stages"`
+}
+
+// AddStage adds the next stage reached by the tx
+func (s *TransactionStatus) AddStage(status string, timestamp *ArgUint64) {
+	s.Status = status
+	s.Stages = append(s.Stages, TransactionStatusStage{Status: status, Timestamp: timestamp})
+}
+
+// TxCheck is the outcome of one of the admission checks returned by zkevm_checkTransaction
+type TxCheck struct {
+	Name    string `json:"name"`
+	Passed  bool   `json:"passed"`
+	Details string `json:"details,omitempty"`
+}
+
+// TxCheckResponse is the outcome of the admission of a tx dry-run by zkevm_checkTransaction,
+// the zk counters and the break even gas price are null when the tx isn't pre-executed
+type TxCheckResponse struct {
+	Accepted           bool              `json:"accepted"`
+	Checks             []TxCheck         `json:"checks"`
+	UsedZKCounters     *ZKCounters       `json:"usedZkCounters"`
+	ReservedZKCounters *ZKCounters       `json:"reservedZkCounters"`
+	CountersLimits     *ZKCountersLimits `json:"countersLimit"`
+	BreakEvenGasPrice  *ArgBig           `json:"breakEvenGasPrice"`
+	OfferedGasPrice    *ArgBig           `json:"offeredGasPrice"`
+}
+
+// NewTxCheckResponse creates the response of zkevm_checkTransaction from the result of the pool
+func NewTxCheckResponse(result *pool.TxCheckResult, limits ZKCountersLimits) TxCheckResponse {
+	res := TxCheckResponse{
+		Accepted: result.Accepted,
+		Checks:   make([]TxCheck, 0, len(result.Checks)),
+	}
+	for _, check := range result.Checks {
+		res.Checks = append(res.Checks, TxCheck{Name: check.Name, Passed: check.Passed, Details: check.Details})
+	}
+	if result.UsedZKCounters != nil {
+		used := NewZKCountersResponse(*result.UsedZKCounters, limits, nil, nil).CountersUsed
+		res.UsedZKCounters = &used
+		res.CountersLimits = &limits
+	}
+	if result.ReservedZKCounters != nil {
+		reserved := NewZKCountersResponse(*result.ReservedZKCounters, limits, nil, nil).CountersUsed
+		res.ReservedZKCounters = &reserved
+	}
+	if result.BreakEvenGasPrice != nil {
+		res.BreakEvenGasPrice = state.Ptr(ArgBig(*result.BreakEvenGasPrice))
+	}
+	if result.OfferedGasPrice != nil {
+		res.OfferedGasPrice = state.Ptr(ArgBig(*result.OfferedGasPrice))
+	}
+	return res
+}
+
+// TxOptions are the options of a tx sent with zkevm_sendRawTransactionWithOptions, the bounds of
+// the validity window are unix timestamps and L2 block numbers
+type TxOptions struct {
+	NotBefore      *ArgUint64 `json:"notBefore,omitempty"`
+	NotAfter       *ArgUint64 `json:"notAfter,omitempty"`
+	NotBeforeBlock *ArgUint64 `json:"notBeforeBlock,omitempty"`
+	NotAfterBlock  *ArgUint64 `json:"notAfterBlock,omitempty"`
+}
+
+// ValidityWindow returns the validity window of the tx
+func (o TxOptions) ValidityWindow() pool.TxValidityWindow {
+	toUint64 := func(v *ArgUint64) *uint64 {
+		if v == nil {
+			return nil
+		}
+		return state.Ptr(uint64(*v))
+	}
+	return pool.TxValidityWindow{
+		NotBefore:      toUint64(o.NotBefore),
+		NotAfter:       toUint64(o.NotAfter),
+		NotBeforeBlock: toUint64(o.NotBeforeBlock),
+		NotAfterBlock:  toUint64(o.NotAfterBlock),
+	}
+}
@@ -11,6 +11,9 @@ import (
 type concurrentWsConn struct {
 	wsConn *websocket.Conn
 	mutex  *sync.Mutex
+
+	// XLayer funcs to run once the response of the current request is written
+	afterResponseFuncs []func()
 }
 
 // NewConcurrentWsConn creates a new instance of concurrentWsConn
@@ -0,0 +1,20 @@
+package jsonrpc
+
+// afterResponse registers a func to run once the response of the request being handled
+// is written, it's used to send the notifications that must follow a subscription id
+func (c *concurrentWsConn) afterResponse(fn func()) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.afterResponseFuncs = append(c.afterResponseFuncs, fn)
+}
+
+// runAfterResponse runs the funcs registered while handling the request
+func (c *concurrentWsConn) runAfterResponse() {
+	c.mutex.Lock()
+	fns := c.afterResponseFuncs
+	c.afterResponseFuncs = nil
+	c.mutex.Unlock()
+	for _, fn := range fns {
+		fn()
+	}
+}
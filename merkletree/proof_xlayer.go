@@ -0,0 +1,401 @@
+package merkletree
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/merkletree/hashdb"
+	"github.com/ethereum/go-ethereum/common"
+	poseidon "github.com/iden3/go-iden3-crypto/goldenposeidon"
+)
+
+const (
+	// maxLevels is the max depth of the sparse merkle tree, one level per key bit
+	maxLevels = 256
+	// siblingLen is the number of field elements of an intermediate node, left child and right child
+	siblingLen = 8
+	// valueLen is the number of field elements of a leaf value, 32 bits each
+	valueLen = 8
+)
+
+var (
+	// ErrInvalidProof is returned when a proof doesn't match the root it is verified against
+	ErrInvalidProof = errors.New("invalid merkle proof")
+
+	// leafCapacity is the capacity used to hash the leaves of the tree, intermediate nodes use all zeroes
+	leafCapacity = [4]uint64{1, 0, 0, 0}
+)
+
+// StateProof is the proof of the value of a key of the sparse merkle tree. When the key is not in
+// the tree the proof ends either in an empty node or in the leaf of another key sharing the path
+type StateProof struct {
+	// Root is the root of the tree.
+	Root []uint64
+	// Key is the key of the leaf.
+	Key []uint64
+	// Value is the value of the leaf, zero when the key is not in the tree.
+	Value []uint64
+	// Siblings are the intermediate nodes from the root down to the leaf, left and right children.
+	Siblings [][]uint64
+	// InsKey is the key of the leaf found in the path of a key that is not in the tree.
+	InsKey []uint64
+	// InsValue is the value of the leaf found in the path of a key that is not in the tree.
+	InsValue []uint64
+	// IsOld0 is true when the path of a key that is not in the tree ends in an empty node.
+	IsOld0 bool
+}
+
+type stateProofJSON struct {
+	Root     string     `json:"root"`
+	Key      string     `json:"key"`
+	Value    string     `json:"value"`
+	Siblings [][]string `json:"siblings"`
+	InsKey   string     `json:"insKey,omitempty"`
+	InsValue string     `json:"insValue,omitempty"`
+	IsOld0   bool       `json:"isOld0"`
+}
+
+// MarshalJSON encodes the hashes and values as hex strings
+func (p StateProof) MarshalJSON() ([]byte, error) {
+	res := stateProofJSON{
+		Root:     H4ToString(p.Root),
+		Key:      H4ToString(p.Key),
+		Value:    hex.EncodeBig(fea2scalar(p.Value)),
+		Siblings: make([][]string, 0, len(p.Siblings)),
+		IsOld0:   p.IsOld0,
+	}
+	for _, sibling := range p.Siblings {
+		s := make([]string, 0, len(sibling))
+		for _, fe := range sibling {
+			s = append(s, hex.EncodeUint64(fe))
+		}
+		res.Siblings = append(res.Siblings, s)
+	}
+	if !p.IsOld0 && p.InsKey != nil {
+		res.InsKey = H4ToString(p.InsKey)
+		res.InsValue = hex.EncodeBig(fea2scalar(p.InsValue))
+	}
+	return json.Marshal(res)
+}
+
+// UnmarshalJSON decodes a proof encoded by MarshalJSON
+func (p *StateProof) UnmarshalJSON(data []byte) error {
+	var res stateProofJSON
+	if err := json.Unmarshal(data, &res); err != nil {
+		return err
+	}
+
+	var err error
+	if p.Root, err = StringToh4(res.Root); err != nil {
+		return err
+	}
+	if p.Key, err = StringToh4(res.Key); err != nil {
+		return err
+	}
+	if p.Value, err = hexToFea(res.Value); err != nil {
+		return err
+	}
+	p.Siblings = make([][]uint64, 0, len(res.Siblings))
+	for _, s := range res.Siblings {
+		sibling := make([]uint64, 0, len(s))
+		for _, fe := range s {
+			if !hex.IsValid(fe) {
+				return fmt.Errorf("invalid sibling %q", fe)
+			}
+			sibling = append(sibling, hex.DecodeUint64(fe))
+		}
+		p.Siblings = append(p.Siblings, sibling)
+	}
+	p.IsOld0 = res.IsOld0
+	if res.InsKey != "" {
+		if p.InsKey, err = StringToh4(res.InsKey); err != nil {
+			return err
+		}
+		if p.InsValue, err = hexToFea(res.InsValue); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hexToFea(s string) ([]uint64, error) {
+	if !hex.IsValid(s) {
+		return nil, fmt.Errorf("invalid value %q", s)
+	}
+	return scalar2fea(hex.DecodeBig(s)), nil
+}
+
+// GetValue returns the proven value of the key
+func (p *StateProof) GetValue() *big.Int {
+	return fea2scalar(p.Value)
+}
+
+// Verify checks that the proof is a valid inclusion or non inclusion proof of the key against the root
+func (p *StateProof) Verify() error {
+	if len(p.Root) != 4 || len(p.Key) != 4 || len(p.Value) != valueLen { //nolint:gomnd
+		return fmt.Errorf("%w: malformed root, key or value", ErrInvalidProof)
+	}
+	if len(p.Siblings) >= maxLevels {
+		return fmt.Errorf("%w: too many siblings %d", ErrInvalidProof, len(p.Siblings))
+	}
+
+	level := len(p.Siblings)
+	keyBits := splitKey(p.Key)
+
+	// Compute the hash of the node at the end of the path of the key
+	var err error
+	var node [4]uint64
+	if !isZero(p.Value) {
+		node, err = leafHash(removeKeyBits(p.Key, level), p.Value)
+		if err != nil {
+			return err
+		}
+	} else if !p.IsOld0 {
+		if len(p.InsKey) != 4 || len(p.InsValue) != valueLen || isZero(p.InsValue) { //nolint:gomnd
+			return fmt.Errorf("%w: malformed inserted key or value", ErrInvalidProof)
+		}
+		insKeyBits := splitKey(p.InsKey)
+		sameKey := true
+		for i := range keyBits {
+			if i < level && insKeyBits[i] != keyBits[i] {
+				return fmt.Errorf("%w: inserted key is not in the path of the key", ErrInvalidProof)
+			}
+			sameKey = sameKey && insKeyBits[i] == keyBits[i]
+		}
+		if sameKey {
+			return fmt.Errorf("%w: inserted key is the proven key", ErrInvalidProof)
+		}
+		node, err = leafHash(removeKeyBits(p.InsKey, level), p.InsValue)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Hash the path up to the root
+	for i := level - 1; i >= 0; i-- {
+		if len(p.Siblings[i]) < siblingLen {
+			return fmt.Errorf("%w: malformed sibling at level %d", ErrInvalidProof, i)
+		}
+		var children [8]uint64
+		copy(children[:], p.Siblings[i][:siblingLen])
+		copy(children[keyBits[i]*4:keyBits[i]*4+4], node[:]) //nolint:gomnd
+		node, err = poseidon.Hash(children, [4]uint64{})
+		if err != nil {
+			return err
+		}
+	}
+
+	for i := range node {
+		if node[i] != p.Root[i] {
+			return fmt.Errorf("%w: computed root %s doesn't match root %s", ErrInvalidProof, H4ToString(node[:]), H4ToString(p.Root))
+		}
+	}
+	return nil
+}
+
+// leafHash computes the hash of a leaf from its remaining key and its value
+func leafHash(rKey []uint64, value []uint64) ([4]uint64, error) {
+	var v [8]uint64
+	copy(v[:], value)
+	valueHash, err := poseidon.Hash(v, [4]uint64{})
+	if err != nil {
+		return [4]uint64{}, err
+	}
+	return poseidon.Hash([8]uint64{rKey[0], rKey[1], rKey[2], rKey[3], valueHash[0], valueHash[1], valueHash[2], valueHash[3]}, leafCapacity)
+}
+
+// splitKey returns the path of a key, the bits are taken alternately from each of the 4 elements of the key
+func splitKey(key []uint64) []uint64 {
+	bits := make([]uint64, 0, maxLevels)
+	for i := 0; i < maxLevels/4; i++ { //nolint:gomnd
+		for j := 0; j < 4; j++ { //nolint:gomnd
+			bits = append(bits, (key[j]>>uint(i))&1)
+		}
+	}
+	return bits
+}
+
+// removeKeyBits returns the remaining key stored in a leaf at the given level
+func removeKeyBits(key []uint64, level int) []uint64 {
+	fullLevels := level / 4   //nolint:gomnd
+	rKey := make([]uint64, 4) //nolint:gomnd
+	for i := range rKey {
+		n := fullLevels
+		if fullLevels*4+i < level { //nolint:gomnd
+			n++
+		}
+		rKey[i] = key[i] >> uint(n)
+	}
+	return rKey
+}
+
+func isZero(fea []uint64) bool {
+	for _, fe := range fea {
+		if fe != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// StorageProof is the proof of a storage slot of an account
+type StorageProof struct {
+	Position common.Hash `json:"position"`
+	Proof    *StateProof `json:"proof"`
+}
+
+// AccountProof contains the proofs of the balance, nonce, code hash and storage slots of an account
+type AccountProof struct {
+	Address      common.Address `json:"address"`
+	StateRoot    common.Hash    `json:"stateRoot"`
+	Balance      *StateProof    `json:"balanceProof"`
+	Nonce        *StateProof    `json:"nonceProof"`
+	CodeHash     *StateProof    `json:"codeHashProof"`
+	StorageProof []StorageProof `json:"storageProof"`
+}
+
+// Verify checks that all the proofs are valid against the state root and that their keys belong
+// to the account, so the values can be trusted by anyone knowing the state root
+func (a *AccountProof) Verify() error {
+	key, err := KeyEthAddrBalance(a.Address)
+	if err != nil {
+		return err
+	}
+	if err := a.verifyProof("balance", a.Balance, key); err != nil {
+		return err
+	}
+
+	key, err = KeyEthAddrNonce(a.Address)
+	if err != nil {
+		return err
+	}
+	if err := a.verifyProof("nonce", a.Nonce, key); err != nil {
+		return err
+	}
+
+	key, err = KeyContractCode(a.Address)
+	if err != nil {
+		return err
+	}
+	if err := a.verifyProof("code hash", a.CodeHash, key); err != nil {
+		return err
+	}
+
+	for _, storage := range a.StorageProof {
+		key, err = KeyContractStorage(a.Address, storage.Position.Big().Bytes())
+		if err != nil {
+			return err
+		}
+		if err := a.verifyProof(fmt.Sprintf("storage %s", storage.Position.String()), storage.Proof, key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (a *AccountProof) verifyProof(name string, proof *StateProof, key []byte) error {
+	if proof == nil {
+		return fmt.Errorf("%w: missing %s proof", ErrInvalidProof, name)
+	}
+	if common.HexToHash(H4ToString(proof.Root)) != a.StateRoot {
+		return fmt.Errorf("%w: %s proof root doesn't match the state root", ErrInvalidProof, name)
+	}
+	if common.HexToHash(H4ToString(proof.Key)) != common.BytesToHash(key) {
+		return fmt.Errorf("%w: %s proof key doesn't match the account", ErrInvalidProof, name)
+	}
+	if err := proof.Verify(); err != nil {
+		return fmt.Errorf("%s proof: %w", name, err)
+	}
+	return nil
+}
+
+// GetAccountProof returns the proofs of the balance, nonce, code hash and the given storage positions of an account
+func (tree *StateTree) GetAccountProof(ctx context.Context, address common.Address, positions []*big.Int, root []byte) (*AccountProof, error) {
+	r := scalarToh4(new(big.Int).SetBytes(root))
+	res := &AccountProof{
+		Address:      address,
+		StateRoot:    common.BytesToHash(root),
+		StorageProof: make([]StorageProof, 0, len(positions)),
+	}
+
+	key, err := KeyEthAddrBalance(address)
+	if err != nil {
+		return nil, err
+	}
+	if res.Balance, err = tree.getProof(ctx, r, scalarToh4(new(big.Int).SetBytes(key))); err != nil {
+		return nil, err
+	}
+
+	key, err = KeyEthAddrNonce(address)
+	if err != nil {
+		return nil, err
+	}
+	if res.Nonce, err = tree.getProof(ctx, r, scalarToh4(new(big.Int).SetBytes(key))); err != nil {
+		return nil, err
+	}
+
+	key, err = KeyContractCode(address)
+	if err != nil {
+		return nil, err
+	}
+	if res.CodeHash, err = tree.getProof(ctx, r, scalarToh4(new(big.Int).SetBytes(key))); err != nil {
+		return nil, err
+	}
+
+	for _, position := range positions {
+		key, err = KeyContractStorage(address, position.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		proof, err := tree.getProof(ctx, r, scalarToh4(new(big.Int).SetBytes(key)))
+		if err != nil {
+			return nil, err
+		}
+		res.StorageProof = append(res.StorageProof, StorageProof{Position: common.BigToHash(position), Proof: proof})
+	}
+
+	return res, nil
+}
+
+// getProof gets the value of a key along with the siblings of its path
+func (tree *StateTree) getProof(ctx context.Context, root, key []uint64) (*StateProof, error) {
+	result, err := tree.grpcClient.Get(ctx, &hashdb.GetRequest{
+		Root:    &hashdb.Fea{Fe0: root[0], Fe1: root[1], Fe2: root[2], Fe3: root[3]},
+		Key:     &hashdb.Fea{Fe0: key[0], Fe1: key[1], Fe2: key[2], Fe3: key[3]},
+		Details: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	proof := &StateProof{
+		Root:     []uint64{root[0], root[1], root[2], root[3]},
+		Key:      key,
+		Value:    make([]uint64, valueLen),
+		Siblings: make([][]uint64, len(result.Siblings)),
+		IsOld0:   result.IsOld0,
+	}
+	if result.Value != "" {
+		if proof.Value, err = string2fea(result.Value); err != nil {
+			return nil, err
+		}
+	}
+	for level, sibling := range result.Siblings {
+		if level >= uint64(len(proof.Siblings)) || sibling == nil {
+			return nil, fmt.Errorf("unexpected sibling at level %d", level)
+		}
+		proof.Siblings[level] = sibling.Sibling
+	}
+	if isZero(proof.Value) && !result.IsOld0 && result.InsKey != nil {
+		proof.InsKey = []uint64{result.InsKey.Fe0, result.InsKey.Fe1, result.InsKey.Fe2, result.InsKey.Fe3}
+		if proof.InsValue, err = string2fea(result.InsValue); err != nil {
+			return nil, err
+		}
+	}
+
+	return proof, nil
+}
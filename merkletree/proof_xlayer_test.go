@@ -0,0 +1,189 @@
+package merkletree
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+	"testing"
+
+	poseidon "github.com/iden3/go-iden3-crypto/goldenposeidon"
+	"github.com/stretchr/testify/require"
+)
+
+type testVectorRaw struct {
+	Keys         []string `json:"keys"`
+	Values       []string `json:"values"`
+	ExpectedRoot string   `json:"expectedRoot"`
+}
+
+type testLeaf struct {
+	key   []uint64
+	value *big.Int
+}
+
+// testTreeHash computes the hash of the node of a test tree containing the given leaves at the given level
+func testTreeHash(t *testing.T, leaves []testLeaf, level int) [4]uint64 {
+	if len(leaves) == 0 {
+		return [4]uint64{}
+	}
+	if len(leaves) == 1 {
+		h, err := leafHash(removeKeyBits(leaves[0].key, level), scalar2fea(leaves[0].value))
+		require.NoError(t, err)
+		return h
+	}
+
+	left, right := splitTestLeaves(leaves, level)
+	l := testTreeHash(t, left, level+1)
+	r := testTreeHash(t, right, level+1)
+	h, err := poseidon.Hash([8]uint64{l[0], l[1], l[2], l[3], r[0], r[1], r[2], r[3]}, [4]uint64{})
+	require.NoError(t, err)
+	return h
+}
+
+// testTreeProof builds the proof of a key walking a test tree the same way the hashdb service does
+func testTreeProof(t *testing.T, leaves []testLeaf, key []uint64) *StateProof {
+	root := testTreeHash(t, leaves, 0)
+	proof := &StateProof{Root: root[:], Key: key, Value: make([]uint64, valueLen), IsOld0: true}
+
+	keyBits := splitKey(key)
+	for level := 0; len(leaves) > 1; level++ {
+		left, right := splitTestLeaves(leaves, level)
+		l := testTreeHash(t, left, level+1)
+		r := testTreeHash(t, right, level+1)
+		proof.Siblings = append(proof.Siblings, []uint64{l[0], l[1], l[2], l[3], r[0], r[1], r[2], r[3], 0, 0, 0, 0})
+		if keyBits[level] == 0 {
+			leaves = left
+		} else {
+			leaves = right
+		}
+	}
+
+	if len(leaves) == 1 {
+		if H4ToString(leaves[0].key) == H4ToString(key) {
+			proof.Value = scalar2fea(leaves[0].value)
+		} else {
+			proof.InsKey = leaves[0].key
+			proof.InsValue = scalar2fea(leaves[0].value)
+			proof.IsOld0 = false
+		}
+	}
+	return proof
+}
+
+func splitTestLeaves(leaves []testLeaf, level int) ([]testLeaf, []testLeaf) {
+	var left, right []testLeaf
+	for _, leaf := range leaves {
+		if splitKey(leaf.key)[level] == 0 {
+			left = append(left, leaf)
+		} else {
+			right = append(right, leaf)
+		}
+	}
+	return left, right
+}
+
+func TestStateProofVerify(t *testing.T) {
+	// The bit of level i is taken from the element i%4 of the key, so a and b share the
+	// first two levels and c is alone in the right branch of the root
+	a := testLeaf{key: []uint64{0, 0, 0, 7}, value: big.NewInt(100)}
+	b := testLeaf{key: []uint64{0, 0, 1, 9}, value: big.NewInt(200)}
+	c := testLeaf{key: []uint64{1, 0, 0, 0}, value: new(big.Int).Lsh(big.NewInt(1), 255)}
+	leaves := []testLeaf{a, b, c}
+
+	for _, leaf := range leaves {
+		proof := testTreeProof(t, leaves, leaf.key)
+		require.NoError(t, proof.Verify())
+		require.Equal(t, leaf.value, proof.GetValue())
+	}
+
+	// Key not in the tree whose path ends in an empty node
+	proof := testTreeProof(t, leaves, []uint64{0, 1, 0, 0})
+	require.True(t, proof.IsOld0)
+	require.NoError(t, proof.Verify())
+	require.Equal(t, int64(0), proof.GetValue().Int64())
+
+	// Key not in the tree whose path ends in the leaf of another key
+	proof = testTreeProof(t, leaves, []uint64{3, 5, 0, 0})
+	require.False(t, proof.IsOld0)
+	require.Equal(t, c.key, proof.InsKey)
+	require.NoError(t, proof.Verify())
+
+	// Tampered value
+	proof = testTreeProof(t, leaves, a.key)
+	proof.Value = scalar2fea(big.NewInt(101))
+	require.ErrorIs(t, proof.Verify(), ErrInvalidProof)
+
+	// Claiming a key is not in the tree using its own leaf
+	proof = testTreeProof(t, leaves, b.key)
+	proof.InsKey, proof.InsValue, proof.IsOld0 = proof.Key, proof.Value, false
+	proof.Value = make([]uint64, valueLen)
+	require.ErrorIs(t, proof.Verify(), ErrInvalidProof)
+
+	// Tampered sibling
+	proof = testTreeProof(t, leaves, c.key)
+	proof.Siblings[0][0]++
+	require.ErrorIs(t, proof.Verify(), ErrInvalidProof)
+}
+
+func TestStateProofJSON(t *testing.T) {
+	leaves := []testLeaf{
+		{key: []uint64{0, 0, 0, 7}, value: big.NewInt(100)},
+		{key: []uint64{1, 0, 0, 0}, value: big.NewInt(200)},
+	}
+
+	for _, key := range [][]uint64{leaves[0].key, {1, 1, 0, 0}} {
+		proof := testTreeProof(t, leaves, key)
+		b, err := json.Marshal(proof)
+		require.NoError(t, err)
+
+		var decoded StateProof
+		require.NoError(t, json.Unmarshal(b, &decoded))
+		require.Equal(t, proof.Root, decoded.Root)
+		require.Equal(t, proof.Key, decoded.Key)
+		require.Equal(t, proof.Value, decoded.Value)
+		require.Equal(t, proof.InsKey, decoded.InsKey)
+		require.Equal(t, proof.InsValue, decoded.InsValue)
+		require.NoError(t, decoded.Verify())
+	}
+}
+
+func TestStateProofVerifyTestVectors(t *testing.T) {
+	data, err := os.ReadFile("test/vectors/src/merkle-tree/smt-raw.json")
+	require.NoError(t, err)
+
+	var testVectors []testVectorRaw
+	require.NoError(t, json.Unmarshal(data, &testVectors))
+
+	for ti, testVector := range testVectors {
+		testVector := testVector
+		t.Run(fmt.Sprintf("test vector %d", ti), func(t *testing.T) {
+			// the values are set in order, a zero value removes the key from the tree
+			values := make(map[string]*big.Int)
+			keys := make(map[string][]uint64)
+			for i, k := range testVector.Keys {
+				key, ok := new(big.Int).SetString(k, 10)
+				require.True(t, ok)
+				value, ok := new(big.Int).SetString(testVector.Values[i], 10)
+				require.True(t, ok)
+				keys[k] = scalarToh4(key)
+				values[k] = value
+			}
+			var leaves []testLeaf
+			for k, value := range values {
+				if value.Sign() != 0 {
+					leaves = append(leaves, testLeaf{key: keys[k], value: value})
+				}
+			}
+
+			root := testTreeHash(t, leaves, 0)
+			require.Equal(t, testVector.ExpectedRoot, H4ToString(root[:]))
+
+			for k, key := range keys {
+				proof := testTreeProof(t, leaves, key)
+				require.NoError(t, proof.Verify())
+				require.Zero(t, values[k].Cmp(proof.GetValue()))
+			}
+		})
+	}
+}
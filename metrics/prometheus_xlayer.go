@@ -67,6 +67,18 @@ func GaugeVecSet(name string, label string, value float64) {
 	}
 }
 
+// CounterVecIncWithLabels increments the counter vec with the given name and
+// the values of all its labels.
+func CounterVecIncWithLabels(name string, labels ...string) {
+	if !initialized {
+		return
+	}
+
+	if cv, ok := CounterVec(name); ok {
+		cv.WithLabelValues(labels...).Inc()
+	}
+}
+
 // registerGaugeVecIfNotExists registers single gauge vec metric if not exists
 func registerGaugeVecIfNotExists(opts GaugeVecOpts) {
 	log := log.WithFields("metricName", opts.Name)
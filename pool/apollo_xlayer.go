@@ -3,6 +3,7 @@ package pool
 import (
 	"sync"
 
+	"github.com/0xPolygonHermez/zkevm-node/pool/metrics"
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -28,6 +29,8 @@ type apolloConfig struct {
 
 	BlockedList []string
 
+	Quota QuotaCfg
+
 	sync.RWMutex
 }
 
@@ -87,6 +90,15 @@ func (c *apolloConfig) setFreeGasExAddresses(freeGasExAddrs []string) {
 	copy(c.FreeGasExAddress, freeGasExAddrs)
 }
 
+func (c *apolloConfig) setQuota(quota QuotaCfg) {
+	if c == nil || !c.EnableApollo {
+		return
+	}
+	c.Quota = quota
+	c.Quota.LocalAddresses = make([]string, len(quota.LocalAddresses))
+	copy(c.Quota.LocalAddresses, quota.LocalAddresses)
+}
+
 func (c *apolloConfig) setBridgeClaimMethods(bridgeClaimMethods []string) {
 	if c == nil || !c.EnableApollo {
 		return
@@ -101,6 +113,7 @@ func (c *apolloConfig) setBridgeClaimMethods(bridgeClaimMethods []string) {
 // FreeGasAddress
 // EnableWhitelist
 // EnablePendingStat
+// Quota
 func UpdateConfig(apolloConfig Config) {
 	getApolloConfig().Lock()
 	getApolloConfig().EnableApollo = true
@@ -117,8 +130,11 @@ func UpdateConfig(apolloConfig Config) {
 	getApolloConfig().FreeGasLimit = apolloConfig.FreeGasLimit
 	getApolloConfig().EnableFreeGasList = apolloConfig.EnableFreeGasList
 	getApolloConfig().setFreeGasList(apolloConfig.FreeGasList)
+	getApolloConfig().setQuota(apolloConfig.Quota)
 
 	getApolloConfig().Unlock()
+
+	metrics.Quotas(apolloConfig.Quota.MaxPendingTxsPerSender, apolloConfig.Quota.MaxPendingTxsPerIP, apolloConfig.Quota.GlobalSlots, apolloConfig.GlobalQueue)
 }
 
 func getClaimMethod(localBridgeClaimMethods []string) []string {
@@ -205,6 +221,16 @@ func getAccountQueue(accountQueue uint64) uint64 {
 	return accountQueue
 }
 
+func getQuotaCfg(quota QuotaCfg) QuotaCfg {
+	if getApolloConfig().enable() {
+		getApolloConfig().RLock()
+		defer getApolloConfig().RUnlock()
+		return getApolloConfig().Quota
+	}
+
+	return quota
+}
+
 func getEnableWhitelist(enableWhitelist bool) bool {
 	if getApolloConfig().enable() {
 		getApolloConfig().RLock()
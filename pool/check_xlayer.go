@@ -0,0 +1,415 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// Names of the admission checks of the pool reported by CheckTx
+const (
+	TxCheckIP                = "ip"
+	TxCheckSignature         = "signature"
+	TxCheckChainID           = "chainId"
+	TxCheckTxType            = "txType"
+	TxCheckSender            = "sender"
+	TxCheckSize              = "size"
+	TxCheckFee               = "fee"
+	TxCheckBlockedAddress    = "blockedAddress"
+	TxCheckWhitelist         = "whitelist"
+	TxCheckNonce             = "nonce"
+	TxCheckFreeGas           = "freeGas"
+	TxCheckMinGasPrice       = "minGasPrice"
+	TxCheckBalance           = "balance"
+	TxCheckIntrinsicGas      = "intrinsicGas"
+	TxCheckReplacement       = "replacement"
+	TxCheckExecutorFields    = "executorFields"
+	TxCheckQuotas            = "quotas"
+	TxCheckFreeGasAddress    = "freeGasAddress"
+	TxCheckPreExecution      = "preExecution"
+	TxCheckBreakEvenGasPrice = "breakEvenGasPrice"
+	TxCheckGlobalSlots       = "globalSlots"
+	TxCheckGlobalQueue       = "globalQueue"
+)
+
+// TxCheck is the outcome of one of the admission checks of the pool
+type TxCheck struct {
+	Name    string
+	Passed  bool
+	Details string
+}
+
+// TxCheckResult is the outcome of the admission of a tx dry-run by CheckTx
+type TxCheckResult struct {
+	Accepted bool
+	Checks   []TxCheck
+	// UsedZKCounters and ReservedZKCounters are set once the tx is pre-executed
+	UsedZKCounters     *state.ZKCounters
+	ReservedZKCounters *state.ZKCounters
+	// BreakEvenGasPrice is set once the break even gas price is calculated, the factor is applied
+	BreakEvenGasPrice *big.Int
+	OfferedGasPrice   *big.Int
+}
+
+func (r *TxCheckResult) add(name string, err error, details string) {
+	check := TxCheck{Name: name, Passed: err == nil, Details: details}
+	if err != nil {
+		r.Accepted = false
+		check.Details = err.Error()
+		if details != "" {
+			check.Details = fmt.Sprintf("%s: %s", err.Error(), details)
+		}
+	}
+	r.Checks = append(r.Checks, check)
+}
+
+// txCheckReporter receives the outcome of each admission check of a tx, the checks stop when it returns false
+type txCheckReporter func(name string, err error, details string) bool
+
+// txCheckContext is the context the admission checks of a tx ran with
+type txCheckContext struct {
+	from common.Address
+	root common.Hash
+}
+
+// CheckTx runs the admission checks of AddTx without storing the tx nor updating the pool, every
+// check is reported so the reason of a rejection can be explained. The checks that depend on the
+// sender are skipped when the signature is not valid
+func (p *Pool) CheckTx(ctx context.Context, tx types.Transaction, ip string) (*TxCheckResult, error) {
+	result := &TxCheckResult{Accepted: true, Checks: []TxCheck{}, OfferedGasPrice: tx.GasPrice()}
+	poolTx := NewTransaction(tx, ip, false, p)
+
+	checkCtx, err := p.checkTx(ctx, *poolTx, func(name string, err error, details string) bool {
+		result.add(name, err, details)
+		return true
+	})
+	if err != nil || checkCtx == nil {
+		return result, err
+	}
+
+	if getEnableFreeGasByNonce(p.cfg.EnableFreeGasByNonce) {
+		freeGpAddr, err := p.getFreeGasAddrToAdd(ctx, *poolTx, checkCtx.from, checkCtx.root)
+		if err != nil {
+			return nil, err
+		}
+		details := "no address to add"
+		if freeGpAddr != nil {
+			details = fmt.Sprintf("%s would be added", freeGpAddr.String())
+		}
+		result.add(TxCheckFreeGasAddress, nil, details)
+	}
+
+	if err := p.checkTxExecution(ctx, result, tx); err != nil {
+		return nil, err
+	}
+
+	// The global slots and queue are checked when storing the tx, the global queue only when there is no tx to evict
+	txToEvict, err := p.getTxToEvict(ctx, *poolTx, checkCtx.from)
+	switch {
+	case errors.Is(err, ErrUnderpriced):
+		result.add(TxCheckGlobalSlots, err, "")
+	case errors.Is(err, ErrTxPoolOverflow):
+		result.add(TxCheckGlobalSlots, nil, "no tx to evict")
+		result.add(TxCheckGlobalQueue, err, "")
+	case err != nil:
+		return nil, err
+	case txToEvict != nil:
+		result.add(TxCheckGlobalSlots, nil, fmt.Sprintf("the pool is full, tx %s would be evicted", txToEvict.Hash().String()))
+	default:
+		result.add(TxCheckGlobalSlots, nil, "")
+		result.add(TxCheckGlobalQueue, nil, "")
+	}
+	return result, nil
+}
+
+// checkTx runs the admission checks of AddTx in order and reports the outcome of each one. The sender
+// and the state root the checks ran with are returned once all the checks have been run, nil is returned
+// if the reporter stopped them or the sender can't be recovered. An error is returned when a check can't be run
+func (p *Pool) checkTx(ctx context.Context, poolTx Transaction, report txCheckReporter) (*txCheckContext, error) {
+	// Make sure the IP is valid.
+	var err error
+	if poolTx.IP != "" && !IsValidIP(poolTx.IP) {
+		err = ErrInvalidIP
+	}
+	if !report(TxCheckIP, err, fmt.Sprintf("ip %s", poolTx.IP)) {
+		return nil, nil
+	}
+
+	// Make sure the transaction is signed properly.
+	if err := state.CheckSignature(poolTx.Transaction); err != nil {
+		report(TxCheckSignature, ErrInvalidSender, "")
+		return nil, nil
+	}
+	if !report(TxCheckSignature, nil, "") {
+		return nil, nil
+	}
+
+	txChainID := poolTx.ChainId().Uint64()
+	err = nil
+	if txChainID != p.chainID && txChainID != 0 {
+		err = ErrInvalidChainID
+	}
+	if !report(TxCheckChainID, err, fmt.Sprintf("tx chain id %d, chain id %d", txChainID, p.chainID)) {
+		return nil, nil
+	}
+
+	// Accept only legacy transactions until EIP-2718/2930 activates.
+	err = nil
+	if poolTx.Type() != types.LegacyTxType {
+		err = ErrTxTypeNotSupported
+	}
+	if !report(TxCheckTxType, err, fmt.Sprintf("tx type %d", poolTx.Type())) {
+		return nil, nil
+	}
+
+	// check Pre EIP155 txs signature and get the tx sender for the validations
+	var from common.Address
+	if txChainID == 0 && !state.IsPreEIP155Tx(poolTx.Transaction) {
+		err = ErrInvalidSender
+	} else if from, err = state.GetSender(poolTx.Transaction); err != nil {
+		err = ErrInvalidSender
+	}
+	if err != nil {
+		report(TxCheckSender, err, "")
+		return nil, nil
+	}
+	if !report(TxCheckSender, nil, fmt.Sprintf("sender %s", from.String())) {
+		return nil, nil
+	}
+
+	// Reject transactions over defined size to prevent DOS attacks
+	encodedTx, err := state.EncodeTransaction(poolTx.Transaction, 0xFF, p.cfg.ForkID) //nolint: gomnd
+	if err != nil {
+		if !report(TxCheckSize, ErrTxTypeNotSupported, "") {
+			return nil, nil
+		}
+	} else {
+		if uint64(len(encodedTx)) > p.cfg.MaxTxBytesSize {
+			log.Infof("%v: %v", ErrOversizedData.Error(), from.String())
+			err = ErrOversizedData
+		}
+		if !report(TxCheckSize, err, fmt.Sprintf("%d bytes, max %d bytes", len(encodedTx), p.cfg.MaxTxBytesSize)) {
+			return nil, nil
+		}
+	}
+
+	// Transactions can't be negative. This may never happen using RLP decoded
+	// transactions but may occur if you create a transaction using the RPC.
+	err = ErrNegativeValue
+	if poolTx.Value().Sign() >= 0 {
+		err = checkTxFee(poolTx.GasPrice(), poolTx.Gas(), p.cfg.TxFeeCap)
+	}
+	if !report(TxCheckFee, err, fmt.Sprintf("gas price %v, gas %d, fee cap %v", poolTx.GasPrice(), poolTx.Gas(), p.cfg.TxFeeCap)) {
+		return nil, nil
+	}
+
+	// check if the sender or the receiver are blocked
+	err = nil
+	if p.checkBlockedAddr(from) {
+		log.Infof("%v: %v", ErrBlockedSender.Error(), from.String())
+		err = ErrBlockedSender
+	} else if to := poolTx.To(); to != nil && p.checkBlockedAddr(*to) {
+		log.Infof("%v: %v", ErrBlockedReceiver.Error(), to.String())
+		err = ErrBlockedReceiver
+	}
+	if !report(TxCheckBlockedAddress, err, "") {
+		return nil, nil
+	}
+
+	// check if sender is whitelisted
+	err = nil
+	details := "whitelist disabled"
+	if getEnableWhitelist(p.cfg.EnableWhitelist) {
+		details = fmt.Sprintf("sender %s", from.String())
+		if _, listed := p.whitelistedAddresses.Load(from.String()); !listed {
+			log.Infof("%v: %v", ErrNoWhitelistedSender.Error(), from.String())
+			err = ErrNoWhitelistedSender
+		}
+	}
+	if !report(TxCheckWhitelist, err, details) {
+		return nil, nil
+	}
+
+	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		log.Errorf("failed to load last l2 block while adding tx to the pool", err)
+		return nil, err
+	}
+	checkCtx := &txCheckContext{from: from, root: lastL2Block.Root()}
+
+	currentNonce, err := p.state.GetNonce(ctx, from, checkCtx.root)
+	if err != nil {
+		log.Errorf("failed to get nonce while adding tx to the pool", err)
+		return nil, err
+	}
+	// Ensure the transaction adheres to nonce ordering and doesn't jump out of the expected AccountQueue
+	err = nil
+	details = fmt.Sprintf("nonce %d, account nonce %d", poolTx.Nonce(), currentNonce)
+	accountQueue := getAccountQueue(p.cfg.AccountQueue)
+	if poolTx.Nonce() < currentNonce {
+		err = ErrNonceTooLow
+	} else if accountQueue > 0 && poolTx.Nonce() > currentNonce+accountQueue-1 {
+		log.Infof("%v: %v", ErrNonceTooHigh.Error(), from.String())
+		err = ErrNonceTooHigh
+		details = fmt.Sprintf("%s, max gap %d", details, accountQueue-1)
+	}
+	if !report(TxCheckNonce, err, details) {
+		return nil, nil
+	}
+
+	freeGp, err := p.checkFreeGp(ctx, poolTx, from)
+	if !report(TxCheckFreeGas, err, fmt.Sprintf("eligible %t", freeGp)) {
+		return nil, nil
+	}
+
+	err = nil
+	details = "free gas tx"
+	if !freeGp {
+		p.minSuggestedGasPriceMux.RLock()
+		minSuggestedGasPrice := p.minSuggestedGasPrice
+		p.minSuggestedGasPriceMux.RUnlock()
+		if poolTx.GasPrice().Cmp(minSuggestedGasPrice) == -1 {
+			log.Debugf("low gas price: minSuggestedGasPrice %v got %v", minSuggestedGasPrice, poolTx.GasPrice())
+			err = ErrGasPrice
+		}
+		details = fmt.Sprintf("gas price %v, min gas price %v", poolTx.GasPrice(), minSuggestedGasPrice)
+	}
+	if !report(TxCheckMinGasPrice, err, details) {
+		return nil, nil
+	}
+
+	// Transactor should have enough funds to cover the costs
+	// cost == V + GP * GL
+	balance, err := p.state.GetBalance(ctx, from, checkCtx.root)
+	if err != nil {
+		log.Errorf("failed to get balance for account %v while adding tx to the pool", from.String(), err)
+		return nil, err
+	}
+	err = nil
+	if balance.Cmp(poolTx.Cost()) < 0 {
+		err = ErrInsufficientFunds
+	}
+	if !report(TxCheckBalance, err, fmt.Sprintf("balance %v, cost %v", balance, poolTx.Cost())) {
+		return nil, nil
+	}
+
+	// Ensure the transaction has more gas than the basic poolTx fee.
+	intrinsicGas, err := IntrinsicGas(poolTx.Transaction)
+	if err == nil && poolTx.Gas() < intrinsicGas {
+		err = ErrIntrinsicGas
+	}
+	if !report(TxCheckIntrinsicGas, err, fmt.Sprintf("gas %d, intrinsic gas %d", poolTx.Gas(), intrinsicGas)) {
+		return nil, nil
+	}
+
+	// try to get a transaction from the pool with the same nonce to check
+	// if the new one has a price bump
+	oldTxs, err := p.storage.GetTxsByFromAndNonce(ctx, from, poolTx.Nonce())
+	if err != nil {
+		log.Errorf("failed to txs for the same account and nonce while adding tx to the pool", err)
+		return nil, err
+	}
+	err, details = checkTxReplacement(poolTx, oldTxs)
+	if !report(TxCheckReplacement, err, details) {
+		return nil, nil
+	}
+
+	// Executor field size requirements check
+	if !report(TxCheckExecutorFields, p.checkTxFieldCompatibilityWithExecutor(ctx, poolTx.Transaction), "") {
+		return nil, nil
+	}
+
+	// pending txs quotas
+	err = p.checkQuotas(ctx, poolTx, from, oldTxs)
+	if err != nil && !errors.Is(err, ErrTxPoolAccountOverflow) && !errors.Is(err, ErrTxPoolIPOverflow) {
+		return nil, err
+	}
+	if !report(TxCheckQuotas, err, "") {
+		return nil, nil
+	}
+
+	return checkCtx, nil
+}
+
+// checkTxReplacement checks if the new transaction has more gas than all the other txs in the pool
+// with the same from and nonce to be able to replace the current txs by the new when being selected
+func checkTxReplacement(poolTx Transaction, oldTxs []Transaction) (error, string) { //nolint:revive,stylecheck
+	details := "no tx with the same nonce"
+	txPrice := new(big.Int).Mul(poolTx.GasPrice(), new(big.Int).SetUint64(poolTx.Gas()))
+	for _, oldTx := range oldTxs {
+		// discard invalid txs
+		if oldTx.Status == TxStatusInvalid || oldTx.Status == TxStatusFailed {
+			continue
+		}
+		if oldTx.Hash() == poolTx.Hash() {
+			return ErrAlreadyKnown, fmt.Sprintf("tx %s", oldTx.Hash().String())
+		}
+		// if old Tx Price is higher than the new poolTx price, it returns an error
+		oldTxPrice := new(big.Int).Mul(oldTx.GasPrice(), new(big.Int).SetUint64(oldTx.Gas()))
+		if oldTxPrice.Cmp(txPrice) > 0 {
+			return ErrReplaceUnderpriced, fmt.Sprintf("tx %s pays %v, the tx pays %v", oldTx.Hash().String(), oldTxPrice, txPrice)
+		}
+		details = fmt.Sprintf("replaces tx %s", oldTx.Hash().String())
+	}
+	return nil, details
+}
+
+// checkTxExecution pre-executes the tx to check its zk counters and its break even gas price
+func (p *Pool) checkTxExecution(ctx context.Context, result *TxCheckResult, tx types.Transaction) error {
+	preExecutionResponse, err := p.preExecuteTx(ctx, tx)
+	if errors.Is(err, runtime.ErrIntrinsicInvalidBatchGasLimit) {
+		result.add(TxCheckPreExecution, ErrGasLimit, "")
+		return nil
+	} else if err != nil {
+		result.add(TxCheckPreExecution, err, "")
+		return nil
+	}
+	result.UsedZKCounters = &preExecutionResponse.usedZKCounters
+	result.ReservedZKCounters = &preExecutionResponse.reservedZKCounters
+
+	oocErr := preExecutionResponse.OOCError
+	if oocErr == nil {
+		oocErr = p.batchConstraintsCfg.CheckNodeLevelOOC(preExecutionResponse.reservedZKCounters)
+	}
+	details := fmt.Sprintf("used steps %d, reserved steps %d", preExecutionResponse.usedZKCounters.Steps, preExecutionResponse.reservedZKCounters.Steps)
+	switch {
+	case oocErr != nil:
+		result.add(TxCheckPreExecution, fmt.Errorf("out of counters: %w", oocErr), details)
+	case preExecutionResponse.OOGError != nil:
+		// The txs running out of gas are accepted, they fail when executed
+		result.add(TxCheckPreExecution, nil, fmt.Sprintf("out of gas, %s", details))
+	case preExecutionResponse.isReverted:
+		result.add(TxCheckPreExecution, nil, fmt.Sprintf("reverted, %s", details))
+	default:
+		result.add(TxCheckPreExecution, nil, details)
+	}
+	if preExecutionResponse.txResponse == nil {
+		return nil
+	}
+
+	gasPrices, err := p.GetGasPrices(ctx)
+	if err != nil {
+		return err
+	}
+	gasUsed := preExecutionResponse.txResponse.GasUsed
+	txGasPrice, l2GasPrice := p.effectiveGasPrice.GetTxAndL2GasPrice(tx.GasPrice(), gasPrices.L1GasPrice, gasPrices.L2GasPrice)
+	breakEvenGasPrice, err := p.effectiveGasPrice.CalculateBreakEvenGasPrice(tx.Data(), txGasPrice, gasUsed, gasPrices.L1GasPrice)
+	if err == nil {
+		withFactor := new(big.Int)
+		new(big.Float).Mul(new(big.Float).SetInt(breakEvenGasPrice), big.NewFloat(p.cfg.EffectiveGasPrice.BreakEvenFactor)).Int(withFactor)
+		result.BreakEvenGasPrice = withFactor
+		details = fmt.Sprintf("gas price %v, break even gas price %v, l2 gas price %d, gas used %d, effective gas price enabled %t",
+			txGasPrice, withFactor, l2GasPrice, gasUsed, p.cfg.EffectiveGasPrice.Enabled)
+	} else {
+		details = fmt.Sprintf("break even gas price not calculated: %v", err)
+	}
+	result.add(TxCheckBreakEvenGasPrice, p.ValidateBreakEvenGasPrice(ctx, tx, gasUsed, gasPrices), details)
+	return nil
+}
@@ -0,0 +1,70 @@
+package pool_test
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getTxCheck(t *testing.T, result *pool.TxCheckResult, name string) pool.TxCheck {
+	for _, check := range result.Checks {
+		if check.Name == name {
+			return check
+		}
+	}
+	t.Fatalf("check %s not found", name)
+	return pool.TxCheck{}
+}
+
+func Test_CheckTx(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(senderPrivateKey, "0x"))
+	require.NoError(t, err)
+
+	p, s := setupQuotaPool(t, pool.QuotaCfg{}, 0, privateKey)
+	ctx := context.Background()
+
+	tx := newQuotaTestTx(t, privateKey, 0, gasPrice)
+	result, err := p.CheckTx(ctx, tx, ip)
+	require.NoError(t, err)
+	assert.True(t, result.Accepted)
+	assert.True(t, getTxCheck(t, result, pool.TxCheckSignature).Passed)
+	assert.True(t, getTxCheck(t, result, pool.TxCheckNonce).Passed)
+	assert.True(t, getTxCheck(t, result, pool.TxCheckBalance).Passed)
+	assert.True(t, getTxCheck(t, result, pool.TxCheckGlobalSlots).Passed)
+	assert.True(t, getTxCheck(t, result, pool.TxCheckGlobalQueue).Passed)
+	assert.NotNil(t, result.UsedZKCounters)
+	assert.NotNil(t, result.ReservedZKCounters)
+
+	// Nothing is stored
+	count, err := s.CountTransactionsByStatus(ctx, pool.TxStatusPending)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), count)
+
+	// A replacement paying less than the pending tx is rejected
+	require.NoError(t, p.AddTx(ctx, tx, ip))
+	result, err = p.CheckTx(ctx, newQuotaTestTx(t, privateKey, 0, new(big.Int).Sub(gasPrice, big.NewInt(1))), ip)
+	require.NoError(t, err)
+	assert.False(t, result.Accepted)
+	replacement := getTxCheck(t, result, pool.TxCheckReplacement)
+	assert.False(t, replacement.Passed)
+	assert.Contains(t, replacement.Details, pool.ErrReplaceUnderpriced.Error())
+
+	// A nonce too far ahead of the account nonce is rejected
+	result, err = p.CheckTx(ctx, newQuotaTestTx(t, privateKey, 1000, gasPrice), ip)
+	require.NoError(t, err)
+	assert.False(t, result.Accepted)
+	assert.False(t, getTxCheck(t, result, pool.TxCheckNonce).Passed)
+
+	// The checks of AddTx are run in the same order, an invalid IP is rejected before the signature is checked
+	result, err = p.CheckTx(ctx, newQuotaTestTx(t, privateKey, 1, gasPrice), "300.0.0.1")
+	require.NoError(t, err)
+	assert.False(t, result.Accepted)
+	assert.Equal(t, pool.TxCheckIP, result.Checks[0].Name)
+	assert.Contains(t, result.Checks[0].Details, pool.ErrInvalidIP.Error())
+}
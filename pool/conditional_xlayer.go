@@ -0,0 +1,158 @@
+package pool
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// MaxTxConditionalKnownSlots is the max number of storage slots that can be checked by a conditional tx
+	MaxTxConditionalKnownSlots = 1000
+)
+
+var (
+	// ErrTxConditionNotMet is returned when the conditions of a conditional tx don't hold
+	ErrTxConditionNotMet = errors.New("transaction conditional not met")
+
+	// ErrInvalidTxConditional is returned when the conditions of a conditional tx are not valid
+	ErrInvalidTxConditional = errors.New("invalid transaction conditional")
+)
+
+// ConditionalStateReader is the state needed to check the known accounts of a conditional tx
+type ConditionalStateReader interface {
+	GetStorageAt(ctx context.Context, address common.Address, position *big.Int, root common.Hash) (*big.Int, error)
+}
+
+// KnownAccount is the expected storage of an account, either its storage root or the value of some storage slots
+type KnownAccount struct {
+	StorageRoot  *common.Hash
+	StorageSlots map[common.Hash]common.Hash
+}
+
+// UnmarshalJSON decodes a storage root hash or a map of storage slots
+func (a *KnownAccount) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		var root common.Hash
+		if err := json.Unmarshal(data, &root); err != nil {
+			return err
+		}
+		a.StorageRoot = &root
+		return nil
+	}
+
+	var slots map[common.Hash]common.Hash
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&slots); err != nil {
+		return err
+	}
+	a.StorageSlots = slots
+	return nil
+}
+
+// MarshalJSON encodes the storage root hash or the map of storage slots
+func (a KnownAccount) MarshalJSON() ([]byte, error) {
+	if a.StorageRoot != nil {
+		return json.Marshal(a.StorageRoot)
+	}
+	return json.Marshal(a.StorageSlots)
+}
+
+// TxConditional are the conditions that must hold when a conditional tx is processed by the sequencer
+type TxConditional struct {
+	KnownAccounts  map[common.Address]KnownAccount `json:"knownAccounts,omitempty"`
+	BlockNumberMin *uint64                         `json:"blockNumberMin,omitempty"`
+	BlockNumberMax *uint64                         `json:"blockNumberMax,omitempty"`
+	TimestampMin   *uint64                         `json:"timestampMin,omitempty"`
+	TimestampMax   *uint64                         `json:"timestampMax,omitempty"`
+}
+
+// Validate checks that the conditions are well formed and can be checked by the node
+func (c *TxConditional) Validate() error {
+	if c.BlockNumberMin != nil && c.BlockNumberMax != nil && *c.BlockNumberMin > *c.BlockNumberMax {
+		return fmt.Errorf("%w: blockNumberMin is greater than blockNumberMax", ErrInvalidTxConditional)
+	}
+	if c.TimestampMin != nil && c.TimestampMax != nil && *c.TimestampMin > *c.TimestampMax {
+		return fmt.Errorf("%w: timestampMin is greater than timestampMax", ErrInvalidTxConditional)
+	}
+
+	slots := 0
+	for addr, account := range c.KnownAccounts {
+		// The state is a single sparse merkle tree, so there is no storage root per account
+		if account.StorageRoot != nil {
+			return fmt.Errorf("%w: storage root of account %s is not supported, use storage slots instead", ErrInvalidTxConditional, addr.String())
+		}
+		slots += len(account.StorageSlots)
+	}
+	if slots > MaxTxConditionalKnownSlots {
+		return fmt.Errorf("%w: too many known storage slots %d, max %d", ErrInvalidTxConditional, slots, MaxTxConditionalKnownSlots)
+	}
+
+	return nil
+}
+
+// CheckBlock checks the block number and timestamp bounds against the given block
+func (c *TxConditional) CheckBlock(number uint64, timestamp uint64) error {
+	if c.BlockNumberMin != nil && number < *c.BlockNumberMin {
+		return fmt.Errorf("%w: block number %d is lower than blockNumberMin %d", ErrTxConditionNotMet, number, *c.BlockNumberMin)
+	}
+	if c.BlockNumberMax != nil && number > *c.BlockNumberMax {
+		return fmt.Errorf("%w: block number %d is greater than blockNumberMax %d", ErrTxConditionNotMet, number, *c.BlockNumberMax)
+	}
+	if c.TimestampMin != nil && timestamp < *c.TimestampMin {
+		return fmt.Errorf("%w: timestamp %d is lower than timestampMin %d", ErrTxConditionNotMet, timestamp, *c.TimestampMin)
+	}
+	if c.TimestampMax != nil && timestamp > *c.TimestampMax {
+		return fmt.Errorf("%w: timestamp %d is greater than timestampMax %d", ErrTxConditionNotMet, timestamp, *c.TimestampMax)
+	}
+	return nil
+}
+
+// CheckKnownAccounts checks the storage slots of the known accounts against the state with the given root
+func (c *TxConditional) CheckKnownAccounts(ctx context.Context, st ConditionalStateReader, root common.Hash) error {
+	for addr, account := range c.KnownAccounts {
+		for slot, expected := range account.StorageSlots {
+			value, err := st.GetStorageAt(ctx, addr, slot.Big(), root)
+			if err != nil {
+				return err
+			}
+			if common.BigToHash(value) != expected {
+				return fmt.Errorf("%w: storage slot %s of account %s has changed", ErrTxConditionNotMet, slot.String(), addr.String())
+			}
+		}
+	}
+	return nil
+}
+
+// AddConditionalTx adds a conditional transaction to the pool with the pending state. The conditions are
+// checked against the last L2 block before adding the tx and again by the sequencer before processing it
+func (p *Pool) AddConditionalTx(ctx context.Context, tx types.Transaction, ip string, conditional TxConditional) error {
+	if err := conditional.Validate(); err != nil {
+		return err
+	}
+
+	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := conditional.CheckBlock(lastL2Block.NumberU64(), lastL2Block.Time()); err != nil {
+		return err
+	}
+	if err := conditional.CheckKnownAccounts(ctx, p.state, lastL2Block.Root()); err != nil {
+		return err
+	}
+
+	poolTx := NewTransaction(tx, ip, false, p)
+	if err := p.validateTx(ctx, *poolTx); err != nil {
+		return err
+	}
+
+	return p.storeTx(ctx, tx, ip, false, false, &conditional, nil)
+}
@@ -0,0 +1,82 @@
+package pool
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type conditionalStateReaderMock map[common.Hash]*big.Int
+
+func (m conditionalStateReaderMock) GetStorageAt(ctx context.Context, address common.Address, position *big.Int, root common.Hash) (*big.Int, error) {
+	value, found := m[common.BigToHash(position)]
+	if !found {
+		return nil, errors.New("not found")
+	}
+	return value, nil
+}
+
+func TestTxConditionalJSON(t *testing.T) {
+	input := `{
+		"knownAccounts": {
+			"0x0000000000000000000000000000000000000001": "0x0000000000000000000000000000000000000000000000000000000000000002",
+			"0x0000000000000000000000000000000000000003": {
+				"0x0000000000000000000000000000000000000000000000000000000000000004": "0x0000000000000000000000000000000000000000000000000000000000000005"
+			}
+		},
+		"blockNumberMin": 10,
+		"timestampMax": 20
+	}`
+
+	var conditional TxConditional
+	require.NoError(t, json.Unmarshal([]byte(input), &conditional))
+	require.Len(t, conditional.KnownAccounts, 2)
+	assert.Equal(t, common.Hash{31: 2}, *conditional.KnownAccounts[common.Address{19: 1}].StorageRoot)
+	assert.Equal(t, common.Hash{31: 5}, conditional.KnownAccounts[common.Address{19: 3}].StorageSlots[common.Hash{31: 4}])
+	assert.Equal(t, uint64(10), *conditional.BlockNumberMin)
+	assert.Nil(t, conditional.BlockNumberMax)
+	assert.Equal(t, uint64(20), *conditional.TimestampMax)
+
+	// The storage root is not supported
+	assert.ErrorIs(t, conditional.Validate(), ErrInvalidTxConditional)
+
+	data, err := json.Marshal(conditional)
+	require.NoError(t, err)
+	var decoded TxConditional
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, conditional, decoded)
+}
+
+func TestTxConditionalCheck(t *testing.T) {
+	minValue, maxValue := uint64(10), uint64(20)
+	conditional := TxConditional{
+		KnownAccounts: map[common.Address]KnownAccount{
+			{1}: {StorageSlots: map[common.Hash]common.Hash{{31: 1}: {31: 2}}},
+		},
+		BlockNumberMin: &minValue,
+		BlockNumberMax: &maxValue,
+		TimestampMin:   &minValue,
+		TimestampMax:   &maxValue,
+	}
+	require.NoError(t, conditional.Validate())
+
+	assert.NoError(t, conditional.CheckBlock(10, 20))
+	assert.ErrorIs(t, conditional.CheckBlock(9, 15), ErrTxConditionNotMet)
+	assert.ErrorIs(t, conditional.CheckBlock(21, 15), ErrTxConditionNotMet)
+	assert.ErrorIs(t, conditional.CheckBlock(15, 9), ErrTxConditionNotMet)
+	assert.ErrorIs(t, conditional.CheckBlock(15, 21), ErrTxConditionNotMet)
+
+	ctx := context.Background()
+	assert.NoError(t, conditional.CheckKnownAccounts(ctx, conditionalStateReaderMock{{31: 1}: big.NewInt(2)}, common.Hash{}))
+	assert.ErrorIs(t, conditional.CheckKnownAccounts(ctx, conditionalStateReaderMock{{31: 1}: big.NewInt(3)}, common.Hash{}), ErrTxConditionNotMet)
+
+	conditional.BlockNumberMin = &maxValue
+	conditional.BlockNumberMax = &minValue
+	assert.ErrorIs(t, conditional.Validate(), ErrInvalidTxConditional)
+}
@@ -75,6 +75,8 @@ type Config struct {
 	EnableFreeGasList bool `mapstructure:"EnableFreeGasList"`
 	// FreeGasList is the special project of XLayer
 	FreeGasList []FreeGasInfo `mapstructure:"FreeGasList"`
+	// Quota contains the quotas of pending txs per sender, per IP and for the whole pool
+	Quota QuotaCfg `mapstructure:"Quota"`
 }
 
 // FreeGasInfo contains the details for what tx should be free
@@ -45,6 +45,19 @@ var (
 	// AccountQueue and can't accept another remote transaction.
 	ErrTxPoolAccountOverflow = errors.New("account has reached the tx limit in the txpool")
 
+	// ErrTxPoolIPOverflow is returned if the IP sending the transaction has already
+	// reached the limit of pending transactions in the pool set by the config
+	// Quota.MaxPendingTxsPerIP and can't accept another remote transaction.
+	ErrTxPoolIPOverflow = errors.New("ip has reached the tx limit in the txpool")
+
+	// ErrUnderpriced is returned if the pool is full and the transaction doesn't pay
+	// a higher gas price than the cheapest transaction that can be evicted.
+	ErrUnderpriced = errors.New("transaction underpriced, txpool is full")
+
+	// ErrEvictedUnderpricedTransaction is the failed reason of the transactions
+	// evicted from the full pool to make room for a higher priced transaction.
+	ErrEvictedUnderpricedTransaction = errors.New("transaction evicted from the full txpool by a higher priced transaction")
+
 	// ErrTxPoolOverflow is returned if the transaction pool is full and can't accept
 	// another remote transaction.
 	ErrTxPoolOverflow = errors.New("txpool is full")
@@ -46,12 +46,20 @@ type storage interface {
 	GetReadyTxCount(ctx context.Context) (uint64, error)
 	AddFreeGasAddr(ctx context.Context, addr common.Address) error
 	IsFreeGasAddr(ctx context.Context, addr common.Address) (bool, error)
+	GetPublicTxsByStatus(ctx context.Context, status TxStatus, limit uint64) ([]Transaction, error)
+	GetPublicTxsByFromAndStatus(ctx context.Context, from common.Address, status ...TxStatus) ([]Transaction, error)
+	GetPublicTxNoncesByStatus(ctx context.Context, status TxStatus) (map[common.Address][]uint64, error)
+	CountTransactionsByIPAndStatus(ctx context.Context, ip string, status ...TxStatus) (uint64, error)
+	GetCheapestPendingTx(ctx context.Context, excludedSenders []common.Address) (*Transaction, error)
+	EvictTx(ctx context.Context, hash common.Hash, failedReason string) error
+	RestoreEvictedTx(ctx context.Context, hash common.Hash, failedReason string) error
 }
 
 type stateInterface interface {
 	GetBalance(ctx context.Context, address common.Address, root common.Hash) (*big.Int, error)
 	GetLastL2Block(ctx context.Context, dbTx pgx.Tx) (*state.L2Block, error)
 	GetNonce(ctx context.Context, address common.Address, root common.Hash) (uint64, error)
+	GetStorageAt(ctx context.Context, address common.Address, position *big.Int, root common.Hash) (*big.Int, error)
 	GetTransactionByHash(ctx context.Context, transactionHash common.Hash, dbTx pgx.Tx) (*types.Transaction, error)
 	PreProcessTransaction(ctx context.Context, tx *types.Transaction, dbTx pgx.Tx) (*state.ProcessBatchResponse, error)
 }
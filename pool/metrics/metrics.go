@@ -0,0 +1,125 @@
+package metrics
+
+import (
+	"github.com/0xPolygonHermez/zkevm-node/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// Prefix for the metrics of the pool package.
+	Prefix = "pool_"
+
+	// QuotaMaxPendingTxsPerSenderName is the name of the metric that shows the max number of pending txs of a sender.
+	QuotaMaxPendingTxsPerSenderName = Prefix + "quota_max_pending_txs_per_sender"
+
+	// QuotaMaxPendingTxsPerIPName is the name of the metric that shows the max number of pending txs sent from an IP.
+	QuotaMaxPendingTxsPerIPName = Prefix + "quota_max_pending_txs_per_ip"
+
+	// QuotaGlobalSlotsName is the name of the metric that shows the number of pending txs of the pool from which txs are evicted.
+	QuotaGlobalSlotsName = Prefix + "quota_global_slots"
+
+	// QuotaGlobalQueueName is the name of the metric that shows the max number of pending txs of the pool.
+	QuotaGlobalQueueName = Prefix + "quota_global_queue"
+
+	// PendingTxsName is the name of the metric that shows the number of pending txs of the pool when the global slots and queue are checked.
+	PendingTxsName = Prefix + "pending_txs"
+
+	// QuotaRejectedTxsName is the name of the metric that counts the txs rejected by a quota.
+	QuotaRejectedTxsName = Prefix + "quota_rejected_txs"
+
+	// QuotaEvictedTxsName is the name of the metric that counts the txs evicted to make room for higher priced txs.
+	QuotaEvictedTxsName = Prefix + "quota_evicted_txs"
+
+	// QuotaLabelName is the name of the label for the quota that rejected a tx.
+	QuotaLabelName = "quota"
+)
+
+// QuotaLabel is the quota that rejected a tx
+type QuotaLabel string
+
+const (
+	// QuotaLabelSender is the label of the per sender quota
+	QuotaLabelSender QuotaLabel = "sender"
+	// QuotaLabelIP is the label of the per IP quota
+	QuotaLabelIP QuotaLabel = "ip"
+	// QuotaLabelGlobalSlots is the label of the global slots quota
+	QuotaLabelGlobalSlots QuotaLabel = "global_slots"
+	// QuotaLabelGlobalQueue is the label of the global queue of the pool
+	QuotaLabelGlobalQueue QuotaLabel = "global_queue"
+)
+
+// Register the metrics for the pool package.
+func Register() {
+	var (
+		gauges      []prometheus.GaugeOpts
+		counters    []prometheus.CounterOpts
+		counterVecs []metrics.CounterVecOpts
+	)
+
+	gauges = []prometheus.GaugeOpts{
+		{
+			Name: QuotaMaxPendingTxsPerSenderName,
+			Help: "[POOL] max number of pending txs of a sender, 0 means no limit",
+		},
+		{
+			Name: QuotaMaxPendingTxsPerIPName,
+			Help: "[POOL] max number of pending txs sent from an IP, 0 means no limit",
+		},
+		{
+			Name: QuotaGlobalSlotsName,
+			Help: "[POOL] number of pending txs of the pool from which txs are evicted, 0 means no eviction",
+		},
+		{
+			Name: QuotaGlobalQueueName,
+			Help: "[POOL] max number of pending txs of the pool, 0 means no limit",
+		},
+		{
+			Name: PendingTxsName,
+			Help: "[POOL] number of pending txs of the pool",
+		},
+	}
+
+	counters = []prometheus.CounterOpts{
+		{
+			Name: QuotaEvictedTxsName,
+			Help: "[POOL] number of txs evicted to make room for higher priced txs",
+		},
+	}
+
+	counterVecs = []metrics.CounterVecOpts{
+		{
+			CounterOpts: prometheus.CounterOpts{
+				Name: QuotaRejectedTxsName,
+				Help: "[POOL] number of txs rejected by quota",
+			},
+			Labels: []string{QuotaLabelName},
+		},
+	}
+
+	metrics.RegisterGauges(gauges...)
+	metrics.RegisterCounters(counters...)
+	metrics.RegisterCounterVecs(counterVecs...)
+}
+
+// Quotas sets the gauges to the given quotas.
+func Quotas(maxPendingTxsPerSender, maxPendingTxsPerIP, globalSlots, globalQueue uint64) {
+	metrics.GaugeSet(QuotaMaxPendingTxsPerSenderName, float64(maxPendingTxsPerSender))
+	metrics.GaugeSet(QuotaMaxPendingTxsPerIPName, float64(maxPendingTxsPerIP))
+	metrics.GaugeSet(QuotaGlobalSlotsName, float64(globalSlots))
+	metrics.GaugeSet(QuotaGlobalQueueName, float64(globalQueue))
+}
+
+// PendingTxs sets the gauge to the given number of pending txs.
+func PendingTxs(count uint64) {
+	metrics.GaugeSet(PendingTxsName, float64(count))
+}
+
+// QuotaRejectedTx increases the counter of txs rejected by the given quota.
+func QuotaRejectedTx(quota QuotaLabel) {
+	metrics.CounterVecInc(QuotaRejectedTxsName, string(quota))
+}
+
+// QuotaEvictedTx increases the counter of evicted txs.
+func QuotaEvictedTx() {
+	metrics.CounterInc(QuotaEvictedTxsName)
+}
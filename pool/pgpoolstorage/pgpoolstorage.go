@@ -76,10 +76,13 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 			is_wip,
 			ip,
 			failed_reason,
-			reserved_zkcounters
+			reserved_zkcounters,
+			is_private,
+			conditional,
+			validity_window
 		) 
 		VALUES 
-			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NULL, $20)
+			($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, NULL, $20, $21, $22, $23)
 			ON CONFLICT (hash) DO UPDATE SET 
 			encoded = $2,
 			decoded = $3,
@@ -100,7 +103,10 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 			is_wip = $18,
 			ip = $19,
 			failed_reason = NULL,
-			reserved_zkcounters = $20
+			reserved_zkcounters = $20,
+			is_private = $21,
+			conditional = $22,
+			validity_window = $23
 	`
 
 	// Get FromAddress from the JSON data
@@ -130,7 +136,10 @@ func (p *PostgresPoolStorage) AddTx(ctx context.Context, tx pool.Transaction) er
 		fromAddress,
 		tx.IsWIP,
 		tx.IP,
-		tx.ReservedZKCounters); err != nil {
+		tx.ReservedZKCounters,
+		tx.IsPrivate,
+		tx.Conditional,
+		tx.ValidityWindow); err != nil {
 		return err
 	}
 	return nil
@@ -147,11 +156,11 @@ func (p *PostgresPoolStorage) GetTxsByStatus(ctx context.Context, status pool.Tx
 	)
 	if limit == 0 {
 		sql = `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
-				used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters FROM pool.transaction WHERE status = $1 ORDER BY gas_price DESC`
+				used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, conditional, validity_window, is_private FROM pool.transaction WHERE status = $1 ORDER BY gas_price DESC`
 		rows, err = p.db.Query(ctx, sql, status.String())
 	} else {
 		sql = `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
-				used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters FROM pool.transaction WHERE status = $1 ORDER BY gas_price DESC LIMIT $2`
+				used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, conditional, validity_window, is_private FROM pool.transaction WHERE status = $1 ORDER BY gas_price DESC LIMIT $2`
 		rows, err = p.db.Query(ctx, sql, status.String(), limit)
 	}
 	if err != nil {
@@ -183,11 +192,11 @@ func (p *PostgresPoolStorage) GetNonWIPPendingTxs(ctx context.Context, limit uin
 
 	if limit == 0 {
 		sql = `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
-		used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters FROM pool.transaction WHERE is_wip IS FALSE and status = $1 ORDER BY gas_price DESC`
+		used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, conditional, validity_window, is_private FROM pool.transaction WHERE is_wip IS FALSE and status = $1 ORDER BY gas_price DESC`
 		rows, err = p.db.Query(ctx, sql, pool.TxStatusPending)
 	} else {
 		sql = `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
-		used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters FROM pool.transaction WHERE is_wip IS FALSE and status = $1 ORDER BY gas_price DESC LIMIT $2`
+		used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, conditional, validity_window, is_private FROM pool.transaction WHERE is_wip IS FALSE and status = $1 ORDER BY gas_price DESC LIMIT $2`
 		rows, err = p.db.Query(ctx, sql, pool.TxStatusPending, limit)
 	}
 	if err != nil {
@@ -207,9 +216,9 @@ func (p *PostgresPoolStorage) GetNonWIPPendingTxs(ctx context.Context, limit uin
 	return txs, nil
 }
 
-// GetPendingTxHashesSince returns the pending tx since the given time.
+// GetPendingTxHashesSince returns the pending tx since the given time, private txs are excluded.
 func (p *PostgresPoolStorage) GetPendingTxHashesSince(ctx context.Context, since time.Time) ([]common.Hash, error) {
-	sql := "SELECT hash FROM pool.transaction WHERE status = $1 AND received_at >= $2"
+	sql := "SELECT hash FROM pool.transaction WHERE status = $1 AND received_at >= $2 AND is_private IS FALSE"
 	rows, err := p.db.Query(ctx, sql, pool.TxStatusPending, since)
 	if err != nil {
 		return nil, err
@@ -526,7 +535,7 @@ func (p *PostgresPoolStorage) IsTxPending(ctx context.Context, hash common.Hash)
 // GetTxsByFromAndNonce get all the transactions from the pool with the same from and nonce
 func (p *PostgresPoolStorage) GetTxsByFromAndNonce(ctx context.Context, from common.Address, nonce uint64) ([]pool.Transaction, error) {
 	sql := `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, 
-				   used_poseidon_paddings, used_mem_aligns,	used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters
+				   used_poseidon_paddings, used_mem_aligns,	used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, conditional, validity_window, is_private
 	          FROM pool.transaction
 			 WHERE from_address = $1
 			   AND nonce = $2`
@@ -609,13 +618,15 @@ func (p *PostgresPoolStorage) GetTransactionByHash(ctx context.Context, hash com
 	var (
 		encoded, status, ip string
 		receivedAt          time.Time
-		isWIP               bool
+		isWIP, isPrivate    bool
+		failedReason        *string
+		selectedAt          *time.Time
 	)
 
-	sql := `SELECT encoded, status, received_at, is_wip, ip
+	sql := `SELECT encoded, status, received_at, is_wip, ip, is_private, failed_reason, selected_at
 	          FROM pool.transaction
 			 WHERE hash = $1`
-	err := p.db.QueryRow(ctx, sql, hash.String()).Scan(&encoded, &status, &receivedAt, &isWIP, &ip)
+	err := p.db.QueryRow(ctx, sql, hash.String()).Scan(&encoded, &status, &receivedAt, &isWIP, &ip, &isPrivate, &failedReason, &selectedAt)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, pool.ErrNotFound
 	} else if err != nil {
@@ -633,11 +644,14 @@ func (p *PostgresPoolStorage) GetTransactionByHash(ctx context.Context, hash com
 	}
 
 	poolTx := &pool.Transaction{
-		ReceivedAt:  receivedAt,
-		Status:      pool.TxStatus(status),
-		Transaction: *tx,
-		IsWIP:       isWIP,
-		IP:          ip,
+		ReceivedAt:   receivedAt,
+		Status:       pool.TxStatus(status),
+		Transaction:  *tx,
+		IsWIP:        isWIP,
+		IP:           ip,
+		IsPrivate:    isPrivate,
+		FailedReason: failedReason,
+		SelectedAt:   selectedAt,
 	}
 
 	return poolTx, nil
@@ -648,13 +662,15 @@ func (p *PostgresPoolStorage) GetTransactionByL2Hash(ctx context.Context, hash c
 	var (
 		encoded, status, ip string
 		receivedAt          time.Time
-		isWIP               bool
+		isWIP, isPrivate    bool
+		failedReason        *string
+		selectedAt          *time.Time
 	)
 
-	sql := `SELECT encoded, status, received_at, is_wip, ip
+	sql := `SELECT encoded, status, received_at, is_wip, ip, is_private, failed_reason, selected_at
 	          FROM pool.transaction
 			 WHERE l2_hash = $1`
-	err := p.db.QueryRow(ctx, sql, hash.String()).Scan(&encoded, &status, &receivedAt, &isWIP, &ip)
+	err := p.db.QueryRow(ctx, sql, hash.String()).Scan(&encoded, &status, &receivedAt, &isWIP, &ip, &isPrivate, &failedReason, &selectedAt)
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, pool.ErrNotFound
 	} else if err != nil {
@@ -672,11 +688,14 @@ func (p *PostgresPoolStorage) GetTransactionByL2Hash(ctx context.Context, hash c
 	}
 
 	poolTx := &pool.Transaction{
-		ReceivedAt:  receivedAt,
-		Status:      pool.TxStatus(status),
-		Transaction: *tx,
-		IsWIP:       isWIP,
-		IP:          ip,
+		ReceivedAt:   receivedAt,
+		Status:       pool.TxStatus(status),
+		Transaction:  *tx,
+		IsWIP:        isWIP,
+		IP:           ip,
+		IsPrivate:    isPrivate,
+		FailedReason: failedReason,
+		SelectedAt:   selectedAt,
 	}
 
 	return poolTx, nil
@@ -698,10 +717,13 @@ func scanTx(rows pgx.Rows) (*pool.Transaction, error) {
 		usedSHA256Hashes     uint32
 		failedReason         *string
 		reservedZKCounters   state.ZKCounters
+		conditional          *pool.TxConditional
+		validityWindow       *pool.TxValidityWindow
+		isPrivate            bool
 	)
 
 	if err := rows.Scan(&encoded, &status, &receivedAt, &isWIP, &ip, &cumulativeGasUsed, &usedKeccakHashes, &usedPoseidonHashes,
-		&usedPoseidonPaddings, &usedMemAligns, &usedArithmetics, &usedBinaries, &usedSteps, &usedSHA256Hashes, &failedReason, &reservedZKCounters); err != nil {
+		&usedPoseidonPaddings, &usedMemAligns, &usedArithmetics, &usedBinaries, &usedSteps, &usedSHA256Hashes, &failedReason, &reservedZKCounters, &conditional, &validityWindow, &isPrivate); err != nil {
 		return nil, err
 	}
 
@@ -731,6 +753,9 @@ func scanTx(rows pgx.Rows) (*pool.Transaction, error) {
 	tx.ZKCounters.Sha256Hashes_V2 = usedSHA256Hashes
 	tx.FailedReason = failedReason
 	tx.ReservedZKCounters = reservedZKCounters
+	tx.Conditional = conditional
+	tx.ValidityWindow = validityWindow
+	tx.IsPrivate = isPrivate
 
 	return tx, nil
 }
@@ -775,7 +800,8 @@ func (p *PostgresPoolStorage) MarkWIPTxsAsPending(ctx context.Context) error {
 // UpdateTxWIPStatus updates a transaction wip status accordingly to the
 // provided WIP status and hash
 func (p *PostgresPoolStorage) UpdateTxWIPStatus(ctx context.Context, hash common.Hash, isWIP bool) error {
-	sql := "UPDATE pool.transaction SET is_wip = $1 WHERE hash = $2"
+	// XLayer the time the tx is selected by the sequencer is kept for the tx status
+	sql := "UPDATE pool.transaction SET is_wip = $1, selected_at = CASE WHEN $1 THEN NOW() ELSE selected_at END WHERE hash = $2"
 	if _, err := p.db.Exec(ctx, sql, isWIP, hash.Hex()); err != nil {
 		return err
 	}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"time"
 
+	"github.com/0xPolygonHermez/zkevm-node/pool"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/jackc/pgx/v4"
 )
@@ -124,3 +125,164 @@ func (p *PostgresPoolStorage) AddFreeGasAddr(ctx context.Context, addr common.Ad
 
 	return nil
 }
+
+// GetPublicTxsByStatus gets the non private txs with the given status sorted by gas price,
+// limit parameter is used to limit amount of txs from the db, if limit = 0, then there is no limit
+func (p *PostgresPoolStorage) GetPublicTxsByStatus(ctx context.Context, status pool.TxStatus, limit uint64) ([]pool.Transaction, error) {
+	var (
+		rows pgx.Rows
+		err  error
+		sql  string
+	)
+	if limit == 0 {
+		sql = `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
+				used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, conditional, validity_window, is_private FROM pool.transaction WHERE status = $1 AND is_private IS FALSE ORDER BY gas_price DESC`
+		rows, err = p.db.Query(ctx, sql, status.String())
+	} else {
+		sql = `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes, used_poseidon_paddings, used_mem_aligns,
+				used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, conditional, validity_window, is_private FROM pool.transaction WHERE status = $1 AND is_private IS FALSE ORDER BY gas_price DESC LIMIT $2`
+		rows, err = p.db.Query(ctx, sql, status.String(), limit)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	txs := make([]pool.Transaction, 0, len(rows.RawValues()))
+	for rows.Next() {
+		tx, err := scanTx(rows)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, *tx)
+	}
+
+	return txs, nil
+}
+
+// GetPublicTxsByFromAndStatus gets the non private txs sent by the given address with any of the given status, sorted by nonce
+func (p *PostgresPoolStorage) GetPublicTxsByFromAndStatus(ctx context.Context, from common.Address, status ...pool.TxStatus) ([]pool.Transaction, error) {
+	sql := `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes,
+				   used_poseidon_paddings, used_mem_aligns, used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, conditional, validity_window, is_private
+			  FROM pool.transaction
+			 WHERE from_address = $1
+			   AND status = ANY ($2)
+			   AND is_private IS FALSE
+		  ORDER BY nonce`
+	rows, err := p.db.Query(ctx, sql, from.String(), status)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	txs := make([]pool.Transaction, 0, len(rows.RawValues()))
+	for rows.Next() {
+		tx, err := scanTx(rows)
+		if err != nil {
+			return nil, err
+		}
+		txs = append(txs, *tx)
+	}
+
+	return txs, nil
+}
+
+// GetPublicTxNoncesByStatus gets the distinct nonces, sorted, of the non private txs with the given status grouped by sender
+func (p *PostgresPoolStorage) GetPublicTxNoncesByStatus(ctx context.Context, status pool.TxStatus) (map[common.Address][]uint64, error) {
+	sql := `SELECT from_address, array_agg(DISTINCT nonce::BIGINT ORDER BY nonce::BIGINT)
+			  FROM pool.transaction
+			 WHERE status = $1
+			   AND is_private IS FALSE
+		  GROUP BY from_address`
+	rows, err := p.db.Query(ctx, sql, status.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	nonces := make(map[common.Address][]uint64)
+	for rows.Next() {
+		var from string
+		var senderNonces []int64
+		if err := rows.Scan(&from, &senderNonces); err != nil {
+			return nil, err
+		}
+		result := make([]uint64, 0, len(senderNonces))
+		for _, nonce := range senderNonces {
+			result = append(result, uint64(nonce))
+		}
+		nonces[common.HexToAddress(from)] = result
+	}
+
+	return nonces, rows.Err()
+}
+
+// CountTransactionsByIPAndStatus count all the transactions sent from the given IP with any of the given status
+func (p *PostgresPoolStorage) CountTransactionsByIPAndStatus(ctx context.Context, ip string, status ...pool.TxStatus) (uint64, error) {
+	sql := "SELECT COUNT(*) FROM pool.transaction WHERE ip = $1 AND status = ANY ($2)"
+	var counter uint64
+	err := p.db.QueryRow(ctx, sql, ip, status).Scan(&counter)
+	if err != nil {
+		return 0, err
+	}
+	return counter, nil
+}
+
+// GetCheapestPendingTx gets the pending tx with the lowest gas price not sent by the excluded senders, the txs loaded
+// by the sequencer are included but not the ones it refused to evict because it is executing them. For the same gas
+// price the tx with the highest nonce is returned to avoid nonce gaps
+func (p *PostgresPoolStorage) GetCheapestPendingTx(ctx context.Context, excludedSenders []common.Address) (*pool.Transaction, error) {
+	sql := `SELECT encoded, status, received_at, is_wip, ip, cumulative_gas_used, used_keccak_hashes, used_poseidon_hashes,
+				   used_poseidon_paddings, used_mem_aligns, used_arithmetics, used_binaries, used_steps, used_sha256_hashes, failed_reason, reserved_zkcounters, conditional, validity_window, is_private
+			  FROM pool.transaction
+			 WHERE status = $1
+			   AND (is_wip IS FALSE OR failed_reason IS DISTINCT FROM $3)
+			   AND from_address <> ALL ($2)
+		  ORDER BY gas_price ASC, nonce DESC
+			 LIMIT 1`
+
+	senders := make([]string, 0, len(excludedSenders))
+	for _, sender := range excludedSenders {
+		senders = append(senders, sender.String())
+	}
+
+	rows, err := p.db.Query(ctx, sql, pool.TxStatusPending, senders, pool.ErrTxWIP.Error())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+		return nil, pool.ErrNotFound
+	}
+	return scanTx(rows)
+}
+
+// EvictTx marks a pending tx as evicted with the given failed reason, pool.ErrTxNotPending is
+// returned if the tx is no longer pending so a tx selected in the meantime is not evicted
+func (p *PostgresPoolStorage) EvictTx(ctx context.Context, hash common.Hash, failedReason string) error {
+	sql := `UPDATE pool.transaction SET status = $1, failed_reason = $2 WHERE hash = $3 AND status = $4`
+
+	res, err := p.db.Exec(ctx, sql, pool.TxStatusEvicted, failedReason, hash.String(), pool.TxStatusPending)
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return pool.ErrTxNotPending
+	}
+	return nil
+}
+
+// RestoreEvictedTx sets back as pending and wip an evicted tx the sequencer refused to remove
+// from the worker, the failed reason keeps why the eviction was refused
+func (p *PostgresPoolStorage) RestoreEvictedTx(ctx context.Context, hash common.Hash, failedReason string) error {
+	sql := `UPDATE pool.transaction SET status = $1, is_wip = TRUE, failed_reason = $2 WHERE hash = $3 AND status = $4`
+
+	_, err := p.db.Exec(ctx, sql, pool.TxStatusPending, failedReason, hash.String(), pool.TxStatusEvicted)
+	return err
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/0xPolygonHermez/zkevm-node/event"
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool/metrics"
 	"github.com/0xPolygonHermez/zkevm-node/state"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
 	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
@@ -95,6 +96,9 @@ func NewPool(cfg Config, batchConstraintsCfg state.BatchConstraintsCfg, s storag
 		effectiveGasPrice:       NewEffectiveGasPrice(cfg.EffectiveGasPrice),
 		dgpMux:                  new(sync.RWMutex),
 	}
+	metrics.Register()
+	quota := getQuotaCfg(cfg.Quota)
+	metrics.Quotas(quota.MaxPendingTxsPerSender, quota.MaxPendingTxsPerIP, quota.GlobalSlots, getGlobalQueue(cfg.GlobalQueue))
 	p.refreshGasPrices()
 	go func(cfg *Config, p *Pool) {
 		for {
@@ -196,6 +200,10 @@ func (p *Pool) AddTx(ctx context.Context, tx types.Transaction, ip string) error
 
 // StoreTx adds a transaction to the pool with the pending state
 func (p *Pool) StoreTx(ctx context.Context, tx types.Transaction, ip string, isWIP bool) error {
+	return p.storeTx(ctx, tx, ip, isWIP, false, nil, nil)
+}
+
+func (p *Pool) storeTx(ctx context.Context, tx types.Transaction, ip string, isWIP bool, isPrivate bool, conditional *TxConditional, validityWindow *TxValidityWindow) error {
 	// Execute transaction to calculate its zkCounters
 	preExecutionResponse, err := p.preExecuteTx(ctx, tx)
 	if errors.Is(err, runtime.ErrIntrinsicInvalidBatchGasLimit) {
@@ -264,8 +272,34 @@ func (p *Pool) StoreTx(ctx context.Context, tx types.Transaction, ip string, isW
 	poolTx := NewTransaction(tx, ip, isWIP, p)
 	poolTx.ZKCounters = preExecutionResponse.usedZKCounters
 	poolTx.ReservedZKCounters = preExecutionResponse.reservedZKCounters
+	// XLayer private, conditional and scheduled txs
+	poolTx.IsPrivate = isPrivate
+	poolTx.Conditional = conditional
+	poolTx.ValidityWindow = validityWindow
+
+	// XLayer global slots and queue, the txs reorged by the synchronizer are always stored
+	var txToEvict *Transaction
+	if !isWIP {
+		from, err := state.GetSender(tx)
+		if err != nil {
+			return ErrInvalidSender
+		}
+		txToEvict, err = p.getTxToEvict(ctx, *poolTx, from)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := p.storage.AddTx(ctx, *poolTx); err != nil {
+		return err
+	}
 
-	return p.storage.AddTx(ctx, *poolTx)
+	// XLayer make room for the stored tx
+	if txToEvict != nil {
+		return p.makeRoomForTx(ctx, *txToEvict, *poolTx)
+	}
+
+	return nil
 }
 
 // ValidateBreakEvenGasPrice validates the effective gas price
@@ -424,207 +458,22 @@ func (p *Pool) IsTxPending(ctx context.Context, hash common.Hash) (bool, error)
 }
 
 func (p *Pool) validateTx(ctx context.Context, poolTx Transaction) error {
-	// Make sure the IP is valid.
-	if poolTx.IP != "" && !IsValidIP(poolTx.IP) {
-		return ErrInvalidIP
-	}
-
-	// Make sure the transaction is signed properly.
-	if err := state.CheckSignature(poolTx.Transaction); err != nil {
-		return ErrInvalidSender
-	}
-
-	// check chain id
-	txChainID := poolTx.ChainId().Uint64()
-	if txChainID != p.chainID && txChainID != 0 {
-		return ErrInvalidChainID
-	}
-
-	// Accept only legacy transactions until EIP-2718/2930 activates.
-	if poolTx.Type() != types.LegacyTxType {
-		return ErrTxTypeNotSupported
-	}
-
-	// check Pre EIP155 txs signature
-	if txChainID == 0 && !state.IsPreEIP155Tx(poolTx.Transaction) {
-		return ErrInvalidSender
-	}
-
-	// gets tx sender for validations
-	from, err := state.GetSender(poolTx.Transaction)
-	if err != nil {
-		return ErrInvalidSender
-	}
-
-	// Reject transactions over defined size to prevent DOS attacks
-	decodedTx, err := state.EncodeTransaction(poolTx.Transaction, 0xFF, p.cfg.ForkID) //nolint: gomnd
-	if err != nil {
-		return ErrTxTypeNotSupported
-	}
-
-	if uint64(len(decodedTx)) > p.cfg.MaxTxBytesSize {
-		log.Infof("%v: %v", ErrOversizedData.Error(), from.String())
-		return ErrOversizedData
-	}
-
-	// Transactions can't be negative. This may never happen using RLP decoded
-	// transactions but may occur if you create a transaction using the RPC.
-	if poolTx.Value().Sign() < 0 {
-		return ErrNegativeValue
-	}
-
-	if err := checkTxFee(poolTx.GasPrice(), poolTx.Gas(), p.cfg.TxFeeCap); err != nil {
-		return err
-	}
-
-	// check if sender is blocked
-	blocked := p.checkBlockedAddr(from)
-	if blocked {
-		log.Infof("%v: %v", ErrBlockedSender.Error(), from.String())
-		return ErrBlockedSender
-	}
-
-	// XLayer check if receiver is blocked
-	if to := poolTx.To(); to != nil {
-		blocked = p.checkBlockedAddr(*to)
-		if blocked {
-			log.Infof("%v: %v", ErrBlockedReceiver.Error(), to.String())
-			return ErrBlockedReceiver
-		}
-	}
-
-	// XLayer check if sender is whitelisted
-	if getEnableWhitelist(p.cfg.EnableWhitelist) {
-		_, listed := p.whitelistedAddresses.Load(from.String())
-		if !listed {
-			log.Infof("%v: %v", ErrNoWhitelistedSender.Error(), from.String())
-			return ErrNoWhitelistedSender
-		}
-	}
-
-	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
-	if err != nil {
-		log.Errorf("failed to load last l2 block while adding tx to the pool", err)
-		return err
-	}
-
-	currentNonce, err := p.state.GetNonce(ctx, from, lastL2Block.Root())
-	if err != nil {
-		log.Errorf("failed to get nonce while adding tx to the pool", err)
-		return err
-	}
-	// Ensure the transaction adheres to nonce ordering
-	if poolTx.Nonce() < currentNonce {
-		return ErrNonceTooLow
-	}
-
-	// XLayer check if sender has reached the limit of transactions in the pool
-	accountQueue := getAccountQueue(p.cfg.AccountQueue)
-	if accountQueue > 0 {
-		// txCount, err := p.storage.CountTransactionsByFromAndStatus(ctx, from, TxStatusPending)
-		// if err != nil {
-		// 	return err
-		// }
-		// if txCount >= p.cfg.AccountQueue {
-		// 	return ErrTxPoolAccountOverflow
-		// }
-
-		// Ensure the transaction does not jump out of the expected AccountQueue
-		if poolTx.Nonce() > currentNonce+accountQueue-1 {
-			log.Infof("%v: %v", ErrNonceTooHigh.Error(), from.String())
-			return ErrNonceTooHigh
-		}
-	}
-
-	// check if the pool is full
-	globalQueue := getGlobalQueue(p.cfg.GlobalQueue)
-	if globalQueue > 0 {
-		txCount, err := p.storage.CountTransactionsByStatus(ctx, TxStatusPending)
-		if err != nil {
-			log.Errorf("failed to count pool txs by status pending while adding tx to the pool", err)
-			return err
-		}
-		if txCount >= globalQueue {
-			return ErrTxPoolOverflow
-		}
-	}
-
-	freeGp, err := p.checkFreeGp(ctx, poolTx, from)
-	if err != nil {
-		return err
-	}
-
-	if !freeGp { // XLayer handle
-		p.minSuggestedGasPriceMux.RLock()
-		gasPriceCmp := poolTx.GasPrice().Cmp(p.minSuggestedGasPrice)
-		if gasPriceCmp == -1 {
-			log.Debugf("low gas price: minSuggestedGasPrice %v got %v", p.minSuggestedGasPrice, poolTx.GasPrice())
-		}
-		p.minSuggestedGasPriceMux.RUnlock()
-		if gasPriceCmp == -1 {
-			return ErrGasPrice
-		}
-	}
-
-	// Transactor should have enough funds to cover the costs
-	// cost == V + GP * GL
-	balance, err := p.state.GetBalance(ctx, from, lastL2Block.Root())
-	if err != nil {
-		log.Errorf("failed to get balance for account %v while adding tx to the pool", from.String(), err)
-		return err
-	}
-
-	if balance.Cmp(poolTx.Cost()) < 0 {
-		return ErrInsufficientFunds
-	}
-
-	// Ensure the transaction has more gas than the basic poolTx fee.
-	intrGas, err := IntrinsicGas(poolTx.Transaction)
-	if err != nil {
-		return err
-	}
-	if poolTx.Gas() < intrGas {
-		return ErrIntrinsicGas
-	}
-
-	// try to get a transaction from the pool with the same nonce to check
-	// if the new one has a price bump
-	oldTxs, err := p.storage.GetTxsByFromAndNonce(ctx, from, poolTx.Nonce())
+	// XLayer the admission checks are shared with CheckTx, the first failed check rejects the tx
+	var checkErr error
+	checkCtx, err := p.checkTx(ctx, poolTx, func(name string, err error, details string) bool {
+		checkErr = err
+		return err == nil
+	})
 	if err != nil {
-		log.Errorf("failed to txs for the same account and nonce while adding tx to the pool", err)
 		return err
 	}
-
-	// check if the new transaction has more gas than all the other txs in the pool
-	// with the same from and nonce to be able to replace the current txs by the new
-	// when being selected
-	for _, oldTx := range oldTxs {
-		// discard invalid txs
-		if oldTx.Status == TxStatusInvalid || oldTx.Status == TxStatusFailed {
-			continue
-		}
-
-		oldTxPrice := new(big.Int).Mul(oldTx.GasPrice(), new(big.Int).SetUint64(oldTx.Gas()))
-		txPrice := new(big.Int).Mul(poolTx.GasPrice(), new(big.Int).SetUint64(poolTx.Gas()))
-
-		if oldTx.Hash() == poolTx.Hash() {
-			return ErrAlreadyKnown
-		}
-
-		// if old Tx Price is higher than the new poolTx price, it returns an error
-		if oldTxPrice.Cmp(txPrice) > 0 {
-			return ErrReplaceUnderpriced
-		}
-	}
-
-	// Executor field size requirements check
-	if err := p.checkTxFieldCompatibilityWithExecutor(ctx, poolTx.Transaction); err != nil {
-		return err
+	if checkErr != nil {
+		return checkErr
 	}
 
 	// XLayer free gas
 	if getEnableFreeGasByNonce(p.cfg.EnableFreeGasByNonce) {
-		if err := p.checkAndUpdateFreeGasAddr(ctx, poolTx, from, lastL2Block.Root()); err != nil {
+		if err := p.checkAndUpdateFreeGasAddr(ctx, poolTx, checkCtx.from, checkCtx.root); err != nil {
 			return err
 		}
 	}
@@ -179,6 +179,19 @@ func (p *Pool) checkFreeGp(ctx context.Context, poolTx Transaction, from common.
 
 func (p *Pool) checkAndUpdateFreeGasAddr(ctx context.Context, poolTx Transaction, from common.Address, root common.Hash) error {
 	// check and store the free gas address
+	freeGpAddr, err := p.getFreeGasAddrToAdd(ctx, poolTx, from, root)
+	if err != nil || freeGpAddr == nil {
+		return err
+	}
+	if err = p.storage.AddFreeGasAddr(ctx, *freeGpAddr); err != nil {
+		log.Errorf("failed to save free gas address to the storage", err)
+		return err
+	}
+	return nil
+}
+
+// getFreeGasAddrToAdd returns the address the tx makes gas-free, nil if there's none or it has used its free gas txs
+func (p *Pool) getFreeGasAddrToAdd(ctx context.Context, poolTx Transaction, from common.Address, root common.Hash) (*common.Address, error) {
 	var freeGpAddr common.Address
 	inputHex := hex.EncodeToHex(poolTx.Data())
 	// hard code
@@ -186,7 +199,7 @@ func (p *Pool) checkAndUpdateFreeGasAddr(ctx context.Context, poolTx Transaction
 		if strings.HasPrefix(inputHex, ExWithdrawalMethodSignature) && len(inputHex) > 74 { // erc20 contract transfer
 			addrHex := "0x" + inputHex[10:74]
 			freeGpAddr = common.HexToAddress(addrHex)
-		} else {
+		} else if poolTx.To() != nil {
 			// the to address of any Ex withdrawal okb tx will be considered as a gas-free address
 			// even if this address is a contract, it will not affect the gas-free
 			freeGpAddr = *poolTx.To()
@@ -196,20 +209,18 @@ func (p *Pool) checkAndUpdateFreeGasAddr(ctx context.Context, poolTx Transaction
 		freeGpAddr = common.HexToAddress(addrHex)
 	}
 
-	if freeGpAddr.Cmp(common.Address{}) != 0 {
-		nonce, err := p.state.GetNonce(ctx, freeGpAddr, root)
-		if err != nil {
-			log.Errorf("failed to get nonce while adding tx to the pool", err)
-			return err
-		}
-		if nonce < getFreeGasCountPerAddr(p.cfg.FreeGasCountPerAddr) {
-			if err = p.storage.AddFreeGasAddr(ctx, freeGpAddr); err != nil {
-				log.Errorf("failed to save free gas address to the storage", err)
-				return err
-			}
-		}
+	if freeGpAddr.Cmp(common.Address{}) == 0 {
+		return nil, nil
 	}
-	return nil
+	nonce, err := p.state.GetNonce(ctx, freeGpAddr, root)
+	if err != nil {
+		log.Errorf("failed to get nonce while adding tx to the pool", err)
+		return nil, err
+	}
+	if nonce >= getFreeGasCountPerAddr(p.cfg.FreeGasCountPerAddr) {
+		return nil, nil
+	}
+	return &freeGpAddr, nil
 }
 
 // AddDynamicGp cache the dynamic gas price of L2
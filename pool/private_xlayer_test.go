@@ -0,0 +1,46 @@
+package pool_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_AddPrivateTx(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(senderPrivateKey, "0x"))
+	require.NoError(t, err)
+
+	p, s := setupQuotaPool(t, pool.QuotaCfg{}, 0, privateKey)
+	ctx := context.Background()
+
+	publicTx := newQuotaTestTx(t, privateKey, 0, gasPrice)
+	privateTx := newQuotaTestTx(t, privateKey, 1, gasPrice)
+	require.NoError(t, p.AddTx(ctx, publicTx, ip))
+	require.NoError(t, p.AddPrivateTx(ctx, privateTx, ip))
+
+	tx, err := s.GetTransactionByHash(ctx, publicTx.Hash())
+	require.NoError(t, err)
+	assert.False(t, tx.IsPrivate)
+	tx, err = s.GetTransactionByHash(ctx, privateTx.Hash())
+	require.NoError(t, err)
+	assert.True(t, tx.IsPrivate)
+
+	// the private flag is loaded along with the txs the sequencer processes
+	txs, err := s.GetTxsByStatus(ctx, pool.TxStatusPending, 0)
+	require.NoError(t, err)
+	require.Len(t, txs, 2)
+	for _, tx := range txs {
+		assert.Equal(t, tx.Hash() == privateTx.Hash(), tx.IsPrivate)
+	}
+
+	// the private txs are not exposed through the public queries
+	txs, err = s.GetPublicTxsByStatus(ctx, pool.TxStatusPending, 0)
+	require.NoError(t, err)
+	require.Len(t, txs, 1)
+	assert.Equal(t, publicTx.Hash(), txs[0].Hash())
+}
@@ -0,0 +1,138 @@
+package pool
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool/metrics"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// QuotaCfg contains the quotas of pending txs of the pool
+type QuotaCfg struct {
+	// MaxPendingTxsPerSender is the max number of pending txs of a sender, 0 means no limit
+	MaxPendingTxsPerSender uint64 `mapstructure:"MaxPendingTxsPerSender"`
+	// MaxPendingTxsPerIP is the max number of pending txs sent from the same IP, 0 means no limit
+	MaxPendingTxsPerIP uint64 `mapstructure:"MaxPendingTxsPerIP"`
+	// GlobalSlots is the number of pending txs of the pool from which new txs make room evicting the
+	// pending tx with the lowest gas price of a non local sender, as long as they pay a higher gas price.
+	// The GlobalQueue is still the hard limit of pending txs when there is no tx to evict, 0 means no eviction
+	GlobalSlots uint64 `mapstructure:"GlobalSlots"`
+	// LocalAddresses are the senders that are not limited by the quotas and whose txs are never evicted
+	LocalAddresses []string `mapstructure:"LocalAddresses"`
+}
+
+func (c QuotaCfg) isLocal(address common.Address) bool {
+	return Contains(c.LocalAddresses, address)
+}
+
+func (c QuotaCfg) localAddresses() []common.Address {
+	addresses := make([]common.Address, 0, len(c.LocalAddresses))
+	for _, addr := range c.LocalAddresses {
+		addresses = append(addresses, common.HexToAddress(addr))
+	}
+	return addresses
+}
+
+// checkQuotas checks the pending txs quotas of the sender and the IP of a tx. The txs replacing
+// a pending tx with the same nonce don't use a new slot so they are not limited
+func (p *Pool) checkQuotas(ctx context.Context, poolTx Transaction, from common.Address, oldTxs []Transaction) error {
+	quota := getQuotaCfg(p.cfg.Quota)
+	if quota.isLocal(from) {
+		return nil
+	}
+	for _, oldTx := range oldTxs {
+		if oldTx.Status == TxStatusPending {
+			return nil
+		}
+	}
+
+	if quota.MaxPendingTxsPerSender > 0 {
+		txCount, err := p.storage.CountTransactionsByFromAndStatus(ctx, from, TxStatusPending)
+		if err != nil {
+			log.Errorf("failed to count pool txs by sender while adding tx to the pool, error: %v", err)
+			return err
+		}
+		if txCount >= quota.MaxPendingTxsPerSender {
+			log.Infof("%v: %v", ErrTxPoolAccountOverflow.Error(), from.String())
+			metrics.QuotaRejectedTx(metrics.QuotaLabelSender)
+			return ErrTxPoolAccountOverflow
+		}
+	}
+
+	if quota.MaxPendingTxsPerIP > 0 && poolTx.IP != "" {
+		txCount, err := p.storage.CountTransactionsByIPAndStatus(ctx, poolTx.IP, TxStatusPending)
+		if err != nil {
+			log.Errorf("failed to count pool txs by IP while adding tx to the pool, error: %v", err)
+			return err
+		}
+		if txCount >= quota.MaxPendingTxsPerIP {
+			log.Infof("%v: %v", ErrTxPoolIPOverflow.Error(), poolTx.IP)
+			metrics.QuotaRejectedTx(metrics.QuotaLabelIP)
+			return ErrTxPoolIPOverflow
+		}
+	}
+
+	return nil
+}
+
+// makeRoomForTx evicts the tx chosen by getTxToEvict once the new tx has been stored, so nothing is
+// evicted when storing the new tx fails. If the eviction fails the new tx is removed from the pool
+func (p *Pool) makeRoomForTx(ctx context.Context, txToEvict Transaction, poolTx Transaction) error {
+	log.Infof("txpool is full, evicting tx %s to make room for tx %s", txToEvict.Hash().String(), poolTx.Hash().String())
+	err := p.evictTx(ctx, txToEvict, ErrEvictedUnderpricedTransaction)
+	if err != nil {
+		log.Errorf("failed to evict tx %s, removing tx %s from the pool, error: %v", txToEvict.Hash().String(), poolTx.Hash().String(), err)
+		if delErr := p.storage.DeleteTransactionByHash(ctx, poolTx.Hash()); delErr != nil {
+			log.Errorf("failed to remove tx %s from the pool, error: %v", poolTx.Hash().String(), delErr)
+		}
+		return err
+	}
+	metrics.QuotaEvictedTx()
+
+	return nil
+}
+
+// getTxToEvict checks the global slots and the global queue of the pool before storing a tx. Once the pool
+// has GlobalSlots pending txs it returns the pending tx with the lowest gas price of a non local sender if the
+// new tx pays a higher gas price, or ErrUnderpriced when it doesn't. When there is no tx to evict the GlobalQueue
+// is checked and ErrTxPoolOverflow is returned if the pool is full. It returns nil when the pool has room
+func (p *Pool) getTxToEvict(ctx context.Context, poolTx Transaction, from common.Address) (*Transaction, error) {
+	quota := getQuotaCfg(p.cfg.Quota)
+	globalQueue := getGlobalQueue(p.cfg.GlobalQueue)
+	if quota.GlobalSlots == 0 && globalQueue == 0 {
+		return nil, nil
+	}
+
+	txCount, err := p.storage.CountTransactionsByStatus(ctx, TxStatusPending)
+	if err != nil {
+		log.Errorf("failed to count pool txs by status pending while adding tx to the pool, error: %v", err)
+		return nil, err
+	}
+	metrics.PendingTxs(txCount)
+
+	if quota.GlobalSlots > 0 && txCount >= quota.GlobalSlots {
+		cheapestTx, err := p.storage.GetCheapestPendingTx(ctx, quota.localAddresses())
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			log.Errorf("failed to get the cheapest pending tx while adding tx to the pool, error: %v", err)
+			return nil, err
+		}
+		if cheapestTx != nil {
+			if !quota.isLocal(from) && cheapestTx.GasPrice().Cmp(poolTx.GasPrice()) >= 0 {
+				log.Infof("%v: %v", ErrUnderpriced.Error(), poolTx.Hash().String())
+				metrics.QuotaRejectedTx(metrics.QuotaLabelGlobalSlots)
+				return nil, ErrUnderpriced
+			}
+			return cheapestTx, nil
+		}
+	}
+
+	if globalQueue > 0 && txCount >= globalQueue {
+		log.Infof("%v: %v", ErrTxPoolOverflow.Error(), poolTx.Hash().String())
+		metrics.QuotaRejectedTx(metrics.QuotaLabelGlobalQueue)
+		return nil, ErrTxPoolOverflow
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,166 @@
+package pool_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/db"
+	"github.com/0xPolygonHermez/zkevm-node/event"
+	"github.com/0xPolygonHermez/zkevm-node/event/nileventstorage"
+	"github.com/0xPolygonHermez/zkevm-node/merkletree"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/pool/pgpoolstorage"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/0xPolygonHermez/zkevm-node/state/metrics"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	ethTypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupQuotaPool(t *testing.T, quota pool.QuotaCfg, globalQueue uint64, accounts ...*ecdsa.PrivateKey) (*pool.Pool, *pgpoolstorage.PostgresPoolStorage) {
+	eventStorage, err := nileventstorage.NewNilEventStorage()
+	require.NoError(t, err)
+	eventLog := event.NewEventLog(event.Config{}, eventStorage)
+
+	initOrResetDB(t)
+
+	stateSqlDB, err := db.NewSQLDB(stateDBCfg)
+	require.NoError(t, err)
+	t.Cleanup(stateSqlDB.Close)
+
+	st := newState(stateSqlDB, eventLog)
+
+	genesisActions := []*state.GenesisAction{}
+	for _, privateKey := range accounts {
+		genesisActions = append(genesisActions, &state.GenesisAction{
+			Address: crypto.PubkeyToAddress(privateKey.PublicKey).String(),
+			Type:    int(merkletree.LeafTypeBalance),
+			Value:   "1000000000000000000000",
+		})
+	}
+	genesisBlock := state.Block{
+		BlockNumber: 0,
+		BlockHash:   state.ZeroHash,
+		ParentHash:  state.ZeroHash,
+		ReceivedAt:  time.Now(),
+	}
+	ctx := context.Background()
+	dbTx, err := st.BeginStateTransaction(ctx)
+	require.NoError(t, err)
+	_, err = st.SetGenesis(ctx, genesisBlock, state.Genesis{Actions: genesisActions}, metrics.SynchronizerCallerLabel, dbTx)
+	require.NoError(t, err)
+	require.NoError(t, dbTx.Commit(ctx))
+
+	s, err := pgpoolstorage.NewPostgresPoolStorage(poolDBCfg)
+	require.NoError(t, err)
+
+	quotaCfg := cfg
+	quotaCfg.Quota = quota
+	quotaCfg.GlobalQueue = globalQueue
+	return setupPool(t, quotaCfg, bc, s, st, chainID.Uint64(), ctx, eventLog), s
+}
+
+func newQuotaTestTx(t *testing.T, privateKey *ecdsa.PrivateKey, nonce uint64, gasPrice *big.Int) ethTypes.Transaction {
+	auth, err := bind.NewKeyedTransactorWithChainID(privateKey, chainID)
+	require.NoError(t, err)
+
+	signedTx, err := auth.Signer(auth.From, ethTypes.NewTx(&ethTypes.LegacyTx{
+		Nonce:    nonce,
+		Value:    big.NewInt(0),
+		Gas:      uint64(1000000),
+		GasPrice: gasPrice,
+	}))
+	require.NoError(t, err)
+	return *signedTx
+}
+
+func Test_AddTx_SenderAndIPQuotas(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(senderPrivateKey, "0x"))
+	require.NoError(t, err)
+	otherPrivateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	p, _ := setupQuotaPool(t, pool.QuotaCfg{MaxPendingTxsPerSender: 2, MaxPendingTxsPerIP: 3}, 0, privateKey, otherPrivateKey)
+	ctx := context.Background()
+
+	require.NoError(t, p.AddTx(ctx, newQuotaTestTx(t, privateKey, 0, gasPrice), ip))
+	require.NoError(t, p.AddTx(ctx, newQuotaTestTx(t, privateKey, 1, gasPrice), ip))
+	assert.ErrorIs(t, p.AddTx(ctx, newQuotaTestTx(t, privateKey, 2, gasPrice), ip), pool.ErrTxPoolAccountOverflow)
+
+	// Replacing a pending tx doesn't use a new slot
+	require.NoError(t, p.AddTx(ctx, newQuotaTestTx(t, privateKey, 1, new(big.Int).Mul(gasPrice, big.NewInt(2))), ip))
+
+	require.NoError(t, p.AddTx(ctx, newQuotaTestTx(t, otherPrivateKey, 0, gasPrice), ip))
+	assert.ErrorIs(t, p.AddTx(ctx, newQuotaTestTx(t, otherPrivateKey, 1, gasPrice), ip), pool.ErrTxPoolIPOverflow)
+	require.NoError(t, p.AddTx(ctx, newQuotaTestTx(t, otherPrivateKey, 1, gasPrice), "101.1.50.21"))
+}
+
+func Test_AddTx_GlobalSlotsEviction(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(senderPrivateKey, "0x"))
+	require.NoError(t, err)
+	otherPrivateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	localPrivateKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	localAddress := crypto.PubkeyToAddress(localPrivateKey.PublicKey)
+
+	p, s := setupQuotaPool(t, pool.QuotaCfg{GlobalSlots: 2, LocalAddresses: []string{localAddress.String()}}, 3, privateKey, otherPrivateKey, localPrivateKey)
+	ctx := context.Background()
+
+	cheapTx := newQuotaTestTx(t, privateKey, 1, gasPrice)
+	firstTx := newQuotaTestTx(t, privateKey, 0, gasPrice)
+	require.NoError(t, p.AddTx(ctx, firstTx, ip))
+	require.NoError(t, p.AddTx(ctx, cheapTx, ip))
+
+	// The pool is full and the new tx doesn't pay more than the cheapest tx
+	assert.ErrorIs(t, p.AddTx(ctx, newQuotaTestTx(t, otherPrivateKey, 0, gasPrice), ip), pool.ErrUnderpriced)
+
+	// The cheapest tx with the highest nonce is evicted
+	require.NoError(t, p.AddTx(ctx, newQuotaTestTx(t, otherPrivateKey, 0, new(big.Int).Mul(gasPrice, big.NewInt(2))), ip))
+	evictedTx, err := s.GetTransactionByHash(ctx, cheapTx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, pool.TxStatusEvicted, evictedTx.Status)
+
+	// The txs loaded by the sequencer are evicted too
+	pendingTxs, err := s.GetTxsByStatus(ctx, pool.TxStatusPending, 0)
+	require.NoError(t, err)
+	for _, tx := range pendingTxs {
+		require.NoError(t, s.UpdateTxWIPStatus(ctx, tx.Hash(), true))
+	}
+	require.NoError(t, p.AddTx(ctx, newQuotaTestTx(t, otherPrivateKey, 1, new(big.Int).Mul(gasPrice, big.NewInt(3))), ip))
+	evictedTx, err = s.GetTransactionByHash(ctx, firstTx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, pool.TxStatusEvicted, evictedTx.Status)
+
+	// The txs the sequencer refused to evict are not chosen again, the global queue
+	// rejects the new txs when there is no tx to evict, for the local senders too
+	require.NoError(t, p.RestoreEvictedTx(ctx, firstTx.Hash()))
+	pendingTxs, err = s.GetTxsByStatus(ctx, pool.TxStatusPending, 0)
+	require.NoError(t, err)
+	require.Len(t, pendingTxs, 3)
+	for _, tx := range pendingTxs {
+		require.NoError(t, s.EvictTx(ctx, tx.Hash(), pool.ErrEvictedTransaction.Error()))
+		require.NoError(t, p.RestoreEvictedTx(ctx, tx.Hash()))
+	}
+	assert.ErrorIs(t, p.AddTx(ctx, newQuotaTestTx(t, otherPrivateKey, 2, new(big.Int).Mul(gasPrice, big.NewInt(4))), ip), pool.ErrTxPoolOverflow)
+	assert.ErrorIs(t, p.AddTx(ctx, newQuotaTestTx(t, localPrivateKey, 0, gasPrice), ip), pool.ErrTxPoolOverflow)
+}
+
+func Test_AddTx_GlobalQueue(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(senderPrivateKey, "0x"))
+	require.NoError(t, err)
+
+	p, _ := setupQuotaPool(t, pool.QuotaCfg{}, 1, privateKey)
+	ctx := context.Background()
+
+	// Without global slots nothing is evicted
+	require.NoError(t, p.AddTx(ctx, newQuotaTestTx(t, privateKey, 0, gasPrice), ip))
+	assert.ErrorIs(t, p.AddTx(ctx, newQuotaTestTx(t, privateKey, 1, new(big.Int).Mul(gasPrice, big.NewInt(2))), ip), pool.ErrTxPoolOverflow)
+}
@@ -48,7 +48,11 @@ type Transaction struct {
 	FailedReason          *string
 
 	// XLayer config
-	IsClaims bool
+	IsClaims       bool
+	IsPrivate      bool
+	Conditional    *TxConditional
+	ValidityWindow *TxValidityWindow
+	SelectedAt     *time.Time
 }
 
 // NewTransaction creates a new transaction
@@ -0,0 +1,214 @@
+package pool
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	// TxStatusEvicted represents a pending tx that has been evicted by an operator
+	// and has to be removed from the sequencer worker
+	TxStatusEvicted TxStatus = "evicted"
+)
+
+var (
+	// ErrTxNotPending is returned when trying to evict a tx that is not pending
+	ErrTxNotPending = errors.New("transaction is not pending")
+
+	// ErrTxWIP is returned when trying to evict a tx that is being executed by the sequencer or
+	// that has been executed in a L2 block not stored yet
+	ErrTxWIP = errors.New("transaction is being processed by the sequencer")
+
+	// ErrEvictedTransaction is the failed reason of the txs evicted by an operator
+	ErrEvictedTransaction = errors.New("transaction evicted by operator")
+)
+
+// Content contains the pool txs grouped by sender and nonce. Pending txs are
+// the ones that can be executed right away, queued txs are waiting for a nonce gap
+// to be filled
+type Content struct {
+	Pending map[common.Address]map[uint64]Transaction
+	Queued  map[common.Address]map[uint64]Transaction
+}
+
+// AddPrivateTx adds a private transaction to the pool with the pending state. Private txs are processed
+// by the sequencer like any other tx, but they are not exposed through the pending txs filters nor the txpool content
+func (p *Pool) AddPrivateTx(ctx context.Context, tx types.Transaction, ip string) error {
+	poolTx := NewTransaction(tx, ip, false, p)
+	if err := p.validateTx(ctx, *poolTx); err != nil {
+		return err
+	}
+
+	return p.storeTx(ctx, tx, ip, false, true, nil, nil)
+}
+
+// GetContent returns the non private pending txs of the pool grouped by sender and nonce,
+// limit parameter is used to limit amount of pending txs from the db,
+// if limit = 0, then there is no limit
+func (p *Pool) GetContent(ctx context.Context, limit uint64) (*Content, error) {
+	txs, err := p.storage.GetPublicTxsByStatus(ctx, TxStatusPending, limit)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return p.buildContent(ctx, txs)
+}
+
+// CountContent returns the number of non private pending txs of the pool classified as pending
+// or queued, the txs are counted from the nonces of each sender without loading them
+func (p *Pool) CountContent(ctx context.Context) (pending uint64, queued uint64, err error) {
+	nonces, err := p.storage.GetPublicTxNoncesByStatus(ctx, TxStatusPending)
+	if err != nil {
+		return 0, 0, err
+	}
+	if len(nonces) == 0 {
+		return 0, 0, nil
+	}
+
+	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for from, senderNonces := range nonces {
+		nonce, err := p.state.GetNonce(ctx, from, lastL2Block.Root())
+		if err != nil {
+			return 0, 0, err
+		}
+		// the nonces are sorted, the consecutive ones starting from the state nonce are executable
+		executable := uint64(0)
+		for _, n := range senderNonces {
+			if n == nonce+executable {
+				executable++
+			}
+		}
+		pending += executable
+		queued += uint64(len(senderNonces)) - executable
+	}
+
+	return pending, queued, nil
+}
+
+// GetContentFrom returns the non private pending txs of the pool sent by the given address
+func (p *Pool) GetContentFrom(ctx context.Context, from common.Address) (*Content, error) {
+	txs, err := p.storage.GetPublicTxsByFromAndStatus(ctx, from, TxStatusPending)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+	return p.buildContent(ctx, txs)
+}
+
+// buildContent classifies the txs as pending or queued comparing their nonces
+// with the nonces of the senders in the latest state
+func (p *Pool) buildContent(ctx context.Context, txs []Transaction) (*Content, error) {
+	content := &Content{
+		Pending: make(map[common.Address]map[uint64]Transaction),
+		Queued:  make(map[common.Address]map[uint64]Transaction),
+	}
+	if len(txs) == 0 {
+		return content, nil
+	}
+
+	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	bySender := make(map[common.Address]map[uint64]Transaction)
+	for _, tx := range txs {
+		from, err := state.GetSender(tx.Transaction)
+		if err != nil {
+			return nil, err
+		}
+		if _, found := bySender[from]; !found {
+			bySender[from] = make(map[uint64]Transaction)
+		}
+		bySender[from][tx.Nonce()] = tx
+	}
+
+	for from, senderTxs := range bySender {
+		nonce, err := p.state.GetNonce(ctx, from, lastL2Block.Root())
+		if err != nil {
+			return nil, err
+		}
+		// the txs with consecutive nonces starting from the state nonce are executable
+		for {
+			tx, found := senderTxs[nonce]
+			if !found {
+				break
+			}
+			if _, found := content.Pending[from]; !found {
+				content.Pending[from] = make(map[uint64]Transaction)
+			}
+			content.Pending[from][nonce] = tx
+			delete(senderTxs, nonce)
+			nonce++
+		}
+		if len(senderTxs) > 0 {
+			content.Queued[from] = senderTxs
+		}
+	}
+
+	return content, nil
+}
+
+// EvictTx marks a pending tx as evicted so the sequencer removes it from the worker
+func (p *Pool) EvictTx(ctx context.Context, hash common.Hash) error {
+	tx, err := p.storage.GetTransactionByHash(ctx, hash)
+	if err != nil {
+		return err
+	}
+	return p.evictTx(ctx, *tx, ErrEvictedTransaction)
+}
+
+// EvictTxsByFromAndNonce marks the pending txs with the given sender and nonce as
+// evicted so the sequencer removes them from the worker, the evicted hashes are returned
+func (p *Pool) EvictTxsByFromAndNonce(ctx context.Context, from common.Address, nonce uint64) ([]common.Hash, error) {
+	txs, err := p.storage.GetTxsByFromAndNonce(ctx, from, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]common.Hash, 0, len(txs))
+	for _, tx := range txs {
+		if tx.Status != TxStatusPending {
+			continue
+		}
+		if err := p.evictTx(ctx, tx, ErrEvictedTransaction); err != nil {
+			return nil, err
+		}
+		hashes = append(hashes, tx.Hash())
+	}
+	if len(hashes) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return hashes, nil
+}
+
+func (p *Pool) evictTx(ctx context.Context, tx Transaction, reason error) error {
+	if tx.Status != TxStatusPending {
+		return ErrTxNotPending
+	}
+	// the txs loaded by the worker are evicted too, but the sequencer sets back as pending the ones
+	// it is executing, recording ErrTxWIP as failed reason until they are selected
+	if tx.IsWIP && tx.FailedReason != nil && *tx.FailedReason == ErrTxWIP.Error() {
+		return ErrTxWIP
+	}
+	return p.storage.EvictTx(ctx, tx.Hash(), reason.Error())
+}
+
+// RestoreEvictedTx sets back as pending an evicted tx the sequencer can't remove from the worker
+// because it is being executed, next evictions of the tx are refused with ErrTxWIP
+func (p *Pool) RestoreEvictedTx(ctx context.Context, hash common.Hash) error {
+	return p.storage.RestoreEvictedTx(ctx, hash, ErrTxWIP.Error())
+}
+
+// GetEvictedTxs gets the txs evicted by an operator or to make room in the full pool
+// that are still to be removed from the sequencer worker
+func (p *Pool) GetEvictedTxs(ctx context.Context, limit uint64) ([]Transaction, error) {
+	return p.storage.GetTxsByStatus(ctx, TxStatusEvicted, limit)
+}
@@ -0,0 +1,46 @@
+package pool_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_EvictTx_WIP(t *testing.T) {
+	privateKey, err := crypto.HexToECDSA(strings.TrimPrefix(senderPrivateKey, "0x"))
+	require.NoError(t, err)
+
+	p, s := setupQuotaPool(t, pool.QuotaCfg{}, 0, privateKey)
+	ctx := context.Background()
+
+	tx := newQuotaTestTx(t, privateKey, 0, gasPrice)
+	require.NoError(t, p.AddTx(ctx, tx, ip))
+	require.NoError(t, s.UpdateTxWIPStatus(ctx, tx.Hash(), true))
+
+	// The txs loaded by the worker are evicted
+	require.NoError(t, p.EvictTx(ctx, tx.Hash()))
+	evictedTxs, err := p.GetEvictedTxs(ctx, 0)
+	require.NoError(t, err)
+	require.Len(t, evictedTxs, 1)
+	assert.Equal(t, tx.Hash(), evictedTxs[0].Hash())
+
+	// The sequencer sets back as pending the tx it is executing and next evictions are refused
+	require.NoError(t, p.RestoreEvictedTx(ctx, tx.Hash()))
+	restoredTx, err := s.GetTransactionByHash(ctx, tx.Hash())
+	require.NoError(t, err)
+	assert.Equal(t, pool.TxStatusPending, restoredTx.Status)
+	assert.True(t, restoredTx.IsWIP)
+	assert.NotNil(t, restoredTx.SelectedAt)
+	assert.ErrorIs(t, p.EvictTx(ctx, tx.Hash()), pool.ErrTxWIP)
+	_, err = p.EvictTxsByFromAndNonce(ctx, crypto.PubkeyToAddress(privateKey.PublicKey), 0)
+	assert.ErrorIs(t, err, pool.ErrTxWIP)
+
+	// A selected tx is not evicted
+	require.NoError(t, p.UpdateTxStatus(ctx, tx.Hash(), pool.TxStatusSelected, false, nil))
+	assert.ErrorIs(t, p.EvictTx(ctx, tx.Hash()), pool.ErrTxNotPending)
+}
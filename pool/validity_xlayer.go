@@ -0,0 +1,86 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	// ErrTxValidityWindowExpired is returned when the validity window of a scheduled tx has already closed
+	ErrTxValidityWindowExpired = errors.New("transaction validity window expired")
+
+	// ErrInvalidTxValidityWindow is returned when the validity window of a scheduled tx is not valid
+	ErrInvalidTxValidityWindow = errors.New("invalid transaction validity window")
+)
+
+// TxValidityWindow bounds the L2 blocks that can include a tx, by timestamp and by block number.
+// The sequencer keeps the tx until the window opens and drops it once the window closes
+type TxValidityWindow struct {
+	NotBefore      *uint64 `json:"notBefore,omitempty"`
+	NotAfter       *uint64 `json:"notAfter,omitempty"`
+	NotBeforeBlock *uint64 `json:"notBeforeBlock,omitempty"`
+	NotAfterBlock  *uint64 `json:"notAfterBlock,omitempty"`
+}
+
+// Validate checks that the window has at least a bound and that it's not empty
+func (w *TxValidityWindow) Validate() error {
+	if w.NotBefore == nil && w.NotAfter == nil && w.NotBeforeBlock == nil && w.NotAfterBlock == nil {
+		return fmt.Errorf("%w: no bound is set", ErrInvalidTxValidityWindow)
+	}
+	if w.NotBefore != nil && w.NotAfter != nil && *w.NotBefore > *w.NotAfter {
+		return fmt.Errorf("%w: notBefore is greater than notAfter", ErrInvalidTxValidityWindow)
+	}
+	if w.NotBeforeBlock != nil && w.NotAfterBlock != nil && *w.NotBeforeBlock > *w.NotAfterBlock {
+		return fmt.Errorf("%w: notBeforeBlock is greater than notAfterBlock", ErrInvalidTxValidityWindow)
+	}
+	return nil
+}
+
+// IsOpen checks if a L2 block with the given number and timestamp is after the start of the window
+func (w *TxValidityWindow) IsOpen(number uint64, timestamp uint64) bool {
+	if w.NotBefore != nil && timestamp < *w.NotBefore {
+		return false
+	}
+	if w.NotBeforeBlock != nil && number < *w.NotBeforeBlock {
+		return false
+	}
+	return true
+}
+
+// CheckExpired checks if a L2 block with the given number and timestamp is after the end of the window
+func (w *TxValidityWindow) CheckExpired(number uint64, timestamp uint64) error {
+	if w.NotAfter != nil && timestamp > *w.NotAfter {
+		return fmt.Errorf("%w: timestamp %d is greater than notAfter %d", ErrTxValidityWindowExpired, timestamp, *w.NotAfter)
+	}
+	if w.NotAfterBlock != nil && number > *w.NotAfterBlock {
+		return fmt.Errorf("%w: block number %d is greater than notAfterBlock %d", ErrTxValidityWindowExpired, number, *w.NotAfterBlock)
+	}
+	return nil
+}
+
+// AddTxWithValidityWindow adds a scheduled transaction to the pool with the pending state. The tx is
+// rejected if its window has already closed at the last L2 block, otherwise it's kept by the sequencer
+// until the window opens
+func (p *Pool) AddTxWithValidityWindow(ctx context.Context, tx types.Transaction, ip string, window TxValidityWindow) error {
+	if err := window.Validate(); err != nil {
+		return err
+	}
+
+	lastL2Block, err := p.state.GetLastL2Block(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := window.CheckExpired(lastL2Block.NumberU64(), lastL2Block.Time()); err != nil {
+		return err
+	}
+
+	poolTx := NewTransaction(tx, ip, false, p)
+	if err := p.validateTx(ctx, *poolTx); err != nil {
+		return err
+	}
+
+	return p.storeTx(ctx, tx, ip, false, false, nil, &window)
+}
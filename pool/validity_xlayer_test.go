@@ -0,0 +1,33 @@
+package pool
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTxValidityWindow(t *testing.T) {
+	var window TxValidityWindow
+	assert.ErrorIs(t, window.Validate(), ErrInvalidTxValidityWindow)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"notBefore": 100, "notAfter": 200, "notAfterBlock": 20}`), &window))
+	require.NoError(t, window.Validate())
+	assert.Nil(t, window.NotBeforeBlock)
+
+	assert.False(t, window.IsOpen(10, 99))
+	assert.True(t, window.IsOpen(10, 100))
+	assert.NoError(t, window.CheckExpired(20, 200))
+	assert.ErrorIs(t, window.CheckExpired(20, 201), ErrTxValidityWindowExpired)
+	assert.ErrorIs(t, window.CheckExpired(21, 150), ErrTxValidityWindowExpired)
+
+	notBeforeBlock := uint64(30)
+	window.NotBeforeBlock = &notBeforeBlock
+	assert.False(t, window.IsOpen(29, 150))
+	assert.ErrorIs(t, window.Validate(), ErrInvalidTxValidityWindow)
+
+	notBefore := uint64(300)
+	window = TxValidityWindow{NotBefore: &notBefore, NotAfter: window.NotAfter}
+	assert.ErrorIs(t, window.Validate(), ErrInvalidTxValidityWindow)
+}
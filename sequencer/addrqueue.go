@@ -98,14 +98,14 @@ func (a *addrQueue) ExpireTransactions(maxTime time.Duration) ([]*TxTracker, *Tx
 	)
 
 	for _, txTracker := range a.notReadyTxs {
-		if txTracker.ReceivedAt.Add(maxTime).Before(time.Now()) {
+		if txTracker.isLifetimeExpired(maxTime) { // XLayer scheduled txs
 			txs = append(txs, txTracker)
 			delete(a.notReadyTxs, txTracker.Nonce)
 			log.Debugf("deleting notReadyTx %s from addrQueue %s", txTracker.HashStr, a.fromStr)
 		}
 	}
 
-	if a.readyTx != nil && a.readyTx.ReceivedAt.Add(maxTime).Before(time.Now()) {
+	if a.readyTx != nil && a.readyTx.isLifetimeExpired(maxTime) { // XLayer scheduled txs
 		prevReadyTx = a.readyTx
 		txs = append(txs, a.readyTx)
 		a.readyTx = nil
@@ -15,6 +15,7 @@ type ApolloConfig struct {
 	GasPriceMultiple       float64
 	InitGasPriceMultiple   float64
 	QueryPendingTxsLimit   uint64
+	OrderingPolicy         OrderingPolicyCfg
 
 	sync.RWMutex
 }
@@ -45,6 +46,7 @@ func UpdateConfig(apolloConfig Config) {
 	getApolloConfig().GasPriceMultiple = apolloConfig.GasPriceMultiple
 	getApolloConfig().InitGasPriceMultiple = apolloConfig.InitGasPriceMultiple
 	getApolloConfig().QueryPendingTxsLimit = apolloConfig.QueryPendingTxsLimit
+	getApolloConfig().OrderingPolicy = apolloConfig.OrderingPolicy
 	getApolloConfig().Unlock()
 }
 
@@ -108,3 +110,14 @@ func getQueryPendingTxsLimit(limit uint64) uint64 {
 
 	return ret
 }
+
+func getOrderingPolicyCfg(cfg OrderingPolicyCfg) OrderingPolicyCfg {
+	ret := cfg
+	if getApolloConfig().Enable() {
+		getApolloConfig().RLock()
+		defer getApolloConfig().RUnlock()
+		ret = getApolloConfig().OrderingPolicy
+	}
+
+	return ret
+}
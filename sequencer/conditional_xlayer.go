@@ -0,0 +1,52 @@
+package sequencer
+
+import (
+	"context"
+	"errors"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+)
+
+var (
+	// ErrTxConditionalNotMet happens when the conditions of a conditional tx don't hold in the wip L2 block
+	ErrTxConditionalNotMet = errors.New("transaction conditional not met")
+)
+
+// checkTxConditional checks the conditions of a conditional tx against the state of the wip L2 block. If the conditions
+// don't hold the tx is deleted from the worker and set as failed in the pool, and false is returned
+func (f *finalizer) checkTxConditional(ctx context.Context, tx *TxTracker) bool {
+	if tx.Conditional == nil {
+		return true
+	}
+
+	err := f.getTxConditionalError(ctx, tx.Conditional)
+	if err == nil {
+		return true
+	}
+
+	log.Infof("discarding conditional tx %s, error: %v", tx.HashStr, err)
+
+	f.workerIntf.DeleteTx(tx.Hash, tx.From)
+
+	failedReason := err.Error()
+	err = f.poolIntf.UpdateTxStatus(ctx, tx.Hash, pool.TxStatusFailed, false, &failedReason)
+	if err != nil {
+		log.Errorf("failed to update status to failed in the pool for tx %s, error: %v", tx.HashStr, err)
+	}
+
+	return false
+}
+
+func (f *finalizer) getTxConditionalError(ctx context.Context, conditional *pool.TxConditional) error {
+	if err := conditional.CheckBlock(f.getWIPL2BlockNumber(), f.wipL2Block.timestamp); err != nil {
+		return err
+	}
+
+	return conditional.CheckKnownAccounts(ctx, f.stateIntf, f.wipBatch.imStateRoot)
+}
+
+// getWIPL2BlockNumber returns the number of the wip L2 block, it's returned by the executor when the block is opened
+func (f *finalizer) getWIPL2BlockNumber() uint64 {
+	return f.wipL2Block.number
+}
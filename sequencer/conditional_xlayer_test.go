@@ -0,0 +1,53 @@
+package sequencer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckTxConditional(t *testing.T) {
+	ctx := context.Background()
+	imStateRoot := common.Hash{1}
+	contract, slot := common.Address{2}, common.Hash{3}
+
+	stateMock := NewStateMock(t)
+	poolMock := NewPoolMock(t)
+	workerMock := NewWorkerMock(t)
+	f := &finalizer{
+		stateIntf:  stateMock,
+		poolIntf:   poolMock,
+		workerIntf: workerMock,
+		wipBatch:   &Batch{imStateRoot: imStateRoot},
+		wipL2Block: &L2Block{number: 10, timestamp: 1000},
+	}
+
+	// The block conditions are checked against the number of the wip L2 block kept in memory,
+	// only the known accounts are read from the state
+	minBlock, maxBlock := uint64(10), uint64(10)
+	tx := newSnapshotTestTx(common.Hash{1}, common.Address{1}, 1, 1)
+	tx.Conditional = &pool.TxConditional{
+		BlockNumberMin: &minBlock,
+		BlockNumberMax: &maxBlock,
+		KnownAccounts: map[common.Address]pool.KnownAccount{
+			contract: {StorageSlots: map[common.Hash]common.Hash{slot: common.BigToHash(big.NewInt(5))}},
+		},
+	}
+	stateMock.On("GetStorageAt", ctx, contract, slot.Big(), imStateRoot).Return(big.NewInt(5), nil).Once()
+	assert.True(t, f.checkTxConditional(ctx, tx))
+
+	// A tx whose block range has been exceeded is discarded
+	f.wipL2Block = &L2Block{number: 11, timestamp: 1002}
+	tx = newSnapshotTestTx(common.Hash{2}, common.Address{1}, 1, 1)
+	tx.Conditional = &pool.TxConditional{BlockNumberMax: &maxBlock}
+	workerMock.On("DeleteTx", tx.Hash, tx.From).Once()
+	poolMock.On("UpdateTxStatus", ctx, tx.Hash, pool.TxStatusFailed, false, mock.MatchedBy(func(reason *string) bool {
+		return reason != nil && *reason != ""
+	})).Return(nil).Once()
+	assert.False(t, f.checkTxConditional(ctx, tx))
+}
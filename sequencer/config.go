@@ -44,6 +44,36 @@ type Config struct {
 	InitGasPriceMultiple float64 `mapstructure:"InitGasPriceMultiple"`
 	// QueryPendingTxsLimit is used to limit amount txs from the db
 	QueryPendingTxsLimit uint64 `mapstructure:"QueryPendingTxsLimit"`
+	// WorkerSnapshot is the config for the snapshots of the worker state
+	WorkerSnapshot WorkerSnapshotCfg `mapstructure:"WorkerSnapshot"`
+	// OrderingPolicy is the config of the policy used by the worker to sort the txs to include in the batch
+	OrderingPolicy OrderingPolicyCfg `mapstructure:"OrderingPolicy"`
+	// Preconfirmation is the config of the server that streams the txs executed in the WIP L2 block
+	Preconfirmation PreconfirmationCfg `mapstructure:"Preconfirmation"`
+}
+
+// OrderingPolicyCfg contains the config of the tx ordering policy of the worker
+type OrderingPolicyCfg struct {
+	// Type is the policy used to sort the txs: "gasprice", "fcfs", "fairgasprice" or "weighted"
+	Type string `mapstructure:"Type"`
+	// MaxTxsPerSender is the max number of txs of the same sender in a batch, used by the "fairgasprice" policy
+	MaxTxsPerSender uint64 `mapstructure:"MaxTxsPerSender"`
+	// ReservedResourcesPct is the percentage of the batch resources reserved for the txs sent to the
+	// whitelisted contracts, used by the "weighted" policy
+	ReservedResourcesPct uint32 `mapstructure:"ReservedResourcesPct"`
+	// WhitelistedContracts are the contracts with reserved batch resources, used by the "weighted" policy
+	WhitelistedContracts []string `mapstructure:"WhitelistedContracts"`
+}
+
+// WorkerSnapshotCfg contains the config of the worker state snapshots, used to restore
+// the worker queues after a restart
+type WorkerSnapshotCfg struct {
+	// Enabled is a flag to enable/disable the worker snapshots
+	Enabled bool `mapstructure:"Enabled"`
+	// Filename of the worker snapshot file
+	Filename string `mapstructure:"Filename"`
+	// Interval is the time interval between each worker snapshot
+	Interval types.Duration `mapstructure:"Interval"`
 }
 
 // StreamServerCfg contains the data streamer's configuration properties
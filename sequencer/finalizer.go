@@ -89,6 +89,10 @@ type finalizer struct {
 	streamServer      *datastreamer.StreamServer
 	dataToStream      chan interface{}
 	dataToStreamCount atomic.Int32
+	// XLayer preconfirmation server
+	preconfirmationServer *preconfirmationServer
+	// XLayer tracking number of the last wip L2 block the validity windows of the scheduled txs were updated for
+	validityWindowsL2Block uint64
 }
 
 // newFinalizer returns a new instance of Finalizer.
@@ -407,6 +411,9 @@ func (f *finalizer) finalizeBatches(ctx context.Context) {
 			f.finalizeWIPL2Block(ctx)
 		}
 
+		// XLayer scheduled txs
+		f.updateTxValidityWindows(ctx)
+
 		start := now()
 		tx, oocTxs, err := f.workerIntf.GetBestFittingTx(f.wipBatch.imRemainingResources, f.wipBatch.imHighReservedZKCounters, (f.wipBatch.countOfL2Blocks == 0 && f.wipL2Block.isEmpty()))
 		seqMetrics.GetLogStatistics().CumulativeTiming(seqMetrics.GetTx, time.Since(start))
@@ -457,6 +464,9 @@ func (f *finalizer) finalizeBatches(ctx context.Context) {
 						log.Infof("skipping tx %s due to a batch resource overflow", tx.HashStr)
 						seqMetrics.GetLogStatistics().CumulativeCounting(seqMetrics.FailTxResourceOverCounter)
 						break
+					} else if err == ErrTxConditionalNotMet { // XLayer conditional tx
+						seqMetrics.GetLogStatistics().CumulativeCounting(seqMetrics.FailTxCounter)
+						break
 					} else {
 						log.Errorf("failed to process tx %s, error: %v", err)
 						seqMetrics.GetLogStatistics().CumulativeCounting(seqMetrics.FailTxCounter)
@@ -537,6 +547,11 @@ func (f *finalizer) processTransaction(ctx context.Context, tx *TxTracker, first
 		L1InfoTreeData_V2:         map[uint32]state.L1DataV2{},
 	}
 
+	// XLayer conditional tx
+	if firstTxProcess && !f.checkTxConditional(ctx, tx) {
+		return nil, ErrTxConditionalNotMet
+	}
+
 	txGasPrice := tx.GasPrice
 
 	// If it is the first time we process this tx then we calculate the EffectiveGasPrice
@@ -782,6 +797,9 @@ func (f *finalizer) handleProcessTransactionResponse(ctx context.Context, tx *Tx
 
 	f.wipL2Block.addTx(tx)
 
+	// XLayer preconfirmation
+	f.publishPreconfirmation(tx, txResponse, result.BlockResponses[0].BlockNumber, result.BlockResponses[0].Timestamp)
+
 	f.wipBatch.countOfTxs++
 
 	f.updateWorkerAfterSuccessfulProcessing(ctx, tx.Hash, tx.From, false, result)
@@ -33,6 +33,9 @@ type txPool interface {
 	CountPendingTransactions(ctx context.Context) (uint64, error)
 	UpdateReadyTxCount(ctx context.Context, count uint64) error
 	GetDynamicGasPrice() *big.Int
+	GetEvictedTxs(ctx context.Context, limit uint64) ([]pool.Transaction, error)
+	IsTxPending(ctx context.Context, hash common.Hash) (bool, error)
+	RestoreEvictedTx(ctx context.Context, hash common.Hash) error
 }
 
 // ethermanInterface contains the methods required to interact with ethereum.
@@ -102,4 +105,6 @@ type workerInterface interface {
 	RestoreTxsPendingToStore(ctx context.Context) ([]*TxTracker, []*TxTracker)
 	// XLayer interface
 	CountReadyTx() uint64
+	UpdateValidityWindows(blockNumber uint64, timestamp uint64) []*TxTracker
+	EvictTx(txHash common.Hash, addr common.Address) error
 }
@@ -32,6 +32,7 @@ type L2Block struct {
 	batch                     *Batch
 	batchResponse             *state.ProcessBatchResponse
 	metrics                   metrics
+	number                    uint64 // XLayer
 }
 
 func (b *L2Block) isEmpty() bool {
@@ -644,6 +645,9 @@ func (f *finalizer) openNewWIPL2Block(ctx context.Context, prevTimestamp uint64,
 		f.Halt(ctx, fmt.Errorf("number of L2 block [%d] responses returned by the executor is %d and must be 1", f.wipL2Block.trackingNum, len(batchResponse.BlockResponses)), false)
 	}
 
+	// XLayer: keep the number of the wip L2 block, the system SC storage at imStateRoot may not be in the merkletree
+	f.wipL2Block.number = batchResponse.BlockResponses[0].BlockNumber
+
 	// Update imStateRoot
 	oldIMStateRoot := f.wipBatch.imStateRoot
 	f.wipL2Block.imStateRoot = batchResponse.NewStateRoot
@@ -4,6 +4,10 @@ package sequencer
 
 import (
 	context "context"
+
+	common "github.com/ethereum/go-ethereum/common"
+
+	pool "github.com/0xPolygonHermez/zkevm-node/pool"
 )
 
 // DeleteFailedTransactionsOlderThan provides a mock function with given fields: ctx, date
@@ -13,4 +17,80 @@ func (_m *PoolMock) CountPendingTransactions(ctx context.Context) (uint64, error
 
 func (_m *PoolMock) UpdateReadyTxCount(ctx context.Context, count uint64) error {
 	return nil
-}
\ No newline at end of file
+}
+
+// GetEvictedTxs provides a mock function with given fields: ctx, limit
+func (_m *PoolMock) GetEvictedTxs(ctx context.Context, limit uint64) ([]pool.Transaction, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetEvictedTxs")
+	}
+
+	var r0 []pool.Transaction
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) ([]pool.Transaction, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint64) []pool.Transaction); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]pool.Transaction)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint64) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// IsTxPending provides a mock function with given fields: ctx, hash
+func (_m *PoolMock) IsTxPending(ctx context.Context, hash common.Hash) (bool, error) {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for IsTxPending")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) (bool, error)); ok {
+		return rf(ctx, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) bool); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, common.Hash) error); ok {
+		r1 = rf(ctx, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreEvictedTx provides a mock function with given fields: ctx, hash
+func (_m *PoolMock) RestoreEvictedTx(ctx context.Context, hash common.Hash) error {
+	ret := _m.Called(ctx, hash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreEvictedTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, common.Hash) error); ok {
+		r0 = rf(ctx, hash)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
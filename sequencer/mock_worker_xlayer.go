@@ -1,8 +1,50 @@
 package sequencer
 
+import (
+	"github.com/ethereum/go-ethereum/common"
+)
+
 // CountReadyTx provides a mock function with given fields:
 func (_m *WorkerMock) CountReadyTx() uint64 {
 	_m.Called()
 	ret := _m.Called()
 	return ret.Get(0).(uint64)
 }
+
+// UpdateValidityWindows provides a mock function with given fields: blockNumber, timestamp
+func (_m *WorkerMock) UpdateValidityWindows(blockNumber uint64, timestamp uint64) []*TxTracker {
+	ret := _m.Called(blockNumber, timestamp)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateValidityWindows")
+	}
+
+	var r0 []*TxTracker
+	if rf, ok := ret.Get(0).(func(uint64, uint64) []*TxTracker); ok {
+		r0 = rf(blockNumber, timestamp)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*TxTracker)
+		}
+	}
+
+	return r0
+}
+
+// EvictTx provides a mock function with given fields: txHash, addr
+func (_m *WorkerMock) EvictTx(txHash common.Hash, addr common.Address) error {
+	ret := _m.Called(txHash, addr)
+
+	if len(ret) == 0 {
+		panic("no return value specified for EvictTx")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(common.Hash, common.Address) error); ok {
+		r0 = rf(txHash, addr)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
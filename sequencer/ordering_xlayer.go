@@ -0,0 +1,211 @@
+package sequencer
+
+import (
+	"reflect"
+	"sort"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// OrderingPolicyGasPrice sorts the txs by gas price
+	OrderingPolicyGasPrice = "gasprice"
+	// OrderingPolicyFCFS sorts the txs by arrival time to the pool
+	OrderingPolicyFCFS = "fcfs"
+	// OrderingPolicyFairGasPrice sorts the txs by gas price limiting the number of txs of each sender in a batch
+	OrderingPolicyFairGasPrice = "fairgasprice"
+	// OrderingPolicyWeighted sorts the txs by gas price reserving a share of the batch resources for the whitelisted contracts
+	OrderingPolicyWeighted = "weighted"
+)
+
+// orderingPolicy defines the order in which the ready txs of the worker are tried to be included in the batch.
+// The methods of the policy are called with the worker mutex locked
+type orderingPolicy interface {
+	// sort returns the candidate txs in the order they have to be tried, the txs are received sorted by gas price
+	sort(txs []*TxTracker, isFirstL2BlockAndEmpty bool) []*TxTracker
+	// canUse returns if the tx can use the needed resources from the remaining resources of the batch,
+	// the needed resources always fit in the remaining resources. It's called concurrently
+	canUse(tx *TxTracker, needed state.BatchResources, remaining state.BatchResources) bool
+	// txSelected is called when a tx is selected to be processed
+	txSelected(tx *TxTracker)
+}
+
+// newOrderingPolicy creates the ordering policy for the given config, the gas price policy is used by default
+func newOrderingPolicy(cfg OrderingPolicyCfg, constraints state.BatchConstraintsCfg) orderingPolicy {
+	switch cfg.Type {
+	case OrderingPolicyFCFS:
+		return &fcfsPolicy{}
+	case OrderingPolicyFairGasPrice:
+		return &fairGasPricePolicy{
+			maxTxsPerSender: cfg.MaxTxsPerSender,
+			txsPerSender:    make(map[common.Address]uint64),
+		}
+	case OrderingPolicyWeighted:
+		return newWeightedPolicy(cfg, constraints)
+	case OrderingPolicyGasPrice, "":
+		return &gasPricePolicy{}
+	default:
+		log.Warnf("unknown ordering policy %s, using %s policy", cfg.Type, OrderingPolicyGasPrice)
+		return &gasPricePolicy{}
+	}
+}
+
+// getOrderingPolicy returns the current ordering policy, the policy is created again if its config has changed
+func (w *Worker) getOrderingPolicy() orderingPolicy {
+	cfg := getOrderingPolicyCfg(w.orderingPolicyCfg)
+	if w.orderingPolicy == nil || !reflect.DeepEqual(cfg, w.currentOrderingPolicyCfg) {
+		log.Infof("using tx ordering policy %s", cfg.Type)
+		w.orderingPolicy = newOrderingPolicy(cfg, w.batchConstraints)
+		w.currentOrderingPolicyCfg = cfg
+	}
+	return w.orderingPolicy
+}
+
+// SetOrderingPolicyCfg sets the config of the tx ordering policy
+func (w *Worker) SetOrderingPolicyCfg(cfg OrderingPolicyCfg) {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	w.orderingPolicyCfg = cfg
+}
+
+// gasPricePolicy tries the txs with higher gas price first
+type gasPricePolicy struct{}
+
+func (p *gasPricePolicy) sort(txs []*TxTracker, isFirstL2BlockAndEmpty bool) []*TxTracker {
+	return txs
+}
+
+func (p *gasPricePolicy) canUse(tx *TxTracker, needed state.BatchResources, remaining state.BatchResources) bool {
+	return true
+}
+
+func (p *gasPricePolicy) txSelected(tx *TxTracker) {}
+
+// fcfsPolicy tries the txs in the order they arrived to the pool
+type fcfsPolicy struct{}
+
+func (p *fcfsPolicy) sort(txs []*TxTracker, isFirstL2BlockAndEmpty bool) []*TxTracker {
+	sorted := make([]*TxTracker, len(txs))
+	copy(sorted, txs)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].PoolReceivedAt.Before(sorted[j].PoolReceivedAt)
+	})
+	return sorted
+}
+
+func (p *fcfsPolicy) canUse(tx *TxTracker, needed state.BatchResources, remaining state.BatchResources) bool {
+	return true
+}
+
+func (p *fcfsPolicy) txSelected(tx *TxTracker) {}
+
+// fairGasPricePolicy tries the txs with higher gas price first, skipping the senders that have
+// reached the max number of txs in the current batch
+type fairGasPricePolicy struct {
+	maxTxsPerSender uint64
+	txsPerSender    map[common.Address]uint64
+}
+
+func (p *fairGasPricePolicy) sort(txs []*TxTracker, isFirstL2BlockAndEmpty bool) []*TxTracker {
+	// A new batch starts, reset the txs of each sender
+	if isFirstL2BlockAndEmpty {
+		p.txsPerSender = make(map[common.Address]uint64)
+	}
+	if p.maxTxsPerSender == 0 {
+		return txs
+	}
+
+	candidates := make([]*TxTracker, 0, len(txs))
+	for _, tx := range txs {
+		if p.txsPerSender[tx.From] < p.maxTxsPerSender {
+			candidates = append(candidates, tx)
+		}
+	}
+	return candidates
+}
+
+func (p *fairGasPricePolicy) canUse(tx *TxTracker, needed state.BatchResources, remaining state.BatchResources) bool {
+	return true
+}
+
+func (p *fairGasPricePolicy) txSelected(tx *TxTracker) {
+	p.txsPerSender[tx.From]++
+}
+
+// weightedPolicy tries the txs sent to the whitelisted contracts first. While there are txs sent to the whitelisted
+// contracts, the rest of the txs can't use the share of the batch resources reserved for the whitelisted contracts
+type weightedPolicy struct {
+	whitelisted            map[common.Address]struct{}
+	reserved               state.BatchResources
+	hasWhitelistedReadyTxs bool
+}
+
+func newWeightedPolicy(cfg OrderingPolicyCfg, constraints state.BatchConstraintsCfg) *weightedPolicy {
+	pct := cfg.ReservedResourcesPct
+	if pct > 100 { //nolint:gomnd
+		pct = 100
+	}
+	percentage := func(value uint32) uint32 {
+		return uint32(uint64(value) * uint64(pct) / 100) //nolint:gomnd
+	}
+
+	maxResources := getMaxBatchResources(constraints)
+	p := &weightedPolicy{
+		whitelisted: make(map[common.Address]struct{}, len(cfg.WhitelistedContracts)),
+		reserved: state.BatchResources{
+			ZKCounters: state.ZKCounters{
+				GasUsed:          maxResources.ZKCounters.GasUsed * uint64(pct) / 100, //nolint:gomnd
+				KeccakHashes:     percentage(maxResources.ZKCounters.KeccakHashes),
+				PoseidonHashes:   percentage(maxResources.ZKCounters.PoseidonHashes),
+				PoseidonPaddings: percentage(maxResources.ZKCounters.PoseidonPaddings),
+				MemAligns:        percentage(maxResources.ZKCounters.MemAligns),
+				Arithmetics:      percentage(maxResources.ZKCounters.Arithmetics),
+				Binaries:         percentage(maxResources.ZKCounters.Binaries),
+				Steps:            percentage(maxResources.ZKCounters.Steps),
+				Sha256Hashes_V2:  percentage(maxResources.ZKCounters.Sha256Hashes_V2),
+			},
+			Bytes: maxResources.Bytes * uint64(pct) / 100, //nolint:gomnd
+		},
+	}
+	for _, addr := range cfg.WhitelistedContracts {
+		p.whitelisted[common.HexToAddress(addr)] = struct{}{}
+	}
+	return p
+}
+
+func (p *weightedPolicy) isWhitelisted(tx *TxTracker) bool {
+	if tx.To == nil {
+		return false
+	}
+	_, found := p.whitelisted[*tx.To]
+	return found
+}
+
+func (p *weightedPolicy) sort(txs []*TxTracker, isFirstL2BlockAndEmpty bool) []*TxTracker {
+	sorted := make([]*TxTracker, 0, len(txs))
+	others := make([]*TxTracker, 0, len(txs))
+	for _, tx := range txs {
+		if p.isWhitelisted(tx) {
+			sorted = append(sorted, tx)
+		} else {
+			others = append(others, tx)
+		}
+	}
+	p.hasWhitelistedReadyTxs = len(sorted) > 0
+	return append(sorted, others...)
+}
+
+func (p *weightedPolicy) canUse(tx *TxTracker, needed state.BatchResources, remaining state.BatchResources) bool {
+	if !p.hasWhitelistedReadyTxs || p.isWhitelisted(tx) {
+		return true
+	}
+	neededWithReserved := needed
+	neededWithReserved.SumUp(p.reserved)
+	fits, _ := remaining.Fits(neededWithReserved)
+	return fits
+}
+
+func (p *weightedPolicy) txSelected(tx *TxTracker) {}
@@ -0,0 +1,113 @@
+package sequencer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newOrderingTestTx(hash common.Hash, from common.Address, to common.Address, gasPrice int64, receivedAt time.Time) *TxTracker {
+	return &TxTracker{
+		Hash:               hash,
+		HashStr:            hash.String(),
+		From:               from,
+		FromStr:            from.String(),
+		To:                 &to,
+		Nonce:              1,
+		GasPrice:           big.NewInt(gasPrice),
+		Cost:               big.NewInt(1),
+		Bytes:              1,
+		ReservedZKCounters: state.ZKCounters{Steps: 1},
+		IP:                 validIP,
+		PoolReceivedAt:     receivedAt,
+	}
+}
+
+func TestNewOrderingPolicy(t *testing.T) {
+	assert.IsType(t, &gasPricePolicy{}, newOrderingPolicy(OrderingPolicyCfg{}, rcMax))
+	assert.IsType(t, &gasPricePolicy{}, newOrderingPolicy(OrderingPolicyCfg{Type: "unknown"}, rcMax))
+	assert.IsType(t, &fcfsPolicy{}, newOrderingPolicy(OrderingPolicyCfg{Type: OrderingPolicyFCFS}, rcMax))
+	assert.IsType(t, &fairGasPricePolicy{}, newOrderingPolicy(OrderingPolicyCfg{Type: OrderingPolicyFairGasPrice}, rcMax))
+	assert.IsType(t, &weightedPolicy{}, newOrderingPolicy(OrderingPolicyCfg{Type: OrderingPolicyWeighted}, rcMax))
+}
+
+func TestFCFSPolicy(t *testing.T) {
+	now := time.Now()
+	tx1 := newOrderingTestTx(common.Hash{1}, common.Address{1}, common.Address{9}, 100, now.Add(time.Second))
+	tx2 := newOrderingTestTx(common.Hash{2}, common.Address{2}, common.Address{9}, 10, now)
+
+	sorted := (&fcfsPolicy{}).sort([]*TxTracker{tx1, tx2}, false)
+	assert.Equal(t, []*TxTracker{tx2, tx1}, sorted)
+}
+
+func TestFairGasPricePolicy(t *testing.T) {
+	tx1 := newOrderingTestTx(common.Hash{1}, common.Address{1}, common.Address{9}, 100, time.Now())
+	tx2 := newOrderingTestTx(common.Hash{2}, common.Address{2}, common.Address{9}, 10, time.Now())
+
+	policy := newOrderingPolicy(OrderingPolicyCfg{Type: OrderingPolicyFairGasPrice, MaxTxsPerSender: 1}, rcMax)
+	assert.Equal(t, []*TxTracker{tx1, tx2}, policy.sort([]*TxTracker{tx1, tx2}, true))
+
+	// The sender of tx1 reaches the max number of txs in the batch
+	policy.txSelected(tx1)
+	assert.Equal(t, []*TxTracker{tx2}, policy.sort([]*TxTracker{tx1, tx2}, false))
+
+	// A new batch starts
+	assert.Equal(t, []*TxTracker{tx1, tx2}, policy.sort([]*TxTracker{tx1, tx2}, true))
+}
+
+func TestWeightedPolicy(t *testing.T) {
+	whitelisted := common.Address{9}
+	tx1 := newOrderingTestTx(common.Hash{1}, common.Address{1}, common.Address{8}, 100, time.Now())
+	tx2 := newOrderingTestTx(common.Hash{2}, common.Address{2}, whitelisted, 10, time.Now())
+
+	policy := newOrderingPolicy(OrderingPolicyCfg{
+		Type:                 OrderingPolicyWeighted,
+		ReservedResourcesPct: 50,
+		WhitelistedContracts: []string{whitelisted.String()},
+	}, rcMax)
+
+	// Without txs to the whitelisted contracts there are no reserved resources
+	assert.Equal(t, []*TxTracker{tx1}, policy.sort([]*TxTracker{tx1}, false))
+	needed := state.BatchResources{ZKCounters: state.ZKCounters{Steps: 6}, Bytes: 1}
+	remaining := getMaxBatchResources(rcMax)
+	assert.True(t, policy.canUse(tx1, needed, remaining))
+
+	// With txs to the whitelisted contracts half of the batch is reserved for them
+	assert.Equal(t, []*TxTracker{tx2, tx1}, policy.sort([]*TxTracker{tx1, tx2}, false))
+	assert.True(t, policy.canUse(tx2, needed, remaining))
+	assert.False(t, policy.canUse(tx1, needed, remaining))
+	needed.ZKCounters.Steps = 5
+	assert.True(t, policy.canUse(tx1, needed, remaining))
+}
+
+func TestWorkerGetBestFittingTxFCFS(t *testing.T) {
+	ctx := context.Background()
+	stateMock := NewStateMock(t)
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(common.Hash{0}, nil)
+	for _, addr := range []common.Address{{1}, {2}} {
+		stateMock.On("GetNonceByStateRoot", ctx, addr, common.Hash{0}).Return(big.NewInt(1), nil)
+		stateMock.On("GetBalanceByStateRoot", ctx, addr, common.Hash{0}).Return(big.NewInt(10), nil)
+	}
+
+	worker := initWorker(stateMock, rcMax)
+	worker.SetOrderingPolicyCfg(OrderingPolicyCfg{Type: OrderingPolicyFCFS})
+
+	now := time.Now()
+	for _, tx := range []*TxTracker{
+		newOrderingTestTx(common.Hash{1}, common.Address{1}, common.Address{9}, 100, now.Add(time.Second)),
+		newOrderingTestTx(common.Hash{2}, common.Address{2}, common.Address{9}, 10, now),
+	} {
+		_, err := worker.AddTxTracker(ctx, tx)
+		require.NoError(t, err)
+	}
+
+	tx, _, err := worker.GetBestFittingTx(getMaxBatchResources(rcMax), state.ZKCounters{}, true)
+	require.NoError(t, err)
+	assert.Equal(t, common.Hash{2}, tx.Hash)
+}
@@ -0,0 +1,270 @@
+package sequencer
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	rpctypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gorilla/websocket"
+)
+
+const (
+	// preconfirmationSubscription is the subscription id of the notifications sent by the preconfirmation server
+	preconfirmationSubscription = "preconfirmations"
+	// preconfirmationClientBufferSize is the max number of preconfirmations queued for a client, slow clients are disconnected
+	preconfirmationClientBufferSize = 1024
+	// preconfirmationBufferSize is the max number of preconfirmations queued by the finalizer to be sent to the
+	// clients, the preconfirmations are dropped when it's full so the finalizer is never blocked
+	preconfirmationBufferSize          = 4096
+	preconfirmationWriteTimeout        = 5 * time.Second
+	preconfirmationAuthorizationPrefix = "Bearer "
+)
+
+// errPreconfirmationNoAuth is returned when the preconfirmation server would be reachable from other hosts without authentication
+var errPreconfirmationNoAuth = errors.New("the preconfirmation server requires AuthKeys when Host is not a loopback address")
+
+// PreconfirmationCfg contains the config of the preconfirmation server, it streams the txs executed in the
+// WIP L2 block to the RPC nodes before the L2 block is stored
+type PreconfirmationCfg struct {
+	// Enabled is a flag to enable/disable the preconfirmation server
+	Enabled bool `mapstructure:"Enabled"`
+	// Host is the address the websocket preconfirmation server listens on
+	Host string `mapstructure:"Host"`
+	// Port is the port of the websocket preconfirmation server
+	Port uint16 `mapstructure:"Port"`
+	// AuthKeys are the keys accepted by the preconfirmation server, sent by the clients as a bearer token
+	// in the Authorization header. The clients are not authenticated when it's empty, which is only
+	// allowed when Host is a loopback address
+	AuthKeys []string `mapstructure:"AuthKeys"`
+}
+
+// preconfirmationServer streams the preconfirmations to the connected clients as eth_subscription
+// notifications, so the RPC nodes relay them with the same client used to relay them between RPC nodes
+type preconfirmationServer struct {
+	cfg              PreconfirmationCfg
+	upgrader         websocket.Upgrader
+	preconfirmations chan state.Preconfirmation
+	clients          map[*preconfirmationClient]struct{}
+	mutex            sync.Mutex
+}
+
+type preconfirmationClient struct {
+	conn     *websocket.Conn
+	messages chan []byte
+}
+
+func newPreconfirmationServer(cfg PreconfirmationCfg) *preconfirmationServer {
+	return &preconfirmationServer{
+		cfg:              cfg,
+		preconfirmations: make(chan state.Preconfirmation, preconfirmationBufferSize),
+		clients:          make(map[*preconfirmationClient]struct{}),
+	}
+}
+
+// start starts listening for websocket connections and sending the published preconfirmations
+func (s *preconfirmationServer) start() error {
+	if len(s.cfg.AuthKeys) == 0 && !isLoopbackHost(s.cfg.Host) {
+		return errPreconfirmationNoAuth
+	}
+	address := net.JoinHostPort(s.cfg.Host, strconv.FormatUint(uint64(s.cfg.Port), 10)) //nolint:gomnd
+	lis, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handle)
+	srv := &http.Server{
+		Handler:           mux,
+		ReadHeaderTimeout: time.Minute,
+	}
+	go func() {
+		if err := srv.Serve(lis); err != nil && err != http.ErrServerClosed {
+			log.Errorf("preconfirmation server stopped, error: %v", err)
+		}
+	}()
+	go s.send()
+	log.Infof("preconfirmation server listening on %s", address)
+	return nil
+}
+
+func (s *preconfirmationServer) handle(w http.ResponseWriter, req *http.Request) {
+	if !s.authenticate(req) {
+		log.Warnf("preconfirmation client %s rejected, authentication required", req.RemoteAddr)
+		http.Error(w, "preconfirmation authentication required", http.StatusUnauthorized)
+		return
+	}
+	conn, err := s.upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		log.Errorf("failed to upgrade preconfirmation connection, error: %v", err)
+		return
+	}
+	c := &preconfirmationClient{
+		conn:     conn,
+		messages: make(chan []byte, preconfirmationClientBufferSize),
+	}
+	s.mutex.Lock()
+	s.clients[c] = struct{}{}
+	s.mutex.Unlock()
+	log.Infof("preconfirmation client %s connected", conn.RemoteAddr())
+
+	// The messages of the client are discarded, reading is needed to detect the close of the connection
+	go func() {
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				s.remove(c)
+				return
+			}
+		}
+	}()
+
+	defer func() {
+		conn.Close()
+		log.Infof("preconfirmation client %s disconnected", conn.RemoteAddr())
+	}()
+	for message := range c.messages {
+		_ = conn.SetWriteDeadline(time.Now().Add(preconfirmationWriteTimeout))
+		if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+			log.Warnf("failed to send preconfirmation to client %s, error: %v", conn.RemoteAddr(), err)
+			s.remove(c)
+			return
+		}
+	}
+}
+
+// remove closes the queue of a client, the connection is closed once the queued messages are sent
+func (s *preconfirmationServer) remove(c *preconfirmationClient) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if _, ok := s.clients[c]; ok {
+		delete(s.clients, c)
+		close(c.messages)
+	}
+}
+
+// authenticate checks the request carries one of the configured keys, if any
+func (s *preconfirmationServer) authenticate(req *http.Request) bool {
+	if len(s.cfg.AuthKeys) == 0 {
+		return true
+	}
+	authorization := req.Header.Get("Authorization")
+	if !strings.HasPrefix(authorization, preconfirmationAuthorizationPrefix) {
+		return false
+	}
+	key := []byte(strings.TrimPrefix(authorization, preconfirmationAuthorizationPrefix))
+	for _, authKey := range s.cfg.AuthKeys {
+		if subtle.ConstantTimeCompare(key, []byte(authKey)) == 1 {
+			return true
+		}
+	}
+	return false
+}
+
+// publish queues a preconfirmation to be sent to the clients without blocking,
+// the preconfirmation is dropped if the queue is full
+func (s *preconfirmationServer) publish(preconfirmation state.Preconfirmation) {
+	select {
+	case s.preconfirmations <- preconfirmation:
+	default:
+		log.Warnf("preconfirmation queue is full, dropping preconfirmation of tx %s", preconfirmation.TxHash)
+	}
+}
+
+// send encodes the queued preconfirmations and sends them to the clients
+func (s *preconfirmationServer) send() {
+	for preconfirmation := range s.preconfirmations {
+		s.broadcast(preconfirmation)
+	}
+}
+
+// broadcast sends a preconfirmation to all the clients without blocking, the clients
+// whose queue is full are disconnected
+func (s *preconfirmationServer) broadcast(preconfirmation state.Preconfirmation) {
+	result, err := json.Marshal(preconfirmation)
+	if err != nil {
+		log.Errorf("failed to marshal preconfirmation of tx %s, error: %v", preconfirmation.TxHash, err)
+		return
+	}
+	message, err := json.Marshal(rpctypes.SubscriptionResponse{
+		JSONRPC: "2.0",
+		Method:  "eth_subscription",
+		Params: rpctypes.SubscriptionResponseParams{
+			Subscription: preconfirmationSubscription,
+			Result:       result,
+		},
+	})
+	if err != nil {
+		log.Errorf("failed to marshal preconfirmation of tx %s, error: %v", preconfirmation.TxHash, err)
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for c := range s.clients {
+		select {
+		case c.messages <- message:
+		default:
+			log.Warnf("preconfirmation client %s is too slow, disconnecting it", c.conn.RemoteAddr())
+			delete(s.clients, c)
+			close(c.messages)
+		}
+	}
+}
+
+// startPreconfirmationServer starts the preconfirmation server if enabled
+func (s *Sequencer) startPreconfirmationServer() *preconfirmationServer {
+	if !s.cfg.Preconfirmation.Enabled {
+		return nil
+	}
+	server := newPreconfirmationServer(s.cfg.Preconfirmation)
+	if err := server.start(); err != nil {
+		log.Fatalf("failed to start preconfirmation server, error: %v", err)
+	}
+	return server
+}
+
+// publishPreconfirmation publishes a tx executed in the WIP L2 block, the private txs are not published
+func (f *finalizer) publishPreconfirmation(tx *TxTracker, txResponse *state.ProcessTransactionResponse, blockNumber, blockTimestamp uint64) {
+	if f.preconfirmationServer == nil || tx.IsPrivate {
+		return
+	}
+	logs := make([]*types.Log, 0, len(txResponse.Logs))
+	for _, l := range txResponse.Logs {
+		l := *l
+		l.TxHash = txResponse.TxHash
+		l.BlockNumber = blockNumber
+		if l.Topics == nil {
+			l.Topics = []common.Hash{}
+		}
+		logs = append(logs, &l)
+	}
+	f.preconfirmationServer.publish(state.Preconfirmation{
+		Status:         state.PreconfirmationStatusUnconfirmed,
+		TxHash:         txResponse.TxHash,
+		BlockNumber:    hexutil.Uint64(blockNumber),
+		BlockTimestamp: hexutil.Uint64(blockTimestamp),
+		ReceiptStatus:  hexutil.Uint64(txResponse.Status),
+		GasUsed:        hexutil.Uint64(txResponse.GasUsed),
+		Logs:           logs,
+	})
+}
+
+// isLoopbackHost returns whether a host is only reachable from the local machine
+func isLoopbackHost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
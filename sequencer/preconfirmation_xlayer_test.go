@@ -0,0 +1,73 @@
+package sequencer
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	rpctypes "github.com/0xPolygonHermez/zkevm-node/jsonrpc/types"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublishPreconfirmation(t *testing.T) {
+	// The clients must be authenticated when the server is reachable from other hosts
+	server := newPreconfirmationServer(PreconfirmationCfg{Enabled: true, Host: "0.0.0.0", Port: 6911})
+	assert.ErrorIs(t, server.start(), errPreconfirmationNoAuth)
+
+	server = newPreconfirmationServer(PreconfirmationCfg{Enabled: true, Host: "127.0.0.1", Port: 6911, AuthKeys: []string{"key"}})
+	require.NoError(t, server.start())
+
+	_, res, err := websocket.DefaultDialer.Dial("ws://127.0.0.1:6911", http.Header{"Authorization": []string{"Bearer wrong"}})
+	require.Error(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws://127.0.0.1:6911", http.Header{"Authorization": []string{"Bearer key"}})
+	require.NoError(t, err)
+	defer conn.Close()
+	require.Eventually(t, func() bool {
+		server.mutex.Lock()
+		defer server.mutex.Unlock()
+		return len(server.clients) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The private txs are not published, so the first message received is the preconfirmation of the public tx
+	txHash := common.HexToHash("0x1")
+	f := &finalizer{preconfirmationServer: server}
+	f.publishPreconfirmation(&TxTracker{IsPrivate: true}, &state.ProcessTransactionResponse{TxHash: common.HexToHash("0x3")}, 10, 100)
+	f.publishPreconfirmation(&TxTracker{}, &state.ProcessTransactionResponse{
+		TxHash:  txHash,
+		GasUsed: 21000,
+		Status:  1,
+		Logs:    []*types.Log{{Address: common.HexToAddress("0x2"), Data: []byte{1}}},
+	}, 10, 100)
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	_, message, err := conn.ReadMessage()
+	require.NoError(t, err)
+
+	var notification rpctypes.SubscriptionResponse
+	require.NoError(t, json.Unmarshal(message, &notification))
+	assert.Equal(t, "eth_subscription", notification.Method)
+	assert.Equal(t, preconfirmationSubscription, notification.Params.Subscription)
+
+	var preconfirmation state.Preconfirmation
+	require.NoError(t, json.Unmarshal(notification.Params.Result, &preconfirmation))
+	assert.Equal(t, state.PreconfirmationStatusUnconfirmed, preconfirmation.Status)
+	assert.Equal(t, txHash, preconfirmation.TxHash)
+	assert.Equal(t, uint64(10), uint64(preconfirmation.BlockNumber))
+	assert.Equal(t, uint64(100), uint64(preconfirmation.BlockTimestamp))
+	assert.Equal(t, uint64(1), uint64(preconfirmation.ReceiptStatus))
+	require.Len(t, preconfirmation.Logs, 1)
+	assert.Equal(t, txHash, preconfirmation.Logs[0].TxHash)
+	assert.Equal(t, uint64(10), preconfirmation.Logs[0].BlockNumber)
+
+	// A finalizer without preconfirmation server doesn't publish
+	(&finalizer{}).publishPreconfirmation(&TxTracker{}, &state.ProcessTransactionResponse{TxHash: txHash}, 10, 100)
+}
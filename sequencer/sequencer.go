@@ -94,7 +94,13 @@ func (s *Sequencer) Start(ctx context.Context) {
 
 	s.workerReadyTxsCond = newTimeoutCond(&sync.Mutex{})
 	s.worker = NewWorker(s.stateIntf, s.batchCfg.Constraints, s.workerReadyTxsCond)
+	// XLayer ordering policy
+	s.worker.SetOrderingPolicyCfg(s.cfg.OrderingPolicy)
+	// XLayer worker snapshot
+	s.restoreWorkerSnapshot(ctx)
 	s.finalizer = newFinalizer(s.cfg.Finalizer, s.poolCfg, s.worker, s.pool, s.stateIntf, s.etherman, s.cfg.L2Coinbase, s.isSynced, s.batchCfg.Constraints, s.eventLog, s.streamServer, s.workerReadyTxsCond, s.dataToStream)
+	// XLayer preconfirmation server
+	s.finalizer.preconfirmationServer = s.startPreconfirmationServer()
 	go s.finalizer.Start(ctx)
 
 	go s.loadFromPool(ctx)
@@ -108,6 +114,8 @@ func (s *Sequencer) Start(ctx context.Context) {
 	// X Layer handler
 	go s.countPendingTx()
 	go s.countReadyTx()
+	go s.removeEvictedTxs(ctx)
+	go s.snapshotWorker(ctx)
 
 	// Wait until context is done
 	<-ctx.Done()
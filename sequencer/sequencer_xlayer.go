@@ -2,6 +2,7 @@ package sequencer
 
 import (
 	"context"
+	"errors"
 	"math/big"
 	"time"
 
@@ -64,3 +65,134 @@ func (s *Sequencer) checkFreeGas(tx pool.Transaction, txTracker *TxTracker) (fre
 
 	return
 }
+
+// removeEvictedTxs keeps removing from the worker the txs evicted from the pool
+func (s *Sequencer) removeEvictedTxs(ctx context.Context) {
+	ticker := time.NewTicker(s.cfg.LoadPoolTxsCheckInterval.Duration)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		if s.finalizer.haltFinalizer.Load() {
+			return
+		}
+
+		evictedTxs, err := s.pool.GetEvictedTxs(ctx, getQueryPendingTxsLimit(s.cfg.QueryPendingTxsLimit))
+		if err != nil && err != pool.ErrNotFound {
+			log.Errorf("error loading evicted txs from pool, error: %v", err)
+			continue
+		}
+
+		for _, tx := range evictedTxs {
+			failedReason := pool.ErrEvictedTransaction.Error()
+			if tx.FailedReason != nil {
+				failedReason = *tx.FailedReason
+			}
+
+			from, err := state.GetSender(tx.Transaction)
+			if err != nil {
+				log.Errorf("failed to get sender of evicted tx %s, error: %v", tx.Hash().String(), err)
+				continue
+			}
+			if err := s.worker.EvictTx(tx.Hash(), from); errors.Is(err, pool.ErrTxWIP) {
+				log.Infof("evicted tx %s is being processed by the sequencer, setting it back as pending", tx.Hash().String())
+				if err := s.pool.RestoreEvictedTx(ctx, tx.Hash()); err != nil {
+					log.Errorf("failed to restore evicted tx %s, error: %v", tx.Hash().String(), err)
+				}
+				continue
+			}
+
+			err = s.pool.UpdateTxStatus(ctx, tx.Hash(), pool.TxStatusFailed, false, &failedReason)
+			if err != nil {
+				log.Errorf("failed to update evicted tx %s status, error: %v", tx.Hash().String(), err)
+				continue
+			}
+			log.Infof("evicted tx %s removed from worker", tx.Hash().String())
+		}
+	}
+}
+
+// restoreWorkerSnapshot restores the worker state from the last snapshot, only the txs that are still
+// pending in the pool are restored. The restored txs are marked as WIP to not load them again from the pool
+func (s *Sequencer) restoreWorkerSnapshot(ctx context.Context) {
+	if !s.cfg.WorkerSnapshot.Enabled {
+		return
+	}
+
+	snapshot, err := loadWorkerSnapshot(s.cfg.WorkerSnapshot.Filename)
+	if err != nil {
+		log.Errorf("failed to load worker snapshot, error: %v", err)
+		return
+	}
+	if snapshot == nil {
+		log.Infof("worker snapshot %s not found", s.cfg.WorkerSnapshot.Filename)
+		return
+	}
+
+	restoredTxs, replacedTxs := s.worker.RestoreSnapshot(ctx, s.filterPendingTxs(ctx, snapshot.PendingToStore), s.filterPendingTxs(ctx, snapshot.Txs))
+	for _, tx := range restoredTxs {
+		err = s.pool.UpdateTxWIPStatus(ctx, tx.Hash, true)
+		if err != nil {
+			log.Errorf("failed to update restored tx %s wip status, error: %v", tx.HashStr, err)
+		}
+	}
+	failedReason := ErrReplacedTransaction.Error()
+	for _, tx := range replacedTxs {
+		err = s.pool.UpdateTxStatus(ctx, tx.Hash, pool.TxStatusFailed, false, &failedReason)
+		if err != nil {
+			log.Errorf("failed to update replaced tx %s status, error: %v", tx.HashStr, err)
+		}
+	}
+
+	log.Infof("restored %d txs from worker snapshot created at %v", len(restoredTxs), snapshot.CreatedAt)
+}
+
+// filterPendingTxs returns the txs that are still pending in the pool
+func (s *Sequencer) filterPendingTxs(ctx context.Context, txs []*TxTracker) []*TxTracker {
+	pendingTxs := make([]*TxTracker, 0, len(txs))
+	for _, tx := range txs {
+		isPending, err := s.pool.IsTxPending(ctx, tx.Hash)
+		if err != nil {
+			log.Errorf("failed to check if tx %s from worker snapshot is pending, error: %v", tx.HashStr, err)
+			continue
+		}
+		if isPending {
+			pendingTxs = append(pendingTxs, tx)
+		}
+	}
+	return pendingTxs
+}
+
+// snapshotWorker keeps saving the worker state to be able to restore it after a restart
+func (s *Sequencer) snapshotWorker(ctx context.Context) {
+	if !s.cfg.WorkerSnapshot.Enabled {
+		return
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			s.saveWorkerSnapshot()
+			return
+		case <-time.After(s.cfg.WorkerSnapshot.Interval.Duration):
+		}
+
+		if s.finalizer.haltFinalizer.Load() {
+			return
+		}
+
+		s.saveWorkerSnapshot()
+	}
+}
+
+func (s *Sequencer) saveWorkerSnapshot() {
+	err := s.worker.SaveSnapshot(s.cfg.WorkerSnapshot.Filename)
+	if err != nil {
+		log.Errorf("failed to save worker snapshot, error: %v", err)
+	}
+}
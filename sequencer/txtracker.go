@@ -33,6 +33,12 @@ type TxTracker struct {
 	L1GasPrice         uint64
 	L2GasPrice         uint64
 	IsClaimTx          bool
+	To                 *common.Address
+	PoolReceivedAt     time.Time              // To sort the txs by arrival time to the pool
+	Conditional        *pool.TxConditional    // Conditions that must hold when the tx is processed
+	ValidityWindow     *pool.TxValidityWindow // L2 blocks that can include the tx
+	BlockWindowOpenAt  time.Time              // Timestamp of the first L2 block within the block bounds of the validity window
+	IsPrivate          bool                   // Private txs are not preconfirmed
 }
 
 // newTxTracker creates and inti a TxTracker
@@ -63,6 +69,11 @@ func newTxTracker(ptx pool.Transaction, usedZKCounters state.ZKCounters, reserve
 		RawTx:              rawTx,
 		ReceivedAt:         time.Now(),
 		IP:                 ip,
+		To:                 tx.To(),
+		PoolReceivedAt:     ptx.ReceivedAt,
+		Conditional:        ptx.Conditional,
+		ValidityWindow:     ptx.ValidityWindow,
+		IsPrivate:          ptx.IsPrivate,
 		EffectiveGasPrice:  new(big.Int).SetUint64(0),
 		EGPLog: state.EffectiveGasPriceLog{
 			ValueFinal:     new(big.Int).SetUint64(0),
@@ -0,0 +1,139 @@
+package sequencer
+
+import (
+	"context"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+)
+
+// lifetimeStart returns the time the lifetime of the tx in the worker is counted from, or false if it hasn't
+// started yet. A scheduled tx isn't expired by TxLifetimeMax before its window opens, the lifetime of a tx
+// with a block bound starts at the first L2 block within the bound
+func (tx *TxTracker) lifetimeStart() (time.Time, bool) {
+	start := tx.ReceivedAt
+	if tx.ValidityWindow == nil {
+		return start, true
+	}
+	if tx.ValidityWindow.NotBeforeBlock != nil {
+		if tx.BlockWindowOpenAt.IsZero() {
+			return time.Time{}, false
+		}
+		if tx.BlockWindowOpenAt.After(start) {
+			start = tx.BlockWindowOpenAt
+		}
+	}
+	if tx.ValidityWindow.NotBefore != nil {
+		notBefore := time.Unix(int64(*tx.ValidityWindow.NotBefore), 0)
+		if notBefore.After(start) {
+			start = notBefore
+		}
+	}
+	return start, true
+}
+
+// isLifetimeExpired checks if the tx has been in the worker for more than maxTime since its lifetime started
+func (tx *TxTracker) isLifetimeExpired(maxTime time.Duration) bool {
+	start, started := tx.lifetimeStart()
+	return started && start.Add(maxTime).Before(time.Now())
+}
+
+// scheduleTx keeps a ready tx out of the txSortedList while the wip L2 block is outside its validity
+// window, returns true if the tx has been scheduled. The worker mutex must be held
+func (w *Worker) scheduleTx(readyTx *TxTracker) bool {
+	if readyTx.ValidityWindow == nil {
+		return false
+	}
+
+	if readyTx.ValidityWindow.IsOpen(w.validityBlockNumber, w.validityTimestamp) &&
+		readyTx.ValidityWindow.CheckExpired(w.validityBlockNumber, w.validityTimestamp) == nil {
+		delete(w.scheduledTxs, readyTx.HashStr)
+		return false
+	}
+
+	if _, found := w.scheduledTxs[readyTx.HashStr]; !found {
+		log.Debugf("readyTx %s (nonce: %d, addr: %s) scheduled until its validity window opens", readyTx.HashStr, readyTx.Nonce, readyTx.FromStr)
+		w.scheduledTxs[readyTx.HashStr] = readyTx
+	}
+	return true
+}
+
+// UpdateValidityWindows sets the number and timestamp of the wip L2 block, deletes the txs whose validity window
+// has closed and moves to the txSortedList the scheduled ready txs whose window has opened. The deleted txs are returned.
+// Only the txs with a validity window are checked, they are tracked in validityWindowTxs from the time they are added
+// to the worker until they leave it
+func (w *Worker) UpdateValidityWindows(blockNumber uint64, timestamp uint64) []*TxTracker {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	w.validityBlockNumber = blockNumber
+	w.validityTimestamp = timestamp
+
+	var expiredTxs []*TxTracker
+	for hash, tx := range w.validityWindowTxs {
+		// The txs that are no longer in their addrQueue have left the worker or have been replaced
+		addrQueue, found := w.pool[tx.FromStr]
+		if !found || (addrQueue.readyTx != tx && addrQueue.notReadyTxs[tx.Nonce] != tx) {
+			delete(w.validityWindowTxs, hash)
+			continue
+		}
+
+		if tx.ValidityWindow.NotBeforeBlock != nil && blockNumber >= *tx.ValidityWindow.NotBeforeBlock && tx.BlockWindowOpenAt.IsZero() {
+			tx.BlockWindowOpenAt = time.Unix(int64(timestamp), 0)
+		}
+
+		err := tx.ValidityWindow.CheckExpired(blockNumber, timestamp)
+		if err == nil {
+			continue
+		}
+		reason := err.Error()
+		tx.FailedReason = &reason
+		expiredTxs = append(expiredTxs, tx)
+		delete(w.validityWindowTxs, hash)
+		if addrQueue.readyTx == tx {
+			addrQueue.readyTx = nil
+			w.txSortedList.delete(tx)
+			w.deleteReadyTxCounter(tx.FromStr)
+			log.Debugf("deleting readyTx %s from addrQueue %s, reason: %s", tx.HashStr, addrQueue.fromStr, reason)
+		} else {
+			delete(addrQueue.notReadyTxs, tx.Nonce)
+			log.Debugf("deleting notReadyTx %s from addrQueue %s, reason: %s", tx.HashStr, addrQueue.fromStr, reason)
+		}
+	}
+
+	for hash, tx := range w.scheduledTxs {
+		// The scheduled txs that are no longer the ready tx of their addrQueue are discarded, they are scheduled
+		// again when they become ready
+		addrQueue, found := w.pool[tx.FromStr]
+		if !found || addrQueue.readyTx != tx {
+			delete(w.scheduledTxs, hash)
+			continue
+		}
+		if tx.ValidityWindow.IsOpen(blockNumber, timestamp) {
+			log.Infof("validity window of scheduled tx %s opened at block %d, timestamp %d", tx.HashStr, blockNumber, timestamp)
+			w.addTxToSortedList(tx)
+		}
+	}
+
+	return expiredTxs
+}
+
+// updateTxValidityWindows updates the validity windows of the scheduled txs in the worker once per wip L2 block,
+// the txs whose window has closed are set as failed in the pool
+func (f *finalizer) updateTxValidityWindows(ctx context.Context) {
+	if f.wipL2Block.trackingNum == f.validityWindowsL2Block {
+		return
+	}
+
+	f.validityWindowsL2Block = f.wipL2Block.trackingNum
+
+	expiredTxs := f.workerIntf.UpdateValidityWindows(f.getWIPL2BlockNumber(), f.wipL2Block.timestamp)
+	for _, tx := range expiredTxs {
+		log.Infof("discarding scheduled tx %s, reason: %s", tx.HashStr, *tx.FailedReason)
+		err := f.poolIntf.UpdateTxStatus(ctx, tx.Hash, pool.TxStatusFailed, false, tx.FailedReason)
+		if err != nil {
+			log.Errorf("failed to update status to failed in the pool for tx %s, error: %v", tx.HashStr, err)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package sequencer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerValidityWindows(t *testing.T) {
+	ctx := context.Background()
+	root := common.Hash{0}
+	addr1, addr2 := common.Address{1}, common.Address{2}
+
+	stateMock := NewStateMock(t)
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(root, nil)
+	stateMock.On("GetNonceByStateRoot", ctx, addr1, root).Return(big.NewInt(1), nil).Once()
+	stateMock.On("GetBalanceByStateRoot", ctx, addr1, root).Return(big.NewInt(10), nil).Once()
+	stateMock.On("GetNonceByStateRoot", ctx, addr2, root).Return(big.NewInt(1), nil).Once()
+	stateMock.On("GetBalanceByStateRoot", ctx, addr2, root).Return(big.NewInt(10), nil).Once()
+
+	worker := initWorker(stateMock, rcMax)
+	worker.UpdateValidityWindows(10, 1000)
+
+	notBefore, notAfterBlock := uint64(1100), uint64(12)
+	scheduledTx := newSnapshotTestTx(common.Hash{1}, addr1, 1, 1)
+	scheduledTx.ValidityWindow = &pool.TxValidityWindow{NotBefore: &notBefore}
+	quoteTx := newSnapshotTestTx(common.Hash{2}, addr2, 1, 1)
+	quoteTx.ValidityWindow = &pool.TxValidityWindow{NotAfterBlock: &notAfterBlock}
+	for _, tx := range []*TxTracker{scheduledTx, quoteTx} {
+		_, err := worker.AddTxTracker(ctx, tx)
+		require.NoError(t, err)
+	}
+
+	// The scheduled tx is kept out of the sorted list until its window opens
+	require.Equal(t, 1, worker.txSortedList.len())
+	assert.Equal(t, quoteTx.HashStr, worker.txSortedList.getByIndex(0).HashStr)
+
+	assert.Empty(t, worker.UpdateValidityWindows(11, 1050))
+	require.Equal(t, 1, worker.txSortedList.len())
+
+	assert.Empty(t, worker.UpdateValidityWindows(12, 1100))
+	require.Equal(t, 2, worker.txSortedList.len())
+	assert.Empty(t, worker.scheduledTxs)
+
+	// The tx whose window closed is deleted
+	expiredTxs := worker.UpdateValidityWindows(13, 1150)
+	require.Len(t, expiredTxs, 1)
+	assert.Equal(t, quoteTx.HashStr, expiredTxs[0].HashStr)
+	require.NotNil(t, expiredTxs[0].FailedReason)
+	assert.Contains(t, *expiredTxs[0].FailedReason, pool.ErrTxValidityWindowExpired.Error())
+	require.Equal(t, 1, worker.txSortedList.len())
+	assert.Equal(t, scheduledTx.HashStr, worker.txSortedList.getByIndex(0).HashStr)
+	assert.Nil(t, worker.pool[addr2.String()].readyTx)
+
+	// Only the txs with a validity window still in the worker are tracked
+	require.Len(t, worker.validityWindowTxs, 1)
+	assert.Contains(t, worker.validityWindowTxs, scheduledTx.HashStr)
+	worker.DeleteTx(scheduledTx.Hash, scheduledTx.From)
+	assert.Empty(t, worker.UpdateValidityWindows(14, 1200))
+	assert.Empty(t, worker.validityWindowTxs)
+}
+
+func TestTxTrackerLifetimeStart(t *testing.T) {
+	receivedAt := time.Now()
+	tx := &TxTracker{ReceivedAt: receivedAt}
+	start, started := tx.lifetimeStart()
+	assert.True(t, started)
+	assert.Equal(t, receivedAt, start)
+
+	notBefore := uint64(receivedAt.Add(time.Hour).Unix())
+	tx.ValidityWindow = &pool.TxValidityWindow{NotBefore: &notBefore}
+	start, started = tx.lifetimeStart()
+	assert.True(t, started)
+	assert.Equal(t, time.Unix(int64(notBefore), 0), start)
+
+	// The lifetime of a tx with a block bound doesn't start until the bound is reached
+	notBeforeBlock := uint64(20)
+	tx.ValidityWindow = &pool.TxValidityWindow{NotBeforeBlock: &notBeforeBlock}
+	tx.ReceivedAt = receivedAt.Add(-time.Hour)
+	_, started = tx.lifetimeStart()
+	assert.False(t, started)
+	assert.False(t, tx.isLifetimeExpired(time.Minute))
+
+	tx.BlockWindowOpenAt = time.Unix(receivedAt.Unix(), 0)
+	start, started = tx.lifetimeStart()
+	assert.True(t, started)
+	assert.Equal(t, tx.BlockWindowOpenAt, start)
+	assert.False(t, tx.isLifetimeExpired(time.Minute))
+}
+
+func TestWorkerBlockWindowLifetime(t *testing.T) {
+	ctx := context.Background()
+	root := common.Hash{0}
+	addr := common.Address{1}
+
+	stateMock := NewStateMock(t)
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(root, nil)
+	stateMock.On("GetNonceByStateRoot", ctx, addr, root).Return(big.NewInt(1), nil).Once()
+	stateMock.On("GetBalanceByStateRoot", ctx, addr, root).Return(big.NewInt(10), nil).Once()
+
+	worker := initWorker(stateMock, rcMax)
+	worker.UpdateValidityWindows(10, uint64(time.Now().Unix()))
+
+	notBeforeBlock := uint64(12)
+	tx := newSnapshotTestTx(common.Hash{1}, addr, 1, 1)
+	tx.ReceivedAt = time.Now().Add(-time.Hour)
+	tx.ValidityWindow = &pool.TxValidityWindow{NotBeforeBlock: &notBeforeBlock}
+	_, err := worker.AddTxTracker(ctx, tx)
+	require.NoError(t, err)
+
+	// The tx isn't expired by its lifetime while it waits for its block bound
+	assert.Empty(t, worker.ExpireTransactions(time.Minute))
+	require.Contains(t, worker.scheduledTxs, tx.HashStr)
+
+	assert.Empty(t, worker.UpdateValidityWindows(12, uint64(time.Now().Unix())))
+	assert.False(t, tx.BlockWindowOpenAt.IsZero())
+	assert.Empty(t, worker.ExpireTransactions(time.Minute))
+	require.Equal(t, 1, worker.txSortedList.len())
+}
@@ -27,8 +27,15 @@ type Worker struct {
 	wipTx            *TxTracker
 
 	// X Layer
-	claimGp        *big.Int
-	readyTxCounter map[string]uint64
+	claimGp                  *big.Int
+	readyTxCounter           map[string]uint64
+	orderingPolicyCfg        OrderingPolicyCfg
+	currentOrderingPolicyCfg OrderingPolicyCfg
+	orderingPolicy           orderingPolicy
+	scheduledTxs             map[string]*TxTracker
+	validityWindowTxs        map[string]*TxTracker
+	validityBlockNumber      uint64
+	validityTimestamp        uint64
 }
 
 // NewWorker creates an init a worker
@@ -43,8 +50,10 @@ func NewWorker(state stateInterface, constraints state.BatchConstraintsCfg, read
 		readyTxsCond:     readyTxsCond,
 
 		// XLayer
-		readyTxCounter: make(map[string]uint64),
-		claimGp:        new(big.Int),
+		readyTxCounter:    make(map[string]uint64),
+		claimGp:           new(big.Int),
+		scheduledTxs:      make(map[string]*TxTracker),
+		validityWindowTxs: make(map[string]*TxTracker),
 	}
 
 	return &w
@@ -126,6 +135,11 @@ func (w *Worker) addTxTracker(ctx context.Context, tx *TxTracker, mutex *sync.Mu
 		return repTx, dropReason
 	}
 
+	// XLayer validity windows
+	if tx.ValidityWindow != nil {
+		w.validityWindowTxs[tx.HashStr] = tx
+	}
+
 	// Update the txSortedList (if needed)
 	if prevReadyTx != nil {
 		log.Debugf("prevReadyTx %s (nonce: %d, gasPrice: %d, addr: %s) deleted from TxSortedList", prevReadyTx.HashStr, prevReadyTx.Nonce, prevReadyTx.GasPrice, tx.FromStr)
@@ -447,6 +461,10 @@ func (w *Worker) GetBestFittingTx(remainingResources state.BatchResources, highR
 		return nil, nil, ErrTransactionsListEmpty
 	}
 
+	// XLayer ordering policy
+	policy := w.getOrderingPolicy()
+	candidates := policy.sort(w.txSortedList.GetSorted(), isFistL2BlockAndEmpty)
+
 	var (
 		tx          *TxTracker
 		foundMutex  sync.RWMutex
@@ -464,7 +482,7 @@ func (w *Worker) GetBestFittingTx(remainingResources state.BatchResources, highR
 	for i := 0; i < nGoRoutines; i++ {
 		go func(n int, bresources state.BatchResources) {
 			defer wg.Done()
-			for i := n; i < w.txSortedList.len(); i += nGoRoutines {
+			for i := n; i < len(candidates); i += nGoRoutines {
 				foundMutex.RLock()
 				if foundAt != -1 && i > foundAt {
 					foundMutex.RUnlock()
@@ -472,9 +490,10 @@ func (w *Worker) GetBestFittingTx(remainingResources state.BatchResources, highR
 				}
 				foundMutex.RUnlock()
 
-				txCandidate := w.txSortedList.getByIndex(i)
+				txCandidate := candidates[i]
 				needed, _ := getNeededZKCounters(highReservedCounters, txCandidate.UsedZKCounters, txCandidate.ReservedZKCounters)
-				fits, _ := bresources.Fits(state.BatchResources{ZKCounters: needed, Bytes: txCandidate.Bytes})
+				neededResources := state.BatchResources{ZKCounters: needed, Bytes: txCandidate.Bytes}
+				fits, _ := bresources.Fits(neededResources)
 				if !fits {
 					// If we are looking for a tx for the first empty L2 block in the batch and this tx doesn't fits in the batch, then this tx will never fit in any batch.
 					// We add the tx to the oocTxs slice. That slice will be returned to set these txs as invalid (and delete them from the worker) from the finalizer code
@@ -487,6 +506,11 @@ func (w *Worker) GetBestFittingTx(remainingResources state.BatchResources, highR
 					continue
 				}
 
+				// XLayer ordering policy
+				if !policy.canUse(txCandidate, neededResources, bresources) {
+					continue
+				}
+
 				foundMutex.Lock()
 				if foundAt == -1 || foundAt > i {
 					foundAt = i
@@ -503,6 +527,8 @@ func (w *Worker) GetBestFittingTx(remainingResources state.BatchResources, highR
 	if foundAt != -1 {
 		log.Infof("best fitting tx %s found at index %d with gasPrice %d", tx.HashStr, foundAt, tx.GasPrice)
 		w.wipTx = tx
+		// XLayer ordering policy
+		policy.txSelected(tx)
 		// XLayer claim tx
 		if !tx.IsClaimTx {
 			w.claimGp = tx.GasPrice
@@ -547,6 +573,11 @@ func (w *Worker) ExpireTransactions(maxTime time.Duration) []*TxTracker {
 }
 
 func (w *Worker) addTxToSortedList(readyTx *TxTracker) {
+	// XLayer scheduled txs
+	if w.scheduleTx(readyTx) {
+		return
+	}
+
 	w.txSortedList.add(readyTx)
 	if w.txSortedList.len() == 1 {
 		// The txSortedList was empty before to add the new tx, we notify finalizer that we have new ready txs to process
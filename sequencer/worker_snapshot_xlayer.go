@@ -0,0 +1,109 @@
+package sequencer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/log"
+)
+
+const (
+	// workerSnapshotVersion is the version of the worker snapshot file format
+	workerSnapshotVersion = 1
+)
+
+// workerSnapshot contains the worker state needed to rebuild the addrQueues and the
+// txSortedList after a restart, keeping the ZK counters and the processing order of the txs
+type workerSnapshot struct {
+	Version   uint64    `json:"version"`
+	CreatedAt time.Time `json:"createdAt"`
+	// PendingToStore are the txs processed in L2 blocks not stored yet, in the order they were processed
+	PendingToStore []*TxTracker `json:"pendingToStore"`
+	// Txs are the ready and notReady txs of the addrQueues
+	Txs []*TxTracker `json:"txs"`
+}
+
+// SaveSnapshot writes the current worker state to the given file
+func (w *Worker) SaveSnapshot(filename string) error {
+	w.workerMutex.Lock()
+	snapshot := workerSnapshot{
+		Version:        workerSnapshotVersion,
+		CreatedAt:      time.Now(),
+		PendingToStore: w.pendingToStore,
+		Txs:            []*TxTracker{},
+	}
+	for _, addrQueue := range w.pool {
+		snapshot.Txs = append(snapshot.Txs, addrQueue.getTransactions()...)
+	}
+	data, err := json.Marshal(snapshot)
+	w.workerMutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	// Write to a temporary file first so a crash never leaves a truncated snapshot
+	tmpFilename := filename + ".tmp"
+	err = os.WriteFile(tmpFilename, data, 0600) //nolint:gomnd
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmpFilename, filename)
+}
+
+// loadWorkerSnapshot reads a worker snapshot from the given file, nil is returned if the file doesn't exist
+func loadWorkerSnapshot(filename string) (*workerSnapshot, error) {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	snapshot := &workerSnapshot{}
+	err = json.Unmarshal(data, snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if snapshot.Version != workerSnapshotVersion {
+		return nil, fmt.Errorf("unsupported worker snapshot version %d", snapshot.Version)
+	}
+	return snapshot, nil
+}
+
+// RestoreSnapshot adds to the worker the txs of a snapshot. The addrQueues are recreated with the current
+// nonces and balances from the state, so the txs with an already used nonce are dropped. The txs pending
+// to store are added as reorged txs to process them again in the same order before any other tx
+func (w *Worker) RestoreSnapshot(ctx context.Context, pendingToStore []*TxTracker, txs []*TxTracker) (restoredTxs []*TxTracker, replacedTxs []*TxTracker) {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	w.reorgedTxs = []*TxTracker{}
+
+	restore := func(tx *TxTracker) bool {
+		replacedTx, dropReason := w.addTxTracker(ctx, tx, nil)
+		if dropReason != nil {
+			log.Infof("tx %s (nonce: %d) from worker snapshot not restored, reason: %v", tx.HashStr, tx.Nonce, dropReason)
+			return false
+		}
+		if replacedTx != nil {
+			replacedTxs = append(replacedTxs, replacedTx)
+		}
+		restoredTxs = append(restoredTxs, tx)
+		return true
+	}
+
+	for _, tx := range pendingToStore {
+		if restore(tx) {
+			w.reorgedTxs = append(w.reorgedTxs, tx)
+		}
+	}
+	for _, tx := range txs {
+		restore(tx)
+	}
+
+	return restoredTxs, replacedTxs
+}
@@ -0,0 +1,90 @@
+package sequencer
+
+import (
+	"context"
+	"math/big"
+	"path/filepath"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSnapshotTestTx(hash common.Hash, from common.Address, nonce uint64, steps uint32) *TxTracker {
+	return &TxTracker{
+		Hash:               hash,
+		HashStr:            hash.String(),
+		From:               from,
+		FromStr:            from.String(),
+		Nonce:              nonce,
+		GasPrice:           big.NewInt(1),
+		Cost:               big.NewInt(1),
+		Bytes:              1,
+		UsedZKCounters:     state.ZKCounters{Steps: steps},
+		ReservedZKCounters: state.ZKCounters{Steps: steps},
+		IP:                 validIP,
+	}
+}
+
+func TestWorkerSnapshot(t *testing.T) {
+	ctx := context.Background()
+	filename := filepath.Join(t.TempDir(), "worker_snapshot.json")
+	root := common.Hash{0}
+	addr1, addr2 := common.Address{1}, common.Address{2}
+
+	stateMock := NewStateMock(t)
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(root, nil)
+	stateMock.On("GetNonceByStateRoot", ctx, addr1, root).Return(big.NewInt(1), nil).Once()
+	stateMock.On("GetBalanceByStateRoot", ctx, addr1, root).Return(big.NewInt(10), nil).Once()
+	stateMock.On("GetNonceByStateRoot", ctx, addr2, root).Return(big.NewInt(1), nil).Once()
+	stateMock.On("GetBalanceByStateRoot", ctx, addr2, root).Return(big.NewInt(10), nil).Once()
+
+	worker := initWorker(stateMock, rcMax)
+	for _, tx := range []*TxTracker{
+		newSnapshotTestTx(common.Hash{1}, addr1, 1, 1),
+		newSnapshotTestTx(common.Hash{2}, addr1, 2, 2),
+		newSnapshotTestTx(common.Hash{3}, addr2, 1, 3),
+	} {
+		_, err := worker.AddTxTracker(ctx, tx)
+		require.NoError(t, err)
+	}
+	worker.UpdateTxZKCounters(common.Hash{2}, addr1, state.ZKCounters{Steps: 5}, state.ZKCounters{Steps: 6})
+	worker.MoveTxPendingToStore(common.Hash{3}, addr2)
+
+	require.NoError(t, worker.SaveSnapshot(filename))
+
+	snapshot, err := loadWorkerSnapshot(filename)
+	require.NoError(t, err)
+	require.Len(t, snapshot.PendingToStore, 1)
+	assert.Equal(t, common.Hash{3}, snapshot.PendingToStore[0].Hash)
+	require.Len(t, snapshot.Txs, 2)
+
+	// The tx with nonce 1 of addr1 has been stored in the state before the restart
+	stateMock.On("GetNonceByStateRoot", ctx, addr1, root).Return(big.NewInt(2), nil).Once()
+	stateMock.On("GetBalanceByStateRoot", ctx, addr1, root).Return(big.NewInt(10), nil).Once()
+	stateMock.On("GetNonceByStateRoot", ctx, addr2, root).Return(big.NewInt(1), nil).Once()
+	stateMock.On("GetBalanceByStateRoot", ctx, addr2, root).Return(big.NewInt(10), nil).Once()
+
+	restoredWorker := initWorker(stateMock, rcMax)
+	restoredTxs, replacedTxs := restoredWorker.RestoreSnapshot(ctx, snapshot.PendingToStore, snapshot.Txs)
+	assert.Len(t, restoredTxs, 2)
+	assert.Empty(t, replacedTxs)
+	assert.Equal(t, 2, restoredWorker.txSortedList.len())
+
+	require.Len(t, restoredWorker.reorgedTxs, 1)
+	assert.Equal(t, common.Hash{3}, restoredWorker.reorgedTxs[0].Hash)
+
+	readyTx := restoredWorker.pool[addr1.String()].readyTx
+	require.NotNil(t, readyTx)
+	assert.Equal(t, common.Hash{2}, readyTx.Hash)
+	assert.Equal(t, uint32(5), readyTx.UsedZKCounters.Steps)
+	assert.Equal(t, uint32(6), readyTx.ReservedZKCounters.Steps)
+}
+
+func TestLoadWorkerSnapshotNotFound(t *testing.T) {
+	snapshot, err := loadWorkerSnapshot(filepath.Join(t.TempDir(), "worker_snapshot.json"))
+	require.NoError(t, err)
+	assert.Nil(t, snapshot)
+}
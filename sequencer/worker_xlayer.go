@@ -1,5 +1,10 @@
 package sequencer
 
+import (
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/ethereum/go-ethereum/common"
+)
+
 func (w *Worker) deleteReadyTxCounter(addr string) {
 	if w == nil || w.readyTxCounter == nil {
 		return
@@ -28,3 +33,22 @@ func (w *Worker) CountReadyTx() uint64 {
 	}
 	return count
 }
+
+// EvictTx deletes from the addrQueue a tx evicted from the pool, pool.ErrTxWIP is returned for
+// the tx being executed and the ones executed in a L2 block that is still to be stored
+func (w *Worker) EvictTx(txHash common.Hash, addr common.Address) error {
+	w.workerMutex.Lock()
+	defer w.workerMutex.Unlock()
+
+	if w.wipTx != nil && w.wipTx.Hash == txHash {
+		return pool.ErrTxWIP
+	}
+	for _, txToStore := range w.pendingToStore {
+		if txToStore.Hash == txHash {
+			return pool.ErrTxWIP
+		}
+	}
+
+	w.deleteTx(txHash, addr)
+	return nil
+}
@@ -0,0 +1,55 @@
+package sequencer
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/0xPolygonHermez/zkevm-node/pool"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkerEvictTx(t *testing.T) {
+	ctx := context.Background()
+	root := common.Hash{0}
+	addrs := []common.Address{{1}, {2}, {3}}
+
+	stateMock := NewStateMock(t)
+	stateMock.On("GetLastStateRoot", ctx, nil).Return(root, nil)
+	worker := initWorker(stateMock, rcMax)
+	for i, addr := range addrs {
+		stateMock.On("GetNonceByStateRoot", ctx, addr, root).Return(big.NewInt(1), nil).Once()
+		stateMock.On("GetBalanceByStateRoot", ctx, addr, root).Return(big.NewInt(10), nil).Once()
+		_, err := worker.AddTxTracker(ctx, newSnapshotTestTx(common.Hash{byte(i + 1)}, addr, 1, 1))
+		require.NoError(t, err)
+	}
+	rc := state.BatchResources{ZKCounters: state.ZKCounters{GasUsed: 10, Steps: 10}, Bytes: 10}
+
+	// The tx being executed can't be evicted
+	executedTx, _, err := worker.GetBestFittingTx(rc, state.ZKCounters{}, true)
+	require.NoError(t, err)
+	require.NotNil(t, executedTx)
+	assert.ErrorIs(t, worker.EvictTx(executedTx.Hash, executedTx.From), pool.ErrTxWIP)
+
+	// Neither once it is executed until its L2 block is stored
+	worker.MoveTxPendingToStore(executedTx.Hash, executedTx.From)
+	wipTx, _, err := worker.GetBestFittingTx(rc, state.ZKCounters{}, true)
+	require.NoError(t, err)
+	require.NotNil(t, wipTx)
+	assert.ErrorIs(t, worker.EvictTx(executedTx.Hash, executedTx.From), pool.ErrTxWIP)
+
+	// The other txs loaded by the worker are evicted
+	require.Equal(t, 2, worker.txSortedList.len())
+	for i := 0; i < worker.txSortedList.len(); i++ {
+		tx := worker.txSortedList.getByIndex(i)
+		if tx.Hash != wipTx.Hash {
+			require.NoError(t, worker.EvictTx(tx.Hash, tx.From))
+			break
+		}
+	}
+	require.Equal(t, 1, worker.txSortedList.len())
+	assert.Equal(t, wipTx.HashStr, worker.txSortedList.getByIndex(0).HashStr)
+}
@@ -4,8 +4,11 @@ import (
 	"context"
 
 	ethmanTypes "github.com/0xPolygonHermez/zkevm-node/etherman/types"
+	"github.com/ethereum/go-ethereum/core/types"
 )
 
 type dataAbilitier interface {
 	PostSequence(ctx context.Context, sequences []ethmanTypes.Sequence) ([]byte, error)
+	PostSequenceWithBlobs(ctx context.Context, sequences []ethmanTypes.Sequence) ([]byte, *types.BlobTxSidecar, error)
+	SequenceFits(sequences []ethmanTypes.Sequence) bool
 }
@@ -163,7 +163,7 @@ func (s *SequenceSender) tryToSendSequenceXLayer(ctx context.Context) {
 
 	// add sequence to be monitored
 	firstSequence := sequences[0]
-	dataAvailabilityMessage, err := s.da.PostSequence(ctx, sequences)
+	dataAvailabilityMessage, sidecar, err := s.da.PostSequenceWithBlobs(ctx, sequences)
 	if err != nil {
 		log.Error("error posting sequences to the data availability protocol: ", err)
 		return
@@ -175,7 +175,11 @@ func (s *SequenceSender) tryToSendSequenceXLayer(ctx context.Context) {
 	}
 
 	monitoredTxID := fmt.Sprintf(monitoredIDFormat, firstSequence.BatchNumber, lastSequence.BatchNumber)
-	err = s.ethTxManager.Add(ctx, ethTxManagerOwner, monitoredTxID, s.cfg.SenderAddress, to, nil, data, s.cfg.GasOffset, nil)
+	if sidecar != nil {
+		err = s.ethTxManager.AddWithBlobs(ctx, ethTxManagerOwner, monitoredTxID, s.cfg.SenderAddress, to, nil, data, s.cfg.GasOffset, sidecar, nil)
+	} else {
+		err = s.ethTxManager.Add(ctx, ethTxManagerOwner, monitoredTxID, s.cfg.SenderAddress, to, nil, data, s.cfg.GasOffset, nil)
+	}
 	if err != nil {
 		mTxLogger := ethtxmanager.CreateLogger(ethTxManagerOwner, monitoredTxID, s.cfg.SenderAddress, to)
 		mTxLogger.Errorf("error to add sequences tx to eth tx manager: ", err)
@@ -268,6 +272,14 @@ func (s *SequenceSender) getSequencesToSendXLayer(ctx context.Context) ([]types.
 		}
 
 		sequences = append(sequences, seq)
+		// When the data is posted in blobs, the sequence is closed before the batch that doesn't fit in the blobs of a single tx
+		if s.da != nil && !s.da.SequenceFits(sequences) {
+			if len(sequences) == 1 {
+				return nil, fmt.Errorf("batch %d doesn't fit in the blobs of a single sequence tx", seq.BatchNumber)
+			}
+			log.Infof("sequence should be sent to L1, because the batch %d doesn't fit in the blobs of the sequence tx", seq.BatchNumber)
+			return sequences[:len(sequences)-1], nil
+		}
 		if len(sequences) == int(s.cfg.MaxBatchesForL1) {
 			log.Infof(
 				"sequence should be sent to L1, because MaxBatchesForL1 (%d) has been reached",
@@ -67,6 +67,20 @@ var (
 	// ErrMaxNativeBlockHashBlockRangeLimitExceeded returned when the range between block number range
 	// to filter native block hashes is bigger than the configured limit
 	ErrMaxNativeBlockHashBlockRangeLimitExceeded = errors.New("native block hashes are limited to a %v block range")
+	// ErrStateAndStateDiffOverride is returned when both state and stateDiff are set
+	// in the override of the same account
+	ErrStateAndStateDiffOverride = errors.New("state and stateDiff can't be used together")
+	// ErrBlockOverrideTimestamp is returned when the timestamp block override is
+	// lower than the timestamp of the block the call is executed on
+	ErrBlockOverrideTimestamp = errors.New("block override timestamp can't be lower than the block timestamp")
+	// ErrBlockOverrideTimestampRange is returned when the timestamp block override is
+	// too far from the timestamp of the block to be set through a changeL2Block tx
+	ErrBlockOverrideTimestampRange = errors.New("block override timestamp is out of range")
+	// ErrEmptyBundle is returned when a bundle to simulate has no txs
+	ErrEmptyBundle = errors.New("bundle has no transactions")
+	// ErrBundleForkIDNotSupported is returned when a bundle is simulated on top of
+	// an l2 block whose fork doesn't support multiple txs per l2 block
+	ErrBundleForkIDNotSupported = errors.New("bundle simulation is only supported after ETROG")
 )
 
 // ConstructErrorFromRevert extracts the reverted reason from the provided returnValue
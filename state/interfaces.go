@@ -168,4 +168,11 @@ type storage interface {
 	GetBatchL2DataByNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]byte, error)
 	GetBatchL2DataByNumbers(ctx context.Context, batchNumbers []uint64, dbTx pgx.Tx) (map[uint64][]byte, error)
 	GetLastL2BlockTimeByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (uint64, error)
+	GetReceiptsByL2BlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]TransactionReceipt, error)
+	GetReceiptsByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]TransactionReceipt, error)
+	GetReceiptsByAddressBefore(ctx context.Context, address common.Address, blockNumber uint64, pageSize uint64, dbTx pgx.Tx) ([]TransactionReceipt, error)
+	GetReceiptsByAddressAfter(ctx context.Context, address common.Address, blockNumber uint64, pageSize uint64, dbTx pgx.Tx) ([]TransactionReceipt, error)
+	GetContractCreationReceipt(ctx context.Context, address common.Address, dbTx pgx.Tx) (*TransactionReceipt, error)
+	GetTransactionBySenderAndNonce(ctx context.Context, sender common.Address, nonce uint64, dbTx pgx.Tx) (*types.Transaction, error)
+	GetFirstAddressIndexedL2BlockNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error)
 }
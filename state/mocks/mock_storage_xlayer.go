@@ -5,6 +5,12 @@ package mocks
 import (
 	context "context"
 
+	common "github.com/ethereum/go-ethereum/common"
+
+	types "github.com/ethereum/go-ethereum/core/types"
+
+	state "github.com/0xPolygonHermez/zkevm-node/state"
+
 	pgx "github.com/jackc/pgx/v4"
 )
 
@@ -19,3 +25,31 @@ func (_m *StorageMock) GetBatchL2DataByNumbers(ctx context.Context, batchNumbers
 func (_m *StorageMock) GetLastL2BlockTimeByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) (uint64, error) {
 	return 0, nil
 }
+
+func (_m *StorageMock) GetReceiptsByL2BlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]state.TransactionReceipt, error) {
+	return nil, nil
+}
+
+func (_m *StorageMock) GetReceiptsByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]state.TransactionReceipt, error) {
+	return nil, nil
+}
+
+func (_m *StorageMock) GetReceiptsByAddressBefore(ctx context.Context, address common.Address, blockNumber uint64, pageSize uint64, dbTx pgx.Tx) ([]state.TransactionReceipt, error) {
+	return nil, nil
+}
+
+func (_m *StorageMock) GetReceiptsByAddressAfter(ctx context.Context, address common.Address, blockNumber uint64, pageSize uint64, dbTx pgx.Tx) ([]state.TransactionReceipt, error) {
+	return nil, nil
+}
+
+func (_m *StorageMock) GetContractCreationReceipt(ctx context.Context, address common.Address, dbTx pgx.Tx) (*state.TransactionReceipt, error) {
+	return nil, nil
+}
+
+func (_m *StorageMock) GetTransactionBySenderAndNonce(ctx context.Context, sender common.Address, nonce uint64, dbTx pgx.Tx) (*types.Transaction, error) {
+	return nil, nil
+}
+
+func (_m *StorageMock) GetFirstAddressIndexedL2BlockNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	return 0, nil
+}
@@ -0,0 +1,218 @@
+package state
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// OverrideAccount indicates the overriding fields of an account during the
+// execution of a message call. State and StateDiff can't be set at the same time:
+// State replaces the whole account storage while StateDiff only patches some slots.
+type OverrideAccount struct {
+	Nonce     *uint64
+	Code      []byte
+	Balance   *big.Int
+	State     map[common.Hash]common.Hash
+	StateDiff map[common.Hash]common.Hash
+}
+
+// StateOverride is the collection of overridden accounts, it follows the
+// geth state override set format
+type StateOverride map[common.Address]OverrideAccount
+
+// Validate checks the state override set can be applied
+func (so StateOverride) Validate() error {
+	for addr, account := range so {
+		if account.State != nil && account.StateDiff != nil {
+			return fmt.Errorf("%w: %s", ErrStateAndStateDiffOverride, addr.String())
+		}
+	}
+	return nil
+}
+
+// nonce returns the overridden nonce of the account, or the loaded one when
+// the account nonce is not overridden
+func (so StateOverride) nonce(addr common.Address, loadedNonce uint64) uint64 {
+	if account, found := so[addr]; found && account.Nonce != nil {
+		return *account.Nonce
+	}
+	return loadedNonce
+}
+
+// balance returns the overridden balance of the account, or the loaded one when
+// the account balance is not overridden
+func (so StateOverride) balance(addr common.Address, loadedBalance *big.Int) *big.Int {
+	if account, found := so[addr]; found && account.Balance != nil {
+		return account.Balance
+	}
+	return loadedBalance
+}
+
+// code returns the overridden code of the account, if any
+func (so StateOverride) code(addr common.Address) ([]byte, bool) {
+	if account, found := so[addr]; found && account.Code != nil {
+		return account.Code, true
+	}
+	return nil, false
+}
+
+func storageOverrideToExecutor(storage map[common.Hash]common.Hash) map[string]string {
+	if storage == nil {
+		return nil
+	}
+	result := make(map[string]string, len(storage))
+	for key, value := range storage {
+		result[key.String()] = value.String()
+	}
+	return result
+}
+
+// toExecutor converts the state override set into the executor format
+func (so StateOverride) toExecutor() map[string]*executor.OverrideAccount {
+	if len(so) == 0 {
+		return nil
+	}
+	result := make(map[string]*executor.OverrideAccount, len(so))
+	for addr, account := range so {
+		overrideAccount := &executor.OverrideAccount{
+			Code:      account.Code,
+			State:     storageOverrideToExecutor(account.State),
+			StateDiff: storageOverrideToExecutor(account.StateDiff),
+		}
+		if account.Nonce != nil {
+			overrideAccount.Nonce = *account.Nonce
+		}
+		if account.Balance != nil {
+			overrideAccount.Balance = account.Balance.Bytes()
+		}
+		result[addr.String()] = overrideAccount
+	}
+	return result
+}
+
+// toExecutorV2 converts the state override set into the executor V2 format
+func (so StateOverride) toExecutorV2() map[string]*executor.OverrideAccountV2 {
+	if len(so) == 0 {
+		return nil
+	}
+	result := make(map[string]*executor.OverrideAccountV2, len(so))
+	for addr, account := range so {
+		overrideAccount := &executor.OverrideAccountV2{
+			Code:      account.Code,
+			State:     storageOverrideToExecutor(account.State),
+			StateDiff: storageOverrideToExecutor(account.StateDiff),
+		}
+		if account.Nonce != nil {
+			overrideAccount.Nonce = *account.Nonce
+		}
+		if account.Balance != nil {
+			overrideAccount.Balance = account.Balance.Bytes()
+		}
+		result[addr.String()] = overrideAccount
+	}
+	return result
+}
+
+// BlockOverrides contains the l2 block fields that can be overridden when
+// executing an unsigned transaction
+type BlockOverrides struct {
+	Time     *uint64
+	Coinbase *common.Address
+}
+
+// unsignedTxOptions are the optional settings used to process an unsigned transaction
+type unsignedTxOptions struct {
+	stateOverride  StateOverride
+	blockOverrides *BlockOverrides
+	// traceConfig, when set, makes the executor generate the full trace of the tx
+	traceConfig *TraceConfig
+}
+
+// senderNonce returns the nonce the unsigned tx must be encoded with, taking
+// into account the nonce override of the sender
+func (o *unsignedTxOptions) senderNonce(sender common.Address, loadedNonce uint64) uint64 {
+	if o == nil {
+		return loadedNonce
+	}
+	return o.stateOverride.nonce(sender, loadedNonce)
+}
+
+// applyUnsignedTxOptions applies the options to a pre ETROG process batch request
+func (s *State) applyUnsignedTxOptions(opts *unsignedTxOptions, request *executor.ProcessBatchRequest, l2Block L2Block, batchL2Data []byte, forkID uint64) error {
+	if opts == nil {
+		return nil
+	}
+
+	request.StateOverride = opts.stateOverride.toExecutor()
+	if opts.blockOverrides != nil {
+		if opts.blockOverrides.Coinbase != nil {
+			request.Coinbase = opts.blockOverrides.Coinbase.String()
+		}
+		if opts.blockOverrides.Time != nil {
+			if *opts.blockOverrides.Time < l2Block.Time() {
+				return ErrBlockOverrideTimestamp
+			}
+			request.EthTimestamp = *opts.blockOverrides.Time
+		}
+	}
+	if opts.traceConfig != nil {
+		txHash, err := unsignedTxHash(batchL2Data, forkID)
+		if err != nil {
+			return err
+		}
+		request.TraceConfig = newExecutorTraceConfig(txHash, *opts.traceConfig)
+	}
+	return nil
+}
+
+// applyUnsignedTxOptionsV2 applies the options to a post ETROG process batch request
+func (s *State) applyUnsignedTxOptionsV2(opts *unsignedTxOptions, request *executor.ProcessBatchRequestV2, l2Block L2Block, batchL2Data []byte, forkID uint64) error {
+	if opts == nil {
+		return nil
+	}
+
+	request.StateOverride = opts.stateOverride.toExecutorV2()
+	if opts.blockOverrides != nil {
+		if opts.blockOverrides.Coinbase != nil {
+			request.Coinbase = opts.blockOverrides.Coinbase.String()
+		}
+		if opts.blockOverrides.Time != nil {
+			if *opts.blockOverrides.Time < l2Block.Time() {
+				return ErrBlockOverrideTimestamp
+			}
+			// the timestamp is moved forward through the changeL2Block tx, whose
+			// delta timestamp field is an uint32
+			delta := *opts.blockOverrides.Time - l2Block.Time()
+			if delta > math.MaxUint32 {
+				return fmt.Errorf("%w: %d seconds after the block timestamp", ErrBlockOverrideTimestampRange, delta)
+			}
+			deltaTimestamp := uint32(delta)
+			request.BatchL2Data = append(s.BuildChangeL2Block(deltaTimestamp, uint32(0)), batchL2Data...)
+			request.TimestampLimit = *opts.blockOverrides.Time
+		}
+	}
+	if opts.traceConfig != nil {
+		txHash, err := unsignedTxHash(batchL2Data, forkID)
+		if err != nil {
+			return err
+		}
+		request.TraceConfig = newExecutorTraceConfigV2(txHash, *opts.traceConfig)
+	}
+	return nil
+}
+
+// unsignedTxHash returns the hash the executor computes for an encoded unsigned tx
+func unsignedTxHash(batchL2Data []byte, forkID uint64) (common.Hash, error) {
+	txs, _, _, err := DecodeTxs(batchL2Data, forkID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	if len(txs) == 0 {
+		return common.Hash{}, ErrInvalidData
+	}
+	return txs[0].Hash(), nil
+}
@@ -0,0 +1,59 @@
+package state
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStateOverrideValidate(t *testing.T) {
+	addr := common.HexToAddress("0x1")
+	slots := map[common.Hash]common.Hash{common.HexToHash("0x1"): common.HexToHash("0x2")}
+
+	assert.NoError(t, StateOverride{addr: {State: slots}}.Validate())
+	assert.NoError(t, StateOverride{addr: {StateDiff: slots}}.Validate())
+
+	err := StateOverride{addr: {State: slots, StateDiff: slots}}.Validate()
+	assert.True(t, errors.Is(err, ErrStateAndStateDiffOverride))
+}
+
+func TestStateOverrideToExecutor(t *testing.T) {
+	assert.Nil(t, StateOverride{}.toExecutorV2())
+
+	addr := common.HexToAddress("0x1")
+	nonce := uint64(7)
+	so := StateOverride{
+		addr: {
+			Nonce:     &nonce,
+			Code:      []byte{0x60, 0x00},
+			Balance:   big.NewInt(1000),
+			StateDiff: map[common.Hash]common.Hash{common.HexToHash("0x1"): common.HexToHash("0x2")},
+		},
+	}
+
+	result := so.toExecutorV2()
+	require.Len(t, result, 1)
+	account := result[addr.String()]
+	require.NotNil(t, account)
+	assert.Equal(t, nonce, account.Nonce)
+	assert.Equal(t, []byte{0x60, 0x00}, account.Code)
+	assert.Equal(t, big.NewInt(1000).Bytes(), account.Balance)
+	assert.Nil(t, account.State)
+	assert.Equal(t, common.HexToHash("0x2").String(), account.StateDiff[common.HexToHash("0x1").String()])
+}
+
+func TestUnsignedTxOptionsSenderNonce(t *testing.T) {
+	sender := common.HexToAddress("0x1")
+	nonce := uint64(7)
+
+	var opts *unsignedTxOptions
+	assert.Equal(t, uint64(3), opts.senderNonce(sender, 3))
+
+	opts = &unsignedTxOptions{stateOverride: StateOverride{sender: {Nonce: &nonce}}}
+	assert.Equal(t, nonce, opts.senderNonce(sender, 3))
+	assert.Equal(t, uint64(3), opts.senderNonce(common.HexToAddress("0x2"), 3))
+}
@@ -0,0 +1,174 @@
+package pgstatestorage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+)
+
+// getAddressTransactionsBeforeSQL selects the txs of an address in the l2 blocks before a given one, starting from
+// the newest, until the page size is reached. The txs of the last l2 block of the page are never split
+const getAddressTransactionsBeforeSQL = `
+	t.hash IN (
+		SELECT a.tx_hash
+		  FROM state.address_transaction a
+		 WHERE a.address = $1 AND a.l2_block_num < $2
+		   AND a.l2_block_num >= COALESCE((
+		           SELECT p.l2_block_num
+		             FROM state.address_transaction p
+		            WHERE p.address = $1 AND p.l2_block_num < $2
+		            ORDER BY p.l2_block_num DESC, p.tx_index DESC
+		           OFFSET $3 - 1 LIMIT 1), 0))`
+
+// getAddressTransactionsAfterSQL selects the txs of an address in the l2 blocks after a given one, starting from
+// the oldest, until the page size is reached. The txs of the last l2 block of the page are never split
+const getAddressTransactionsAfterSQL = `
+	t.hash IN (
+		SELECT a.tx_hash
+		  FROM state.address_transaction a
+		 WHERE a.address = $1 AND a.l2_block_num > $2
+		   AND a.l2_block_num <= COALESCE((
+		           SELECT p.l2_block_num
+		             FROM state.address_transaction p
+		            WHERE p.address = $1 AND p.l2_block_num > $2
+		            ORDER BY p.l2_block_num ASC, p.tx_index ASC
+		           OFFSET $3 - 1 LIMIT 1), a.l2_block_num))`
+
+// addAddressTransactions indexes the txs of a l2 block by the addresses involved in them:
+// the sender, the receiver and the contract deployed by the tx
+func (p *PostgresStorage) addAddressTransactions(ctx context.Context, l2Block *state.L2Block, receipts []*types.Receipt, dbTx pgx.Tx) error {
+	if len(l2Block.Transactions()) == 0 {
+		return nil
+	}
+
+	contractAddresses := make(map[common.Hash]common.Address, len(receipts))
+	for _, receipt := range receipts {
+		if receipt.ContractAddress != state.ZeroAddress {
+			contractAddresses[receipt.TxHash] = receipt.ContractAddress
+		}
+	}
+
+	rows := [][]interface{}{}
+	for idx, tx := range l2Block.Transactions() {
+		from, err := state.GetSender(*tx)
+		if err != nil {
+			return err
+		}
+		addresses := []common.Address{from}
+		if tx.To() != nil && *tx.To() != from {
+			addresses = append(addresses, *tx.To())
+		}
+		if contractAddress, ok := contractAddresses[tx.Hash()]; ok {
+			addresses = append(addresses, contractAddress)
+		}
+		for _, address := range addresses {
+			rows = append(rows, []interface{}{address.String(), tx.Hash().String(), l2Block.NumberU64(), idx})
+		}
+	}
+
+	_, err := dbTx.CopyFrom(ctx, pgx.Identifier{"state", "address_transaction"},
+		[]string{"address", "tx_hash", "l2_block_num", "tx_index"}, pgx.CopyFromRows(rows))
+	return err
+}
+
+// GetReceiptsByAddressBefore returns the txs of an address in the l2 blocks before the given one along with their
+// receipts, at least pageSize txs are returned unless there are no more. The txs are sorted from the oldest to the newest
+func (p *PostgresStorage) GetReceiptsByAddressBefore(ctx context.Context, address common.Address, blockNumber uint64, pageSize uint64, dbTx pgx.Tx) ([]state.TransactionReceipt, error) {
+	q := p.getExecQuerier(dbTx)
+	rows, err := q.Query(ctx, fmt.Sprintf(getReceiptsSQL, getAddressTransactionsBeforeSQL), address.String(), blockNumber, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return scanReceipts(rows)
+}
+
+// GetReceiptsByAddressAfter returns the txs of an address in the l2 blocks after the given one along with their
+// receipts, at least pageSize txs are returned unless there are no more. The txs are sorted from the oldest to the newest
+func (p *PostgresStorage) GetReceiptsByAddressAfter(ctx context.Context, address common.Address, blockNumber uint64, pageSize uint64, dbTx pgx.Tx) ([]state.TransactionReceipt, error) {
+	q := p.getExecQuerier(dbTx)
+	rows, err := q.Query(ctx, fmt.Sprintf(getReceiptsSQL, getAddressTransactionsAfterSQL), address.String(), blockNumber, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return scanReceipts(rows)
+}
+
+// GetContractCreationReceipt returns the tx that deployed a contract along with its receipt,
+// the contracts deployed by other contracts are not found
+func (p *PostgresStorage) GetContractCreationReceipt(ctx context.Context, address common.Address, dbTx pgx.Tx) (*state.TransactionReceipt, error) {
+	q := p.getExecQuerier(dbTx)
+	rows, err := q.Query(ctx, fmt.Sprintf(getReceiptsSQL, "r.contract_address = $1"), address.String())
+	if err != nil {
+		return nil, err
+	}
+	receipts, err := scanReceipts(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(receipts) == 0 {
+		return nil, state.ErrNotFound
+	}
+	return &receipts[0], nil
+}
+
+// GetTransactionBySenderAndNonce returns the tx sent by an address with a given nonce,
+// the txs are looked up in the address index
+func (p *PostgresStorage) GetTransactionBySenderAndNonce(ctx context.Context, sender common.Address, nonce uint64, dbTx pgx.Tx) (*types.Transaction, error) {
+	const getTransactionBySenderAndNonceSQL = `
+		SELECT t.encoded
+		  FROM state.address_transaction a
+		 INNER JOIN state.transaction t ON t.hash = a.tx_hash
+		 WHERE a.address = $1 AND t.decoded->>'nonce' = $2`
+
+	q := p.getExecQuerier(dbTx)
+	rows, err := q.Query(ctx, getTransactionBySenderAndNonceSQL, sender.String(), hex.EncodeUint64(nonce))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	// The address is also indexed as the receiver of the txs, only the tx it sent is returned
+	for rows.Next() {
+		var encoded string
+		if err := rows.Scan(&encoded); err != nil {
+			return nil, err
+		}
+		tx, err := state.DecodeTx(encoded)
+		if err != nil {
+			return nil, err
+		}
+		from, err := state.GetSender(*tx)
+		if err != nil {
+			return nil, err
+		}
+		if from == sender {
+			return tx, nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return nil, state.ErrNotFound
+}
+
+// GetFirstAddressIndexedL2BlockNumber returns the first l2 block whose txs are indexed by address,
+// the txs of the l2 blocks stored before the index was created are not found by address
+func (p *PostgresStorage) GetFirstAddressIndexedL2BlockNumber(ctx context.Context, dbTx pgx.Tx) (uint64, error) {
+	const getFirstAddressIndexedL2BlockNumberSQL = "SELECT first_l2_block_num FROM state.address_transaction_index LIMIT 1"
+
+	var blockNumber uint64
+	q := p.getExecQuerier(dbTx)
+	err := q.QueryRow(ctx, getFirstAddressIndexedL2BlockNumberSQL).Scan(&blockNumber)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+	return blockNumber, nil
+}
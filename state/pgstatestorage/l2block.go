@@ -252,6 +252,12 @@ func (p *PostgresStorage) AddL2Block(ctx context.Context, batchNumber uint64, l2
 		if err != nil {
 			return err
 		}
+
+		// XLayer address index
+		err = p.addAddressTransactions(ctx, l2Block, receipts, dbTx)
+		if err != nil {
+			return err
+		}
 	}
 
 	if len(receipts) > 0 {
@@ -0,0 +1,128 @@
+package pgstatestorage
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/state"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+)
+
+// getReceiptsSQL loads the txs, receipts and logs of a set of l2 blocks, one row per log or per receipt without logs
+const getReceiptsSQL = `
+	SELECT r.tx_index, r.tx_hash, r.type, r.post_state, r.status, r.cumulative_gas_used, r.gas_used, r.contract_address, r.effective_gas_price,
+	       t.encoded, t.l2_hash, t.l2_block_num, b.block_hash, b.header->>'timestamp',
+	       l.log_index, l.address, l.data, l.topic0, l.topic1, l.topic2, l.topic3
+	  FROM state.receipt r
+	 INNER JOIN state.transaction t ON t.hash = r.tx_hash
+	 INNER JOIN state.l2block b ON b.block_num = t.l2_block_num
+	  LEFT JOIN state.log l ON l.tx_hash = r.tx_hash
+	 WHERE %s
+	 ORDER BY t.l2_block_num ASC, r.tx_index ASC, l.log_index ASC`
+
+// GetReceiptsByL2BlockNumber returns the txs of a l2 block along with their receipts and logs with a single query
+func (p *PostgresStorage) GetReceiptsByL2BlockNumber(ctx context.Context, blockNumber uint64, dbTx pgx.Tx) ([]state.TransactionReceipt, error) {
+	q := p.getExecQuerier(dbTx)
+	rows, err := q.Query(ctx, fmt.Sprintf(getReceiptsSQL, "b.block_num = $1"), blockNumber)
+	if err != nil {
+		return nil, err
+	}
+	return scanReceipts(rows)
+}
+
+// GetReceiptsByBatchNumber returns the txs of all the l2 blocks of a batch along with their receipts and logs with a single query
+func (p *PostgresStorage) GetReceiptsByBatchNumber(ctx context.Context, batchNumber uint64, dbTx pgx.Tx) ([]state.TransactionReceipt, error) {
+	q := p.getExecQuerier(dbTx)
+	rows, err := q.Query(ctx, fmt.Sprintf(getReceiptsSQL, "b.batch_num = $1"), batchNumber)
+	if err != nil {
+		return nil, err
+	}
+	return scanReceipts(rows)
+}
+
+func scanReceipts(rows pgx.Rows) ([]state.TransactionReceipt, error) {
+	defer rows.Close()
+
+	receipts := make([]state.TransactionReceipt, 0)
+	var current *state.TransactionReceipt
+	for rows.Next() {
+		var receipt types.Receipt
+		var txHash, contractAddress, encodedTx, blockHash, blockTime string
+		var l2Hash *string
+		var blockNumber uint64
+		var effectiveGasPrice *uint64
+		var logIndex *uint
+		var logAddress, logData, topic0, topic1, topic2, topic3 *string
+
+		err := rows.Scan(&receipt.TransactionIndex, &txHash, &receipt.Type, &receipt.PostState, &receipt.Status,
+			&receipt.CumulativeGasUsed, &receipt.GasUsed, &contractAddress, &effectiveGasPrice,
+			&encodedTx, &l2Hash, &blockNumber, &blockHash, &blockTime,
+			&logIndex, &logAddress, &logData, &topic0, &topic1, &topic2, &topic3)
+		if err != nil {
+			return nil, err
+		}
+
+		// The rows of the same receipt are consecutive, a new receipt starts when the tx hash changes
+		if current == nil || current.Receipt.TxHash != common.HexToHash(txHash) {
+			tx, err := state.DecodeTx(encodedTx)
+			if err != nil {
+				return nil, err
+			}
+			receipt.TxHash = common.HexToHash(txHash)
+			receipt.ContractAddress = common.HexToAddress(contractAddress)
+			receipt.BlockNumber = new(big.Int).SetUint64(blockNumber)
+			receipt.BlockHash = common.HexToHash(blockHash)
+			if effectiveGasPrice != nil {
+				receipt.EffectiveGasPrice = new(big.Int).SetUint64(*effectiveGasPrice)
+			}
+			receipt.Logs = []*types.Log{}
+
+			txReceipt := state.TransactionReceipt{Tx: *tx, Receipt: &receipt, BlockTime: hex.DecodeUint64(blockTime)}
+			if l2Hash != nil {
+				h := common.HexToHash(*l2Hash)
+				txReceipt.L2Hash = &h
+			}
+			receipts = append(receipts, txReceipt)
+			current = &receipts[len(receipts)-1]
+		}
+
+		if logIndex == nil {
+			continue
+		}
+		log := &types.Log{
+			Address:     common.HexToAddress(*logAddress),
+			Topics:      []common.Hash{},
+			BlockNumber: blockNumber,
+			TxHash:      current.Receipt.TxHash,
+			TxIndex:     current.Receipt.TransactionIndex,
+			BlockHash:   current.Receipt.BlockHash,
+			Index:       *logIndex,
+		}
+		if logData != nil {
+			log.Data, err = hex.DecodeHex(*logData)
+			if err != nil {
+				return nil, err
+			}
+		}
+		for _, topic := range []*string{topic0, topic1, topic2, topic3} {
+			if topic != nil {
+				log.Topics = append(log.Topics, common.HexToHash(*topic))
+			}
+		}
+		current.Receipt.Logs = append(current.Receipt.Logs, log)
+	}
+
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+
+	for i := range receipts {
+		receipts[i].Receipt.Bloom = types.CreateBloom(types.Receipts{receipts[i].Receipt})
+	}
+
+	return receipts, nil
+}
@@ -0,0 +1,32 @@
+package state
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// PreconfirmationStatus is the status of a preconfirmed tx
+type PreconfirmationStatus string
+
+const (
+	// PreconfirmationStatusUnconfirmed is the status of a tx executed by the sequencer in the WIP L2 block
+	PreconfirmationStatusUnconfirmed PreconfirmationStatus = "unconfirmed"
+	// PreconfirmationStatusConfirmed is the status of a preconfirmed tx once its L2 block is stored
+	PreconfirmationStatusConfirmed PreconfirmationStatus = "confirmed"
+	// PreconfirmationStatusReorged is the status of a preconfirmed tx that was not included in its L2 block
+	PreconfirmationStatusReorged PreconfirmationStatus = "reorged"
+)
+
+// Preconfirmation is the result of the execution of a tx by the sequencer before its L2 block is stored,
+// the block hash is only known once the tx is confirmed
+type Preconfirmation struct {
+	Status         PreconfirmationStatus `json:"status"`
+	TxHash         common.Hash           `json:"transactionHash"`
+	BlockNumber    hexutil.Uint64        `json:"blockNumber"`
+	BlockHash      *common.Hash          `json:"blockHash,omitempty"`
+	BlockTimestamp hexutil.Uint64        `json:"blockTimestamp"`
+	ReceiptStatus  hexutil.Uint64        `json:"receiptStatus"`
+	GasUsed        hexutil.Uint64        `json:"gasUsed"`
+	Logs           []*types.Log          `json:"logs"`
+}
@@ -0,0 +1,17 @@
+package state
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/0xPolygonHermez/zkevm-node/merkletree"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// GetAccountProof returns the merkle proofs of the balance, nonce, code hash and the given storage positions of an account
+func (s *State) GetAccountProof(ctx context.Context, address common.Address, positions []*big.Int, root common.Hash) (*merkletree.AccountProof, error) {
+	if s.tree == nil {
+		return nil, ErrStateTreeNil
+	}
+	return s.tree.GetAccountProof(ctx, address, positions, root.Bytes())
+}
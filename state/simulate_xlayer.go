@@ -0,0 +1,127 @@
+package state
+
+import (
+	"context"
+
+	"github.com/0xPolygonHermez/zkevm-node/hex"
+	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v4"
+)
+
+// SimulateBundle executes an ordered list of unsigned txs sent by the same sender
+// in a single virtual l2 block on top of the given l2 block, so every tx sees the
+// effects of the previous ones. Nothing is stored. The batch response is returned
+// along with the error when the bundle runs out of counters, so the caller can
+// report the used counters
+func (s *State) SimulateBundle(ctx context.Context, txs []*types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, stateOverride StateOverride, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
+	if len(txs) == 0 {
+		return nil, ErrEmptyBundle
+	}
+	if err := stateOverride.Validate(); err != nil {
+		return nil, err
+	}
+	if s.executorClient == nil {
+		return nil, ErrExecutorNil
+	}
+	if s.tree == nil {
+		return nil, ErrStateTreeNil
+	}
+
+	var l2Block *L2Block
+	var err error
+	if l2BlockNumber == nil {
+		l2Block, err = s.GetLastL2Block(ctx, dbTx)
+	} else {
+		l2Block, err = s.GetL2BlockByNumber(ctx, *l2BlockNumber, dbTx)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	batch, err := s.GetBatchByL2BlockNumber(ctx, l2Block.NumberU64(), dbTx)
+	if err != nil {
+		return nil, err
+	}
+
+	forkID := s.GetForkIDByBatchNumber(batch.BatchNumber)
+	if forkID < FORKID_ETROG {
+		return nil, ErrBundleForkIDNotSupported
+	}
+
+	loadedNonce, err := s.tree.GetNonce(ctx, senderAddress, l2Block.Root().Bytes())
+	if err != nil {
+		return nil, err
+	}
+	nonce := stateOverride.nonce(senderAddress, loadedNonce.Uint64())
+
+	// all the txs are appended to the same l2 block, consuming consecutive nonces
+	transactions := s.BuildChangeL2Block(uint32(0), uint32(0))
+	for _, tx := range txs {
+		txNonce := nonce
+		batchL2Data, err := EncodeUnsignedTransaction(*tx, s.cfg.ChainID, &txNonce, forkID)
+		if err != nil {
+			log.Errorf("error encoding unsigned transaction ", err)
+			return nil, err
+		}
+		transactions = append(transactions, batchL2Data...)
+		nonce++
+	}
+
+	processBatchRequestV2 := &executor.ProcessBatchRequestV2{
+		From:             senderAddress.String(),
+		OldBatchNum:      batch.BatchNumber,
+		OldStateRoot:     l2Block.Root().Bytes(),
+		OldAccInputHash:  batch.AccInputHash.Bytes(),
+		Coinbase:         batch.Coinbase.String(),
+		ForkId:           forkID,
+		BatchL2Data:      transactions,
+		ChainId:          s.cfg.ChainID,
+		UpdateMerkleTree: cFalse,
+		ContextId:        uuid.NewString(),
+
+		// v2 fields
+		L1InfoRoot:             l2Block.BlockInfoRoot().Bytes(),
+		TimestampLimit:         l2Block.Time(),
+		SkipFirstChangeL2Block: cFalse,
+		SkipWriteBlockInfoRoot: cTrue,
+
+		// XLayer state override
+		StateOverride: stateOverride.toExecutorV2(),
+	}
+
+	log.Debugf("SimulateBundle[processBatchRequestV2.OldBatchNum]: %v", processBatchRequestV2.OldBatchNum)
+	log.Debugf("SimulateBundle[processBatchRequestV2.OldStateRoot]: %v", hex.EncodeToHex(processBatchRequestV2.OldStateRoot))
+	log.Debugf("SimulateBundle[processBatchRequestV2.From]: %v", processBatchRequestV2.From)
+	log.Debugf("SimulateBundle[processBatchRequestV2.ContextId]: %v", processBatchRequestV2.ContextId)
+	log.Debugf("SimulateBundle[txs]: %v", len(txs))
+
+	processBatchResponseV2, err := s.executorClient.ProcessBatchV2(ctx, processBatchRequestV2)
+	if err != nil {
+		log.Errorf("error simulating bundle: %v", err)
+		return nil, err
+	}
+	if processBatchResponseV2.Error != executor.ExecutorError_EXECUTOR_ERROR_NO_ERROR {
+		err = executor.ExecutorErr(processBatchResponseV2.Error)
+		s.eventLog.LogExecutorErrorV2(ctx, processBatchResponseV2.Error, processBatchRequestV2)
+		return nil, err
+	}
+
+	response, err := s.convertToProcessBatchResponseV2(processBatchResponseV2)
+	if err != nil {
+		return nil, err
+	}
+
+	if processBatchResponseV2.ErrorRom != executor.RomError_ROM_ERROR_NO_ERROR {
+		err = executor.RomErr(processBatchResponseV2.ErrorRom)
+		if executor.IsROMOutOfCountersError(executor.RomErrorCode(err)) {
+			return response, err
+		}
+		return nil, err
+	}
+
+	return response, nil
+}
@@ -101,32 +101,8 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 	var response *ProcessTransactionResponse
 	var startTime, endTime time.Time
 	if forkId < FORKID_ETROG {
-		traceConfigRequest := &executor.TraceConfig{
-			TxHashToGenerateFullTrace: transactionHash.Bytes(),
-			// set the defaults to the maximum information we can have.
-			// this is needed to process custom tracers later
-			DisableStorage:   cFalse,
-			DisableStack:     cFalse,
-			EnableMemory:     cTrue,
-			EnableReturnData: cTrue,
-		}
-
-		// if the default tracer is used, then we review the information
-		// we want to have in the trace related to the parameters we received.
-		if traceConfig.IsDefaultTracer() {
-			if traceConfig.DisableStorage {
-				traceConfigRequest.DisableStorage = cTrue
-			}
-			if traceConfig.DisableStack {
-				traceConfigRequest.DisableStack = cTrue
-			}
-			if !traceConfig.EnableMemory {
-				traceConfigRequest.EnableMemory = cFalse
-			}
-			if !traceConfig.EnableReturnData {
-				traceConfigRequest.EnableReturnData = cFalse
-			}
-		}
+		traceConfigRequest := newExecutorTraceConfig(transactionHash, traceConfig)
+
 		// generate batch l2 data for the transaction
 		batchL2Data, err := EncodeTransactions(txsToEncode, effectivePercentage, forkId)
 		if err != nil {
@@ -180,32 +156,7 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 		}
 		response = convertedResponse.BlockResponses[0].TransactionResponses[0]
 	} else {
-		traceConfigRequestV2 := &executor.TraceConfigV2{
-			TxHashToGenerateFullTrace: transactionHash.Bytes(),
-			// set the defaults to the maximum information we can have.
-			// this is needed to process custom tracers later
-			DisableStorage:   cFalse,
-			DisableStack:     cFalse,
-			EnableMemory:     cTrue,
-			EnableReturnData: cTrue,
-		}
-
-		// if the default tracer is used, then we review the information
-		// we want to have in the trace related to the parameters we received.
-		if traceConfig.IsDefaultTracer() {
-			if traceConfig.DisableStorage {
-				traceConfigRequestV2.DisableStorage = cTrue
-			}
-			if traceConfig.DisableStack {
-				traceConfigRequestV2.DisableStack = cTrue
-			}
-			if !traceConfig.EnableMemory {
-				traceConfigRequestV2.EnableMemory = cFalse
-			}
-			if !traceConfig.EnableReturnData {
-				traceConfigRequestV2.EnableReturnData = cFalse
-			}
-		}
+		traceConfigRequestV2 := newExecutorTraceConfigV2(transactionHash, traceConfig)
 
 		// if the l2 block number is 1, it means this is a network that started
 		// at least on Etrog fork, in this case the l2 block 1 will contain the
@@ -388,8 +339,6 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 		return nil, fmt.Errorf("failed to parse gasPrice")
 	}
 
-	// select and prepare tracer
-	var tracer tracers.Tracer
 	tracerContext := &tracers.Context{
 		BlockHash:   receipt.BlockHash,
 		BlockNumber: receipt.BlockNumber,
@@ -397,6 +346,85 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 		TxHash:      transactionHash,
 	}
 
+	traceResult, err := s.parseTrace(result, *receipt, tracerContext, gasPrice, batch.StateRoot.Bytes(), traceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	result.TraceResult = traceResult
+
+	return result, nil
+}
+
+// newExecutorTraceConfig builds the executor trace config to generate the full trace of the given tx
+func newExecutorTraceConfig(txHash common.Hash, traceConfig TraceConfig) *executor.TraceConfig {
+	traceConfigRequest := &executor.TraceConfig{
+		TxHashToGenerateFullTrace: txHash.Bytes(),
+		// set the defaults to the maximum information we can have.
+		// this is needed to process custom tracers later
+		DisableStorage:   cFalse,
+		DisableStack:     cFalse,
+		EnableMemory:     cTrue,
+		EnableReturnData: cTrue,
+	}
+
+	// if the default tracer is used, then we review the information
+	// we want to have in the trace related to the parameters we received.
+	if traceConfig.IsDefaultTracer() {
+		if traceConfig.DisableStorage {
+			traceConfigRequest.DisableStorage = cTrue
+		}
+		if traceConfig.DisableStack {
+			traceConfigRequest.DisableStack = cTrue
+		}
+		if !traceConfig.EnableMemory {
+			traceConfigRequest.EnableMemory = cFalse
+		}
+		if !traceConfig.EnableReturnData {
+			traceConfigRequest.EnableReturnData = cFalse
+		}
+	}
+	return traceConfigRequest
+}
+
+// newExecutorTraceConfigV2 builds the executor trace config V2 to generate the full trace of the given tx
+func newExecutorTraceConfigV2(txHash common.Hash, traceConfig TraceConfig) *executor.TraceConfigV2 {
+	traceConfigRequestV2 := &executor.TraceConfigV2{
+		TxHashToGenerateFullTrace: txHash.Bytes(),
+		// set the defaults to the maximum information we can have.
+		// this is needed to process custom tracers later
+		DisableStorage:   cFalse,
+		DisableStack:     cFalse,
+		EnableMemory:     cTrue,
+		EnableReturnData: cTrue,
+	}
+
+	// if the default tracer is used, then we review the information
+	// we want to have in the trace related to the parameters we received.
+	if traceConfig.IsDefaultTracer() {
+		if traceConfig.DisableStorage {
+			traceConfigRequestV2.DisableStorage = cTrue
+		}
+		if traceConfig.DisableStack {
+			traceConfigRequestV2.DisableStack = cTrue
+		}
+		if !traceConfig.EnableMemory {
+			traceConfigRequestV2.EnableMemory = cFalse
+		}
+		if !traceConfig.EnableReturnData {
+			traceConfigRequestV2.EnableReturnData = cFalse
+		}
+	}
+	return traceConfigRequestV2
+}
+
+// parseTrace parses the full trace of the execution result using the tracer
+// selected in the trace config
+func (s *State) parseTrace(result *runtime.ExecutionResult, receipt types.Receipt, tracerContext *tracers.Context, gasPrice *big.Int, stateRoot []byte, traceConfig TraceConfig) (json.RawMessage, error) {
+	// select and prepare tracer
+	var tracer tracers.Tracer
+	var err error
+
 	if traceConfig.IsDefaultTracer() {
 		structLoggerCfg := structlogger.Config{
 			EnableMemory:     traceConfig.EnableMemory,
@@ -405,12 +433,7 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 			EnableReturnData: traceConfig.EnableReturnData,
 		}
 		tracer := structlogger.NewStructLogger(structLoggerCfg)
-		traceResult, err := tracer.ParseTrace(result, *receipt)
-		if err != nil {
-			return nil, err
-		}
-		result.TraceResult = traceResult
-		return result, nil
+		return tracer.ParseTrace(result, receipt)
 	} else if traceConfig.Is4ByteTracer() {
 		tracer, err = native.NewFourByteTracer(tracerContext, traceConfig.TracerConfig)
 		if err != nil {
@@ -431,6 +454,12 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 			return nil, fmt.Errorf("failed to create flatCallTracer, err: %v", err)
 		}
 		tracer = native.SetFlatCallTracerLimit(tracer, traceConfig.Limit)
+	} else if traceConfig.IsMuxTracer() {
+		tracer, err = native.NewMuxTracer(tracerContext, traceConfig.TracerConfig)
+		if err != nil {
+			log.Errorf("debug transaction: failed to create muxTracer, err: %v", err)
+			return nil, fmt.Errorf("failed to create muxTracer, err: %v", err)
+		}
 	} else if traceConfig.IsNoopTracer() {
 		tracer, err = native.NewNoopTracer(tracerContext, traceConfig.TracerConfig)
 		if err != nil {
@@ -453,7 +482,7 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 		return nil, fmt.Errorf("invalid tracer: %v, err: %v", traceConfig.Tracer, err)
 	}
 
-	fakeDB := &FakeDB{State: s, stateRoot: batch.StateRoot.Bytes()}
+	fakeDB := &FakeDB{State: s, stateRoot: stateRoot}
 	evm := fakevm.NewFakeEVM(fakevm.BlockContext{BlockNumber: big.NewInt(1)}, fakevm.TxContext{GasPrice: gasPrice}, fakeDB, params.TestChainConfig, fakevm.Config{Debug: true, Tracer: tracer})
 
 	traceResult, err := s.buildTrace(evm, result, tracer)
@@ -462,9 +491,7 @@ func (s *State) DebugTransaction(ctx context.Context, transactionHash common.Has
 		return nil, fmt.Errorf("failed parse the trace using the tracer: %v", err)
 	}
 
-	result.TraceResult = traceResult
-
-	return result, nil
+	return traceResult, nil
 }
 
 // ParseTheTraceUsingTheTracer parses the given trace with the given tracer.
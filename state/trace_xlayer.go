@@ -0,0 +1,105 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/executor"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/instrumentation"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime/instrumentation/tracers"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/jackc/pgx/v4"
+)
+
+// DebugCall executes an unsigned tx on top of the given l2 block, applying the
+// state and block overrides, and generates its trace without storing anything
+func (s *State) DebugCall(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, stateOverride StateOverride, blockOverrides *BlockOverrides, traceConfig TraceConfig, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+	if err := stateOverride.Validate(); err != nil {
+		return nil, err
+	}
+
+	var l2Block *L2Block
+	var err error
+	if l2BlockNumber == nil {
+		l2Block, err = s.GetLastL2Block(ctx, dbTx)
+	} else {
+		l2Block, err = s.GetL2BlockByNumber(ctx, *l2BlockNumber, dbTx)
+	}
+	if err != nil {
+		return nil, err
+	}
+	blockNumber := l2Block.NumberU64()
+
+	opts := &unsignedTxOptions{
+		stateOverride:  stateOverride,
+		blockOverrides: blockOverrides,
+		traceConfig:    &traceConfig,
+	}
+	startTime := time.Now()
+	response, err := s.internalProcessUnsignedTransaction(ctx, tx, senderAddress, &blockNumber, true, opts, dbTx)
+	endTime := time.Now()
+	// errors raised by the tx execution are part of the trace
+	if err != nil && (response == nil || executor.IsROMOutOfCountersError(executor.RomErrorCode(err))) {
+		return nil, err
+	}
+	if len(response.BlockResponses) == 0 || len(response.BlockResponses[0].TransactionResponses) == 0 {
+		return nil, fmt.Errorf("%w: the executor didn't return the tx response", ErrInvalidData)
+	}
+	txResponse := response.BlockResponses[0].TransactionResponses[0]
+
+	result := &runtime.ExecutionResult{
+		CreateAddress: txResponse.CreateAddress,
+		GasLeft:       txResponse.GasLeft,
+		GasUsed:       txResponse.GasUsed,
+		ReturnValue:   txResponse.ReturnValue,
+		StateRoot:     txResponse.StateRoot.Bytes(),
+		FullTrace:     txResponse.FullTrace,
+		Err:           txResponse.RomError,
+	}
+
+	traceContext := instrumentation.Context{
+		From:         senderAddress.String(),
+		Input:        tx.Data(),
+		Gas:          tx.Gas(),
+		Value:        tx.Value(),
+		Output:       result.ReturnValue,
+		GasPrice:     tx.GasPrice().String(),
+		OldStateRoot: l2Block.Root(),
+		Time:         uint64(endTime.Sub(startTime)),
+		GasUsed:      result.GasUsed,
+	}
+	if tx.To() == nil {
+		traceContext.Type = "CREATE"
+		traceContext.To = result.CreateAddress.Hex()
+	} else {
+		traceContext.Type = "CALL"
+		traceContext.To = tx.To().Hex()
+	}
+	result.FullTrace.Context = traceContext
+
+	// the call is not mined, so the receipt only has the execution outcome
+	receipt := types.Receipt{
+		TxHash:      txResponse.TxHash,
+		GasUsed:     result.GasUsed,
+		BlockNumber: l2Block.Number(),
+		Status:      types.ReceiptStatusSuccessful,
+	}
+	if result.Failed() {
+		receipt.Status = types.ReceiptStatusFailed
+	}
+	tracerContext := &tracers.Context{
+		BlockNumber: l2Block.Number(),
+		TxHash:      txResponse.TxHash,
+	}
+
+	traceResult, err := s.parseTrace(result, receipt, tracerContext, tx.GasPrice(), l2Block.Root().Bytes(), traceConfig)
+	if err != nil {
+		return nil, err
+	}
+	result.TraceResult = traceResult
+
+	return result, nil
+}
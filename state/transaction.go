@@ -299,7 +299,7 @@ func (s *State) StoreL2Block(ctx context.Context, batchNumber uint64, l2Block *P
 
 // PreProcessUnsignedTransaction processes the unsigned transaction in order to calculate its zkCounters
 func (s *State) PreProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, sender common.Address, l2BlockNumber *uint64, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
-	response, err := s.internalProcessUnsignedTransaction(ctx, tx, sender, l2BlockNumber, false, dbTx)
+	response, err := s.internalProcessUnsignedTransaction(ctx, tx, sender, l2BlockNumber, false, nil, dbTx)
 	if err != nil {
 		return response, err
 	}
@@ -314,7 +314,7 @@ func (s *State) PreProcessTransaction(ctx context.Context, tx *types.Transaction
 		return nil, err
 	}
 
-	response, err := s.internalProcessUnsignedTransaction(ctx, tx, sender, nil, false, dbTx)
+	response, err := s.internalProcessUnsignedTransaction(ctx, tx, sender, nil, false, nil, dbTx)
 	if err != nil {
 		return response, err
 	}
@@ -324,8 +324,13 @@ func (s *State) PreProcessTransaction(ctx context.Context, tx *types.Transaction
 
 // ProcessUnsignedTransaction processes the given unsigned transaction.
 func (s *State) ProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+	return s.processUnsignedTransaction(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, nil, dbTx)
+}
+
+// processUnsignedTransaction processes the given unsigned transaction applying the options
+func (s *State) processUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, opts *unsignedTxOptions, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
 	result := new(runtime.ExecutionResult)
-	response, err := s.internalProcessUnsignedTransaction(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, dbTx)
+	response, err := s.internalProcessUnsignedTransaction(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, opts, dbTx)
 	if err != nil {
 		return nil, err
 	}
@@ -347,7 +352,7 @@ func (s *State) ProcessUnsignedTransaction(ctx context.Context, tx *types.Transa
 }
 
 // internalProcessUnsignedTransaction processes the given unsigned transaction.
-func (s *State) internalProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
+func (s *State) internalProcessUnsignedTransaction(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, opts *unsignedTxOptions, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
 	var l2Block *L2Block
 	var err error
 	if l2BlockNumber == nil {
@@ -366,15 +371,15 @@ func (s *State) internalProcessUnsignedTransaction(ctx context.Context, tx *type
 
 	forkID := s.GetForkIDByBatchNumber(batch.BatchNumber)
 	if forkID < FORKID_ETROG {
-		return s.internalProcessUnsignedTransactionV1(ctx, tx, senderAddress, *batch, *l2Block, forkID, noZKEVMCounters, dbTx)
+		return s.internalProcessUnsignedTransactionV1(ctx, tx, senderAddress, *batch, *l2Block, forkID, noZKEVMCounters, opts, dbTx)
 	} else {
-		return s.internalProcessUnsignedTransactionV2(ctx, tx, senderAddress, *batch, *l2Block, forkID, noZKEVMCounters, dbTx)
+		return s.internalProcessUnsignedTransactionV2(ctx, tx, senderAddress, *batch, *l2Block, forkID, noZKEVMCounters, opts, dbTx)
 	}
 }
 
 // internalProcessUnsignedTransactionV1 processes the given unsigned transaction.
 // pre ETROG
-func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *types.Transaction, senderAddress common.Address, batch Batch, l2Block L2Block, forkID uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
+func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *types.Transaction, senderAddress common.Address, batch Batch, l2Block L2Block, forkID uint64, noZKEVMCounters bool, opts *unsignedTxOptions, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
 	var attempts = 1
 
 	if s.executorClient == nil {
@@ -398,7 +403,7 @@ func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *ty
 	if err != nil {
 		return nil, err
 	}
-	nonce := loadedNonce.Uint64()
+	nonce := opts.senderNonce(senderAddress, loadedNonce.Uint64())
 
 	batchL2Data, err := EncodeUnsignedTransaction(*tx, s.cfg.ChainID, &nonce, forkID)
 	if err != nil {
@@ -426,6 +431,9 @@ func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *ty
 	if noZKEVMCounters {
 		processBatchRequestV1.NoCounters = cTrue
 	}
+	if err := s.applyUnsignedTxOptions(opts, processBatchRequestV1, l2Block, batchL2Data, forkID); err != nil {
+		return nil, err
+	}
 	log.Debugf("internalProcessUnsignedTransactionV1[processBatchRequestV1.From]: %v", processBatchRequestV1.From)
 	log.Debugf("internalProcessUnsignedTransactionV1[processBatchRequestV1.OldBatchNum]: %v", processBatchRequestV1.OldBatchNum)
 	log.Debugf("internalProcessUnsignedTransactionV1[processBatchRequestV1.OldStateRoot]: %v", hex.EncodeToHex(processBatchRequestV1.OldStateRoot))
@@ -502,7 +510,7 @@ func (s *State) internalProcessUnsignedTransactionV1(ctx context.Context, tx *ty
 
 // internalProcessUnsignedTransactionV2 processes the given unsigned transaction.
 // post ETROG
-func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *types.Transaction, senderAddress common.Address, batch Batch, l2Block L2Block, forkID uint64, noZKEVMCounters bool, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
+func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *types.Transaction, senderAddress common.Address, batch Batch, l2Block L2Block, forkID uint64, noZKEVMCounters bool, opts *unsignedTxOptions, dbTx pgx.Tx) (*ProcessBatchResponse, error) {
 	var attempts = 1
 
 	if s.executorClient == nil {
@@ -516,7 +524,7 @@ func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *ty
 	if err != nil {
 		return nil, err
 	}
-	nonce := loadedNonce.Uint64()
+	nonce := opts.senderNonce(senderAddress, loadedNonce.Uint64())
 
 	transactions := s.BuildChangeL2Block(uint32(0), uint32(0))
 
@@ -550,6 +558,9 @@ func (s *State) internalProcessUnsignedTransactionV2(ctx context.Context, tx *ty
 	if noZKEVMCounters {
 		processBatchRequestV2.NoCounters = cTrue
 	}
+	if err := s.applyUnsignedTxOptionsV2(opts, processBatchRequestV2, l2Block, batchL2Data, forkID); err != nil {
+		return nil, err
+	}
 
 	log.Debugf("internalProcessUnsignedTransactionV2[processBatchRequestV2.OldBatchNum]: %v", processBatchRequestV2.OldBatchNum)
 	log.Debugf("internalProcessUnsignedTransactionV2[processBatchRequestV2.OldStateRoot]: %v", hex.EncodeToHex(processBatchRequestV2.OldStateRoot))
@@ -714,6 +725,12 @@ func CheckSupersetBatchTransactions(existingTxHashes []common.Hash, processedTxs
 
 // EstimateGas for a transaction
 func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common.Address, isGasFreeSender bool, l2BlockNumber *uint64, dbTx pgx.Tx) (uint64, []byte, error) {
+	return s.internalEstimateGas(transaction, senderAddress, isGasFreeSender, l2BlockNumber, nil, dbTx)
+}
+
+// internalEstimateGas estimates the gas of a transaction on top of the state
+// modified by the state override set
+func (s *State) internalEstimateGas(transaction *types.Transaction, senderAddress common.Address, isGasFreeSender bool, l2BlockNumber *uint64, stateOverride StateOverride, dbTx pgx.Tx) (uint64, []byte, error) {
 	const ethTransferGas = 21000
 
 	ctx := context.Background()
@@ -755,7 +772,7 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 	if err != nil {
 		return 0, nil, err
 	}
-	nonce := loadedNonce.Uint64()
+	nonce := stateOverride.nonce(senderAddress, loadedNonce.Uint64())
 
 	t4 := time.Now()
 	getNonceTime := t4.Sub(t3)
@@ -772,6 +789,7 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 		} else if err != nil {
 			return 0, nil, err
 		}
+		senderBalance = stateOverride.balance(senderAddress, senderBalance)
 
 		availableBalance := new(big.Int).Set(senderBalance)
 		// check if the account has funds to pay the transfer value
@@ -810,6 +828,9 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 		receiver := *transaction.To()
 		// check if the receiver address is not a smart contract
 		code, err := s.tree.GetCode(ctx, receiver, l2Block.Root().Bytes())
+		if overriddenCode, found := stateOverride.code(receiver); found {
+			code, err = overriddenCode, nil
+		}
 		if err != nil {
 			log.Warnf("error while getting code for address %v: %v", receiver.String(), err)
 		} else if len(code) == 0 {
@@ -833,9 +854,9 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 	log.Debugf("Estimate gas. Trying to execute TX with %v gas", highEnd)
 	var estimationResult *testGasEstimationResult
 	if forkID < FORKID_ETROG {
-		estimationResult, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, isGasFreeSender, highEnd, nonce, false)
+		estimationResult, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, isGasFreeSender, highEnd, nonce, stateOverride, false)
 	} else {
-		estimationResult, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, isGasFreeSender, highEnd, nonce, false)
+		estimationResult, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, isGasFreeSender, highEnd, nonce, stateOverride, false)
 	}
 	if err != nil {
 		return 0, nil, err
@@ -870,9 +891,9 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 	optimisticGasLimit := (estimationResult.gasUsed + estimationResult.gasRefund + params.CallStipend) * 64 / 63 // nolint:gomnd
 	if optimisticGasLimit < highEnd {
 		if forkID < FORKID_ETROG {
-			estimationResult, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, isGasFreeSender, optimisticGasLimit, nonce, false)
+			estimationResult, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, isGasFreeSender, optimisticGasLimit, nonce, stateOverride, false)
 		} else {
-			estimationResult, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, isGasFreeSender, optimisticGasLimit, nonce, false)
+			estimationResult, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, isGasFreeSender, optimisticGasLimit, nonce, stateOverride, false)
 		}
 		if err != nil {
 			// This should not happen under normal conditions since if we make it this far the
@@ -900,9 +921,9 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 
 		log.Debugf("Estimate gas. Trying to execute TX with %v gas", mid)
 		if forkID < FORKID_ETROG {
-			estimationResult, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, isGasFreeSender, mid, nonce, true)
+			estimationResult, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, isGasFreeSender, mid, nonce, stateOverride, true)
 		} else {
-			estimationResult, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, isGasFreeSender, mid, nonce, true)
+			estimationResult, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, isGasFreeSender, mid, nonce, stateOverride, true)
 		}
 		executionTime := time.Since(txExecutionStart)
 		totalExecutionTime += executionTime
@@ -940,7 +961,7 @@ func (s *State) EstimateGas(transaction *types.Transaction, senderAddress common
 // before ETROG
 func (s *State) internalTestGasEstimationTransactionV1(ctx context.Context, batch *Batch, l2Block *L2Block, latestL2BlockNumber uint64,
 	transaction *types.Transaction, forkID uint64, senderAddress common.Address, isGasFreeSender bool,
-	gas uint64, nonce uint64, shouldOmitErr bool) (*testGasEstimationResult, error) {
+	gas uint64, nonce uint64, stateOverride StateOverride, shouldOmitErr bool) (*testGasEstimationResult, error) {
 	timestamp := l2Block.Time()
 	if l2Block.NumberU64() == latestL2BlockNumber {
 		timestamp = uint64(time.Now().Unix())
@@ -982,6 +1003,9 @@ func (s *State) internalTestGasEstimationTransactionV1(ctx context.Context, batc
 		// v1 fields
 		GlobalExitRoot: batch.GlobalExitRoot.Bytes(),
 		EthTimestamp:   timestamp,
+
+		// XLayer state override
+		StateOverride: stateOverride.toExecutor(),
 	}
 
 	log.Debugf("EstimateGas[processBatchRequestV1.From]: %v", processBatchRequestV1.From)
@@ -1047,7 +1071,7 @@ func (s *State) internalTestGasEstimationTransactionV1(ctx context.Context, batc
 // after ETROG
 func (s *State) internalTestGasEstimationTransactionV2(ctx context.Context, batch *Batch, l2Block *L2Block, latestL2BlockNumber uint64,
 	transaction *types.Transaction, forkID uint64, senderAddress common.Address, isGasFreeSender bool,
-	gas uint64, nonce uint64, shouldOmitErr bool) (*testGasEstimationResult, error) {
+	gas uint64, nonce uint64, stateOverride StateOverride, shouldOmitErr bool) (*testGasEstimationResult, error) {
 	deltaTimestamp := uint32(uint64(time.Now().Unix()) - l2Block.Time())
 	transactions := s.BuildChangeL2Block(deltaTimestamp, uint32(0))
 
@@ -1091,6 +1115,9 @@ func (s *State) internalTestGasEstimationTransactionV2(ctx context.Context, batc
 		TimestampLimit:         uint64(time.Now().Unix()),
 		SkipFirstChangeL2Block: cTrue,
 		SkipWriteBlockInfoRoot: cTrue,
+
+		// XLayer state override
+		StateOverride: stateOverride.toExecutorV2(),
 	}
 
 	log.Debugf("EstimateGas[processBatchRequestV2.From]: %v", processBatchRequestV2.From)
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/0xPolygonHermez/zkevm-node/log"
+	"github.com/0xPolygonHermez/zkevm-node/state/runtime"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -139,9 +140,9 @@ func (s *State) EstimateGasOpt(transaction *types.Transaction, senderAddress com
 	log.Debugf("Estimate gas. Trying to execute TX with %v gas", highEnd)
 	var estimationResult *testGasEstimationResult
 	if forkID < FORKID_ETROG {
-		estimationResult, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, isGasFreeSender, highEnd, nonce, false)
+		estimationResult, err = s.internalTestGasEstimationTransactionV1(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, isGasFreeSender, highEnd, nonce, nil, false)
 	} else {
-		estimationResult, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, isGasFreeSender, highEnd, nonce, false)
+		estimationResult, err = s.internalTestGasEstimationTransactionV2(ctx, batch, l2Block, latestL2BlockNumber, transaction, forkID, senderAddress, isGasFreeSender, highEnd, nonce, nil, false)
 	}
 	if err != nil {
 		return 0, nil, err
@@ -171,3 +172,22 @@ func (s *State) EstimateGasOpt(transaction *types.Transaction, senderAddress com
 
 	return estimationResult.gasUsed, nil, nil
 }
+
+// ProcessUnsignedTransactionWithStateOverride processes the given unsigned
+// transaction on top of the state modified by the state override set
+func (s *State) ProcessUnsignedTransactionWithStateOverride(ctx context.Context, tx *types.Transaction, senderAddress common.Address, l2BlockNumber *uint64, noZKEVMCounters bool, stateOverride StateOverride, dbTx pgx.Tx) (*runtime.ExecutionResult, error) {
+	if err := stateOverride.Validate(); err != nil {
+		return nil, err
+	}
+	return s.processUnsignedTransaction(ctx, tx, senderAddress, l2BlockNumber, noZKEVMCounters, &unsignedTxOptions{stateOverride: stateOverride}, dbTx)
+}
+
+// EstimateGasWithStateOverride estimates the gas of a transaction on top of the
+// state modified by the state override set. The estimation always runs the full
+// binary search since the optimized paths can't account for the overrides
+func (s *State) EstimateGasWithStateOverride(transaction *types.Transaction, senderAddress common.Address, isGasFreeSender bool, l2BlockNumber *uint64, stateOverride StateOverride, dbTx pgx.Tx) (uint64, []byte, error) {
+	if err := stateOverride.Validate(); err != nil {
+		return 0, nil, err
+	}
+	return s.internalEstimateGas(transaction, senderAddress, isGasFreeSender, l2BlockNumber, stateOverride, dbTx)
+}
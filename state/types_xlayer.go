@@ -1,6 +1,24 @@
 package state
 
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
 // IsFlatCallTracer returns true when should use flatCallTracer
 func (t *TraceConfig) IsFlatCallTracer() bool {
 	return t.Tracer != nil && *t.Tracer == "flatCallTracer"
 }
+
+// IsMuxTracer returns true when should use muxTracer
+func (t *TraceConfig) IsMuxTracer() bool {
+	return t.Tracer != nil && *t.Tracer == "muxTracer"
+}
+
+// TransactionReceipt is a l2 tx along with its receipt, its l2 hash and the timestamp of its l2 block
+type TransactionReceipt struct {
+	Tx        types.Transaction
+	Receipt   *types.Receipt
+	L2Hash    *common.Hash
+	BlockTime uint64
+}